@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	cmdcore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/auth"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/spf13/cobra"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using sphinx.
+const (
+	authShort = `Manages the tokens flytectl authenticates with, like login, logout, status and token.`
+	authLong  = `
+The tokens of the Pkce and DeviceFlow auth types are cached in the keyring of the OS, per admin endpoint and config
+context, and refreshed when they expire.
+
+When the keyring is unavailable, e.g. in containers, CI runners and SSH sessions without a D-Bus secret service, the
+tokens are cached in the ~/.flyte/tokens.enc file instead, encrypted with the key of the FLYTECTL_TOKEN_CACHE_KEY env
+var or else a random key generated in the ~/.flyte/tokens.enc.key file. Both files are only readable by their owner,
+which is all that keeps the tokens from the other users with the key file: only the env var, kept out of the home dir,
+makes the file confidential on its own, e.g. in backups. The cache can be selected in the config:
+::
+
+ tokenCache:
+   # auto, keyring or file
+   type: file
+   path: /home/user/.flyte/tokens.enc
+
+To log in again, e.g. as another user:
+::
+
+ flytectl auth login
+
+To delete the cached token:
+::
+
+ flytectl auth logout
+
+To print the identity, expiry and scopes of the cached token:
+::
+
+ flytectl auth status
+
+To print a valid access token, e.g. for curl or grpcurl:
+::
+
+ grpcurl -H "authorization: Bearer $(flytectl auth token)" flyte.myexample.com:443 flyteidl.service.AdminService/ListProjects
+`
+
+	loginShort = `Logs in to the admin endpoint.`
+	loginLong  = `
+Runs the auth flow of the auth type of the config even if a valid token is cached, and caches the new token. The Pkce
+flow opens the browser and the DeviceFlow flow prints a code to enter in the browser.
+::
+
+ flytectl auth login
+
+Logs in to the admin endpoint of a context:
+::
+
+ flytectl auth login --context production
+
+The ClientSecret and ExternalCommand auth types only check that a token can be obtained, their tokens not being cached.
+
+Usage
+`
+
+	logoutShort = `Deletes the cached token of the admin endpoint.`
+	logoutLong  = `
+Deletes the cached token of the admin endpoint. The next command logs in again.
+::
+
+ flytectl auth logout
+
+Usage
+`
+
+	statusShort = `Prints the identity, expiry and scopes of the cached token.`
+	statusLong  = `
+Prints the identity, expiry and scopes decoded from the cached access token, without contacting the admin endpoint.
+::
+
+ flytectl auth status
+
+The signature of the token isn't verified, and an opaque token only shows its expiry.
+
+Usage
+`
+
+	tokenShort = `Prints a valid access token.`
+	tokenLong  = `
+Prints the cached access token, refreshing it first when it expires within the refresh grace period, e.g. to call
+Flyte admin with curl:
+::
+
+ curl -H "Authorization: Bearer $(flytectl auth token)" https://flyte.myexample.com/api/v1/projects
+
+The command fails when the token can't be refreshed, which requires flytectl auth login.
+
+Usage
+`
+)
+
+const (
+	statusValid       = "valid"
+	statusExpired     = "expired"
+	statusNotLoggedIn = "not logged in"
+	statusNotCached   = "not cached"
+)
+
+// tokenStatus is the view of the cached token printed by status
+type tokenStatus struct {
+	Endpoint    string   `json:"endpoint"`
+	Context     string   `json:"context,omitempty"`
+	AuthType    string   `json:"authType"`
+	Status      string   `json:"status"`
+	Identity    string   `json:"identity,omitempty"`
+	Subject     string   `json:"subject,omitempty"`
+	Issuer      string   `json:"issuer,omitempty"`
+	Expiry      string   `json:"expiry,omitempty"`
+	Scopes      []string `json:"scopes,omitempty"`
+	Refreshable bool     `json:"refreshable"`
+}
+
+var statusColumns = []printer.Column{
+	{Header: "Endpoint", JSONPath: "$.endpoint"},
+	{Header: "Status", JSONPath: "$.status"},
+	{Header: "Identity", JSONPath: "$.identity"},
+	{Header: "Expiry", JSONPath: "$.expiry"},
+	{Header: "Scopes", JSONPath: "$.scopes"},
+	{Header: "Refreshable", JSONPath: "$.refreshable"},
+}
+
+// newSession returns the session of the admin endpoint of the config in use
+var newSession = func(ctx context.Context) (*auth.Session, error) {
+	return auth.NewSession(admin.GetConfig(ctx), configutil.CurrentContext)
+}
+
+// CreateAuthCommand will return auth command
+func CreateAuthCommand() *cobra.Command {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: authShort,
+		Long:  authLong,
+	}
+
+	authResourcesFuncs := map[string]cmdcore.CommandEntry{
+		"login": {CmdFunc: login, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: loginShort,
+			Long:  loginLong, DisableFlyteClient: true},
+		"logout": {CmdFunc: logout, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: logoutShort,
+			Long:  logoutLong, DisableFlyteClient: true},
+		"status": {CmdFunc: status, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: statusShort,
+			Long:  statusLong, DisableFlyteClient: true},
+		"token": {CmdFunc: printToken, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: tokenShort,
+			Long:  tokenLong, DisableFlyteClient: true},
+	}
+
+	cmdcore.AddCommands(authCmd, authResourcesFuncs)
+	return authCmd
+}
+
+func login(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	session, err := newSession(ctx)
+	if err != nil {
+		return err
+	}
+	token, err := session.Login(ctx)
+	if err != nil {
+		return err
+	}
+	info := auth.DecodeToken(token)
+	identity := info.Identity
+	if len(identity) == 0 {
+		identity = info.Subject
+	}
+	if !session.CachesTokens() {
+		fmt.Printf("Authenticated to %v as %v. The tokens of the %v auth type aren't cached\n",
+			session.Config.Endpoint.String(), identity, session.Config.AuthType)
+		return nil
+	}
+	fmt.Printf("Logged in to %v as %v. The token expires at %v\n", session.Config.Endpoint.String(), identity,
+		formatExpiry(info.Expiry))
+	return nil
+}
+
+func logout(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	session, err := newSession(ctx)
+	if err != nil {
+		return err
+	}
+	if err := session.Logout(); err != nil {
+		if errors.Is(err, auth.ErrNoToken) {
+			fmt.Printf("Not logged in to %v\n", session.Config.Endpoint.String())
+			return nil
+		}
+		return err
+	}
+	fmt.Printf("Logged out of %v\n", session.Config.Endpoint.String())
+	return nil
+}
+
+func status(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	session, err := newSession(ctx)
+	if err != nil {
+		return err
+	}
+	s := tokenStatus{
+		Endpoint: session.Config.Endpoint.String(),
+		Context:  configutil.CurrentContext,
+		AuthType: session.Config.AuthType.String(),
+		Status:   statusNotLoggedIn,
+	}
+	if !session.CachesTokens() {
+		s.Status = statusNotCached
+	} else if token, err := session.CachedToken(); err == nil {
+		info := auth.DecodeToken(token)
+		s.Status = statusValid
+		if info.Expired(time.Now()) {
+			s.Status = statusExpired
+		}
+		s.Identity = info.Identity
+		s.Subject = info.Subject
+		s.Issuer = info.Issuer
+		s.Expiry = formatExpiry(info.Expiry)
+		s.Scopes = info.Scopes
+		s.Refreshable = info.Refreshable
+	} else if !errors.Is(err, auth.ErrNoToken) {
+		return err
+	}
+	p := printer.Printer{}
+	return p.PrintInterface(config.GetConfig().MustOutputFormat(), statusColumns, []tokenStatus{s})
+}
+
+func printToken(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	session, err := newSession(ctx)
+	if err != nil {
+		return err
+	}
+	token, err := session.Token(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token.AccessToken)
+	return nil
+}
+
+func formatExpiry(expiry time.Time) string {
+	if expiry.IsZero() {
+		return ""
+	}
+	return expiry.Local().Format(time.RFC3339)
+}
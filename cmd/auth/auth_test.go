@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/auth"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func TestCreateAuthCommand(t *testing.T) {
+	authCommand := CreateAuthCommand()
+	assert.Equal(t, authCommand.Use, "auth")
+	assert.Equal(t, authCommand.Short, authShort)
+	assert.Equal(t, len(authCommand.Commands()), 4)
+	cmdNouns := authCommand.Commands()
+	sort.Slice(cmdNouns, func(i, j int) bool {
+		return cmdNouns[i].Use < cmdNouns[j].Use
+	})
+	assert.Equal(t, cmdNouns[0].Use, "login")
+	assert.Equal(t, cmdNouns[1].Use, "logout")
+	assert.Equal(t, cmdNouns[2].Use, "status")
+	assert.Equal(t, cmdNouns[3].Use, "token")
+}
+
+func testSession(t *testing.T) *auth.Session {
+	keyring.MockInit()
+	endpoint, err := url.Parse("dns:///flyte.example.com")
+	assert.Nil(t, err)
+	session, err := auth.NewSession(&admin.Config{
+		Endpoint:   config.URL{URL: *endpoint},
+		AuthType:   admin.AuthTypePkce,
+		PkceConfig: admin.GetConfig(context.Background()).PkceConfig,
+	}, "")
+	assert.Nil(t, err)
+	defaultNewSession := newSession
+	newSession = func(ctx context.Context) (*auth.Session, error) { return session, nil }
+	t.Cleanup(func() { newSession = defaultNewSession })
+	return session
+}
+
+func TestLogout(t *testing.T) {
+	s := testutils.Setup()
+	session := testSession(t)
+	t.Run("Not logged in", func(t *testing.T) {
+		assert.Nil(t, logout(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Logged in", func(t *testing.T) {
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "access"}))
+		assert.Nil(t, logout(s.Ctx, []string{}, s.CmdCtx))
+		_, err := session.CachedToken()
+		assert.ErrorIs(t, err, auth.ErrNoToken)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	s := testutils.Setup()
+	session := testSession(t)
+	t.Run("Not logged in", func(t *testing.T) {
+		assert.Nil(t, status(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Logged in", func(t *testing.T) {
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)}))
+		assert.Nil(t, status(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Not cached", func(t *testing.T) {
+		session.Config.AuthType = admin.AuthTypeClientSecret
+		defer func() { session.Config.AuthType = admin.AuthTypePkce }()
+		assert.Nil(t, status(s.Ctx, []string{}, s.CmdCtx))
+	})
+}
+
+func TestPrintToken(t *testing.T) {
+	s := testutils.Setup()
+	session := testSession(t)
+	t.Run("Not logged in", func(t *testing.T) {
+		err := printToken(s.Ctx, []string{}, s.CmdCtx)
+		assert.ErrorIs(t, err, auth.ErrNoToken)
+	})
+	t.Run("Valid token", func(t *testing.T) {
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)}))
+		assert.Nil(t, printToken(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Expired token", func(t *testing.T) {
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(-time.Hour)}))
+		assert.NotNil(t, printToken(s.Ctx, []string{}, s.CmdCtx))
+	})
+}
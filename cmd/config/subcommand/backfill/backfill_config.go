@@ -0,0 +1,19 @@
+package backfill
+
+//go:generate pflags Config --default-var DefaultConfig --bind-default-var
+var (
+	DefaultConfig = &Config{
+		Parallelism: 1,
+	}
+)
+
+// Config stores the flags required by create backfill
+type Config struct {
+	LaunchPlan  string `json:"launchplan" pflag:",name of the scheduled launch plan to backfill."`
+	Version     string `json:"version" pflag:",version of the launch plan to backfill. If not specified the latest version is used."`
+	From        string `json:"from" pflag:",start of the backfill window (inclusive) in RFC3339 or YYYY-MM-DD format."`
+	To          string `json:"to" pflag:",end of the backfill window (exclusive) in RFC3339 or YYYY-MM-DD format."`
+	Parallelism int    `json:"parallelism" pflag:",number of executions to be created concurrently."`
+	DryRun      bool   `json:"dryRun" pflag:",print the executions to be created without creating them."`
+	ResumeFile  string `json:"resumeFile" pflag:",file recording the created executions, used to resume an interrupted backfill."`
+}
@@ -0,0 +1,61 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package backfill
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultConfig.LaunchPlan, fmt.Sprintf("%v%v", prefix, "launchplan"), DefaultConfig.LaunchPlan, "name of the scheduled launch plan to backfill.")
+	cmdFlags.StringVar(&DefaultConfig.Version, fmt.Sprintf("%v%v", prefix, "version"), DefaultConfig.Version, "version of the launch plan to backfill. If not specified the latest version is used.")
+	cmdFlags.StringVar(&DefaultConfig.From, fmt.Sprintf("%v%v", prefix, "from"), DefaultConfig.From, "start of the backfill window (inclusive) in RFC3339 or YYYY-MM-DD format.")
+	cmdFlags.StringVar(&DefaultConfig.To, fmt.Sprintf("%v%v", prefix, "to"), DefaultConfig.To, "end of the backfill window (exclusive) in RFC3339 or YYYY-MM-DD format.")
+	cmdFlags.IntVar(&DefaultConfig.Parallelism, fmt.Sprintf("%v%v", prefix, "parallelism"), DefaultConfig.Parallelism, "number of executions to be created concurrently.")
+	cmdFlags.BoolVar(&DefaultConfig.DryRun, fmt.Sprintf("%v%v", prefix, "dryRun"), DefaultConfig.DryRun, "print the executions to be created without creating them.")
+	cmdFlags.StringVar(&DefaultConfig.ResumeFile, fmt.Sprintf("%v%v", prefix, "resumeFile"), DefaultConfig.ResumeFile, "file recording the created executions,  used to resume an interrupted backfill.")
+	return cmdFlags
+}
@@ -52,5 +52,21 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
 	cmdFlags.StringVar(&DefaultConfig.Host, fmt.Sprintf("%v%v", prefix, "host"), DefaultConfig.Host, "Endpoint of flyte admin")
 	cmdFlags.BoolVar(&DefaultConfig.Insecure, fmt.Sprintf("%v%v", prefix, "insecure"), DefaultConfig.Insecure, "Enable insecure mode")
+	cmdFlags.StringVar(&DefaultConfig.AuthType, fmt.Sprintf("%v%v", prefix, "auth-type"), DefaultConfig.AuthType, "Auth flow used to authenticate with flyte admin: Pkce ClientSecret ExternalCommand or DeviceFlow. Defaults to Pkce.")
+	cmdFlags.StringVar(&DefaultConfig.ClientID, fmt.Sprintf("%v%v", prefix, "client-id"), DefaultConfig.ClientID, "Client ID of the app registered with the authorization server")
+	cmdFlags.StringVar(&DefaultConfig.ClientSecretLocation, fmt.Sprintf("%v%v", prefix, "client-secret-location"), DefaultConfig.ClientSecretLocation, "File containing the client secret with the ClientSecret auth type")
+	cmdFlags.StringVar(&DefaultConfig.ClientSecretEnvVar, fmt.Sprintf("%v%v", prefix, "client-secret-env-var"), DefaultConfig.ClientSecretEnvVar, "Environment variable containing the client secret with the ClientSecret auth type")
+	cmdFlags.StringSliceVar(&DefaultConfig.Scopes, fmt.Sprintf("%v%v", prefix, "scopes"), DefaultConfig.Scopes, "Scopes to request from the authorization server")
+	cmdFlags.StringVar(&DefaultConfig.CACertPath, fmt.Sprintf("%v%v", prefix, "ca-cert-path"), DefaultConfig.CACertPath, "Certificate file used to verify the certificate of flyte admin")
+	cmdFlags.StringSliceVar(&DefaultConfig.Command, fmt.Sprintf("%v%v", prefix, "command"), DefaultConfig.Command, "Command printing the token with the ExternalCommand auth type")
+	cmdFlags.StringVar(&DefaultConfig.Storage.Type, fmt.Sprintf("%v%v", prefix, "storage.type"), DefaultConfig.Storage.Type, "Type of the storage: s3 gcs or minio. No storage is configured if not set.")
+	cmdFlags.StringVar(&DefaultConfig.Storage.Bucket, fmt.Sprintf("%v%v", prefix, "storage.bucket"), DefaultConfig.Storage.Bucket, "Bucket holding the data of Flyte")
+	cmdFlags.StringVar(&DefaultConfig.Storage.Endpoint, fmt.Sprintf("%v%v", prefix, "storage.endpoint"), DefaultConfig.Storage.Endpoint, "Endpoint of the minio or s3 compatible storage e.g. http://localhost:30084")
+	cmdFlags.StringVar(&DefaultConfig.Storage.Region, fmt.Sprintf("%v%v", prefix, "storage.region"), DefaultConfig.Storage.Region, "Region of the s3 bucket")
+	cmdFlags.StringVar(&DefaultConfig.Storage.AuthType, fmt.Sprintf("%v%v", prefix, "storage.auth-type"), DefaultConfig.Storage.AuthType, "Source of the s3 or minio credentials: iam or accesskey. Defaults to iam for s3 and to accesskey for minio.")
+	cmdFlags.StringVar(&DefaultConfig.Storage.AccessKey, fmt.Sprintf("%v%v", prefix, "storage.access-key"), DefaultConfig.Storage.AccessKey, "Access key with the accesskey auth type")
+	cmdFlags.StringVar(&DefaultConfig.Storage.SecretKey, fmt.Sprintf("%v%v", prefix, "storage.secret-key"), DefaultConfig.Storage.SecretKey, "Secret key with the accesskey auth type")
+	cmdFlags.StringVar(&DefaultConfig.Storage.ProjectID, fmt.Sprintf("%v%v", prefix, "storage.project-id"), DefaultConfig.Storage.ProjectID, "Google Cloud project of the gcs bucket")
+	cmdFlags.BoolVar(&DefaultConfig.Interactive, fmt.Sprintf("%v%v", prefix, "interactive"), DefaultConfig.Interactive, "Prompt for the settings of the config")
 	return cmdFlags
 }
@@ -127,4 +127,228 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_auth-type", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth-type", testValue)
+			if vString, err := cmdFlags.GetString("auth-type"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AuthType)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_client-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("client-id", testValue)
+			if vString, err := cmdFlags.GetString("client-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ClientID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_client-secret-location", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("client-secret-location", testValue)
+			if vString, err := cmdFlags.GetString("client-secret-location"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ClientSecretLocation)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_client-secret-env-var", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("client-secret-env-var", testValue)
+			if vString, err := cmdFlags.GetString("client-secret-env-var"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ClientSecretEnvVar)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_scopes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(DefaultConfig.Scopes, ",")
+
+			cmdFlags.Set("scopes", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("scopes"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.Scopes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_ca-cert-path", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("ca-cert-path", testValue)
+			if vString, err := cmdFlags.GetString("ca-cert-path"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.CACertPath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_command", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(DefaultConfig.Command, ",")
+
+			cmdFlags.Set("command", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("command"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.Command)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.type", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.type", testValue)
+			if vString, err := cmdFlags.GetString("storage.type"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.Type)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.bucket", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.bucket", testValue)
+			if vString, err := cmdFlags.GetString("storage.bucket"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.Bucket)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.endpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.endpoint", testValue)
+			if vString, err := cmdFlags.GetString("storage.endpoint"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.Endpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.region", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.region", testValue)
+			if vString, err := cmdFlags.GetString("storage.region"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.Region)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.auth-type", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.auth-type", testValue)
+			if vString, err := cmdFlags.GetString("storage.auth-type"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.AuthType)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.access-key", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.access-key", testValue)
+			if vString, err := cmdFlags.GetString("storage.access-key"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.AccessKey)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.secret-key", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.secret-key", testValue)
+			if vString, err := cmdFlags.GetString("storage.secret-key"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.SecretKey)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_storage.project-id", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("storage.project-id", testValue)
+			if vString, err := cmdFlags.GetString("storage.project-id"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Storage.ProjectID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_interactive", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("interactive", testValue)
+			if vBool, err := cmdFlags.GetBool("interactive"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Interactive)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
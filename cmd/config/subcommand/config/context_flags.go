@@ -0,0 +1,17 @@
+package config
+
+//go:generate pflags ContextConfig --default-var DefaultContextConfig --bind-default-var
+var (
+	DefaultContextConfig = &ContextConfig{}
+)
+
+// ContextConfig holds the flags of the set-context command. Empty flags leave the settings of an existing context as
+// they are.
+type ContextConfig struct {
+	Endpoint string `json:"endpoint" pflag:",Endpoint of flyte admin e.g. dns:///flyte.myexample.com"`
+	Insecure bool   `json:"insecure" pflag:",Enable insecure mode. Updated along with the endpoint of an existing context."`
+	AuthType string `json:"authType" pflag:",Auth flow used to authenticate with flyte admin e.g. Pkce or ClientSecret"`
+	ClientID string `json:"clientId" pflag:",Client ID of the app registered with the authorization server"`
+	Project  string `json:"project" pflag:",Default project of the context"`
+	Domain   string `json:"domain" pflag:",Default domain of the context"`
+}
@@ -0,0 +1,60 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (ContextConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (ContextConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (ContextConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in ContextConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg ContextConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("ContextConfig", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultContextConfig.Endpoint, fmt.Sprintf("%v%v", prefix, "endpoint"), DefaultContextConfig.Endpoint, "Endpoint of flyte admin e.g. dns:///flyte.myexample.com")
+	cmdFlags.BoolVar(&DefaultContextConfig.Insecure, fmt.Sprintf("%v%v", prefix, "insecure"), DefaultContextConfig.Insecure, "Enable insecure mode. Updated along with the endpoint of an existing context.")
+	cmdFlags.StringVar(&DefaultContextConfig.AuthType, fmt.Sprintf("%v%v", prefix, "authType"), DefaultContextConfig.AuthType, "Auth flow used to authenticate with flyte admin e.g. Pkce or ClientSecret")
+	cmdFlags.StringVar(&DefaultContextConfig.ClientID, fmt.Sprintf("%v%v", prefix, "clientId"), DefaultContextConfig.ClientID, "Client ID of the app registered with the authorization server")
+	cmdFlags.StringVar(&DefaultContextConfig.Project, fmt.Sprintf("%v%v", prefix, "project"), DefaultContextConfig.Project, "Default project of the context")
+	cmdFlags.StringVar(&DefaultContextConfig.Domain, fmt.Sprintf("%v%v", prefix, "domain"), DefaultContextConfig.Domain, "Default domain of the context")
+	return cmdFlags
+}
@@ -0,0 +1,186 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+)
+
+var dereferencableKindsContextConfig = map[reflect.Kind]struct{}{
+	reflect.Array: {}, reflect.Chan: {}, reflect.Map: {}, reflect.Ptr: {}, reflect.Slice: {},
+}
+
+// Checks if t is a kind that can be dereferenced to get its underlying type.
+func canGetElementContextConfig(t reflect.Kind) bool {
+	_, exists := dereferencableKindsContextConfig[t]
+	return exists
+}
+
+// This decoder hook tests types for json unmarshaling capability. If implemented, it uses json unmarshal to build the
+// object. Otherwise, it'll just pass on the original data.
+func jsonUnmarshalerHookContextConfig(_, to reflect.Type, data interface{}) (interface{}, error) {
+	unmarshalerType := reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	if to.Implements(unmarshalerType) || reflect.PtrTo(to).Implements(unmarshalerType) ||
+		(canGetElementContextConfig(to.Kind()) && to.Elem().Implements(unmarshalerType)) {
+
+		raw, err := json.Marshal(data)
+		if err != nil {
+			fmt.Printf("Failed to marshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		res := reflect.New(to).Interface()
+		err = json.Unmarshal(raw, &res)
+		if err != nil {
+			fmt.Printf("Failed to umarshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		return res, nil
+	}
+
+	return data, nil
+}
+
+func decode_ContextConfig(input, result interface{}) error {
+	config := &mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		Result:           result,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			jsonUnmarshalerHookContextConfig,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
+
+func join_ContextConfig(arr interface{}, sep string) string {
+	listValue := reflect.ValueOf(arr)
+	strs := make([]string, 0, listValue.Len())
+	for i := 0; i < listValue.Len(); i++ {
+		strs = append(strs, fmt.Sprintf("%v", listValue.Index(i)))
+	}
+
+	return strings.Join(strs, sep)
+}
+
+func testDecodeJson_ContextConfig(t *testing.T, val, result interface{}) {
+	assert.NoError(t, decode_ContextConfig(val, result))
+}
+
+func testDecodeRaw_ContextConfig(t *testing.T, vStringSlice, result interface{}) {
+	assert.NoError(t, decode_ContextConfig(vStringSlice, result))
+}
+
+func TestContextConfig_GetPFlagSet(t *testing.T) {
+	val := ContextConfig{}
+	cmdFlags := val.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+}
+
+func TestContextConfig_SetFlags(t *testing.T) {
+	actual := ContextConfig{}
+	cmdFlags := actual.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+
+	t.Run("Test_endpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("endpoint", testValue)
+			if vString, err := cmdFlags.GetString("endpoint"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vString), &actual.Endpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_insecure", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("insecure", testValue)
+			if vBool, err := cmdFlags.GetBool("insecure"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vBool), &actual.Insecure)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_authType", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("authType", testValue)
+			if vString, err := cmdFlags.GetString("authType"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vString), &actual.AuthType)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_clientId", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("clientId", testValue)
+			if vString, err := cmdFlags.GetString("clientId"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vString), &actual.ClientID)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_project", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("project", testValue)
+			if vString, err := cmdFlags.GetString("project"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vString), &actual.Project)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_domain", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("domain", testValue)
+			if vString, err := cmdFlags.GetString("domain"); err == nil {
+				testDecodeJson_ContextConfig(t, fmt.Sprintf("%v", vString), &actual.Domain)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+}
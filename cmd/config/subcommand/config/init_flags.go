@@ -9,6 +9,27 @@ var (
 
 //Configs
 type Config struct {
-	Host     string `json:"host" pflag:",Endpoint of flyte admin"`
-	Insecure bool   `json:"insecure" pflag:",Enable insecure mode"`
+	Host                 string        `json:"host" pflag:",Endpoint of flyte admin"`
+	Insecure             bool          `json:"insecure" pflag:",Enable insecure mode"`
+	AuthType             string        `json:"auth-type" pflag:",Auth flow used to authenticate with flyte admin: Pkce ClientSecret ExternalCommand or DeviceFlow. Defaults to Pkce."`
+	ClientID             string        `json:"client-id" pflag:",Client ID of the app registered with the authorization server"`
+	ClientSecretLocation string        `json:"client-secret-location" pflag:",File containing the client secret with the ClientSecret auth type"`
+	ClientSecretEnvVar   string        `json:"client-secret-env-var" pflag:",Environment variable containing the client secret with the ClientSecret auth type"`
+	Scopes               []string      `json:"scopes" pflag:",Scopes to request from the authorization server"`
+	CACertPath           string        `json:"ca-cert-path" pflag:",Certificate file used to verify the certificate of flyte admin"`
+	Command              []string      `json:"command" pflag:",Command printing the token with the ExternalCommand auth type"`
+	Storage              StorageConfig `json:"storage"`
+	Interactive          bool          `json:"interactive" pflag:",Prompt for the settings of the config"`
+}
+
+// StorageConfig holds the flags of the storage section of the config.
+type StorageConfig struct {
+	Type      string `json:"type" pflag:",Type of the storage: s3 gcs or minio. No storage is configured if not set."`
+	Bucket    string `json:"bucket" pflag:",Bucket holding the data of Flyte"`
+	Endpoint  string `json:"endpoint" pflag:",Endpoint of the minio or s3 compatible storage e.g. http://localhost:30084"`
+	Region    string `json:"region" pflag:",Region of the s3 bucket"`
+	AuthType  string `json:"auth-type" pflag:",Source of the s3 or minio credentials: iam or accesskey. Defaults to iam for s3 and to accesskey for minio."`
+	AccessKey string `json:"access-key" pflag:",Access key with the accesskey auth type"`
+	SecretKey string `json:"secret-key" pflag:",Secret key with the accesskey auth type"`
+	ProjectID string `json:"project-id" pflag:",Google Cloud project of the gcs bucket"`
 }
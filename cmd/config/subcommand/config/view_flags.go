@@ -0,0 +1,11 @@
+package config
+
+//go:generate pflags ViewConfig --default-var DefaultViewConfig --bind-default-var
+var (
+	DefaultViewConfig = &ViewConfig{}
+)
+
+// ViewConfig holds the flags of the view command
+type ViewConfig struct {
+	Effective bool `json:"effective" pflag:",Print the settings in effect of every config section with the source of their value"`
+}
@@ -0,0 +1,55 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (ViewConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (ViewConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (ViewConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in ViewConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg ViewConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("ViewConfig", pflag.ExitOnError)
+	cmdFlags.BoolVar(&DefaultViewConfig.Effective, fmt.Sprintf("%v%v", prefix, "effective"), DefaultViewConfig.Effective, "Print the settings in effect of every config section with the source of their value")
+	return cmdFlags
+}
@@ -0,0 +1,59 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package promote
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultConfig.Version, fmt.Sprintf("%v%v", prefix, "version"), DefaultConfig.Version, "version of the launch plan to be promoted.")
+	cmdFlags.StringVar(&DefaultConfig.FromDomain, fmt.Sprintf("%v%v", prefix, "fromDomain"), DefaultConfig.FromDomain, "domain to promote from. If not specified the configured domain is used.")
+	cmdFlags.StringVar(&DefaultConfig.ToDomain, fmt.Sprintf("%v%v", prefix, "toDomain"), DefaultConfig.ToDomain, "domain to promote to.")
+	cmdFlags.BoolVar(&DefaultConfig.Activate, fmt.Sprintf("%v%v", prefix, "activate"), DefaultConfig.Activate, "activate the promoted launch plan in the target domain.")
+	cmdFlags.BoolVar(&DefaultConfig.DryRun, fmt.Sprintf("%v%v", prefix, "dryRun"), DefaultConfig.DryRun, "execute command without making any modifications.")
+	return cmdFlags
+}
@@ -0,0 +1,15 @@
+package promote
+
+//go:generate pflags Config --default-var DefaultConfig --bind-default-var
+var (
+	DefaultConfig = &Config{}
+)
+
+// Config stores the flags required by promote launchplan
+type Config struct {
+	Version    string `json:"version" pflag:",version of the launch plan to be promoted."`
+	FromDomain string `json:"fromDomain" pflag:",domain to promote from. If not specified the configured domain is used."`
+	ToDomain   string `json:"toDomain" pflag:",domain to promote to."`
+	Activate   bool   `json:"activate" pflag:",activate the promoted launch plan in the target domain."`
+	DryRun     bool   `json:"dryRun" pflag:",execute command without making any modifications."`
+}
@@ -0,0 +1,60 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package prune
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.IntVar(&DefaultConfig.OlderThanDays, fmt.Sprintf("%v%v", prefix, "olderThanDays"), DefaultConfig.OlderThanDays, "prune versions created more than the given number of days ago.")
+	cmdFlags.IntVar(&DefaultConfig.Keep, fmt.Sprintf("%v%v", prefix, "keep"), DefaultConfig.Keep, "prune versions beyond the given number of newest versions of every entity.")
+	cmdFlags.Int32Var(&DefaultConfig.Limit, fmt.Sprintf("%v%v", prefix, "limit"), DefaultConfig.Limit, "maximum number of entities and versions to fetch.")
+	cmdFlags.BoolVar(&DefaultConfig.DryRun, fmt.Sprintf("%v%v", prefix, "dryRun"), DefaultConfig.DryRun, "list the stale versions without making any modifications.")
+	cmdFlags.BoolVar(&DefaultConfig.Force, fmt.Sprintf("%v%v", prefix, "force"), DefaultConfig.Force, "skip the confirmation prompt.")
+	cmdFlags.BoolVar(&DefaultConfig.Deactivate, fmt.Sprintf("%v%v", prefix, "deactivate"), DefaultConfig.Deactivate, "deactivate the stale launch plan versions which are active instead of skipping them.")
+	return cmdFlags
+}
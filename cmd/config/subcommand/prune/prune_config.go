@@ -0,0 +1,18 @@
+package prune
+
+//go:generate pflags Config --default-var DefaultConfig --bind-default-var
+var (
+	DefaultConfig = &Config{
+		Limit: 1000,
+	}
+)
+
+// Config stores the flags required by prune
+type Config struct {
+	OlderThanDays int   `json:"olderThanDays" pflag:",prune versions created more than the given number of days ago."`
+	Keep          int   `json:"keep" pflag:",prune versions beyond the given number of newest versions of every entity."`
+	Limit         int32 `json:"limit" pflag:",maximum number of entities and versions to fetch."`
+	DryRun        bool  `json:"dryRun" pflag:",list the stale versions without making any modifications."`
+	Force         bool  `json:"force" pflag:",skip the confirmation prompt."`
+	Deactivate    bool  `json:"deactivate" pflag:",deactivate the stale launch plan versions which are active instead of skipping them."`
+}
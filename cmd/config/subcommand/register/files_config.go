@@ -4,10 +4,25 @@ import "github.com/flyteorg/flytestdlib/config"
 
 //go:generate pflags FilesConfig --default-var DefaultFilesConfig --bind-default-var
 
+const (
+	// VersionFromGit versions the entities with the short SHA of the git commit, marked dirty with uncommitted changes
+	VersionFromGit = "git"
+	// VersionFromHash versions each entity with the content hash of its spec
+	VersionFromHash = "hash"
+
+	// UploaderDataProxy uploads the source code to the signed URLs of the data proxy of admin
+	UploaderDataProxy = "dataProxy"
+	// UploaderStorage writes the source code to the storage config
+	UploaderStorage = "storage"
+	// UploaderLocal copies the source code to a dir of the local filesystem
+	UploaderLocal = "local"
+)
+
 var (
 	DefaultFilesConfig = &FilesConfig{
 		Version:         "",
 		ContinueOnError: false,
+		MaxSourceSizeMB: 100,
 	}
 
 	cfg = config.MustRegisterSection("files", DefaultFilesConfig)
@@ -16,6 +31,8 @@ var (
 // FilesConfig containing flags used for registration
 type FilesConfig struct {
 	Version                    string `json:"version" pflag:",Version of the entity to be registered with flyte which are un-versioned after serialization."`
+	VersionFrom                string `json:"versionFrom" pflag:",Derive the version of the un-versioned entities from either git or the content hash of each entity."`
+	VersionTemplate            string `json:"versionTemplate" pflag:",Go template of the version of the un-versioned entities with the fields GitSHA Date Hash and Version."`
 	Force                      bool   `json:"force" pflag:",Force use of version number on entities registered with flyte."`
 	ContinueOnError            bool   `json:"continueOnError" pflag:",Continue on error when registering files."`
 	Archive                    bool   `json:"archive" pflag:",Pass in archive file either an http link or local path."`
@@ -24,8 +41,14 @@ type FilesConfig struct {
 	K8ServiceAccount           string `json:"k8ServiceAccount" pflag:",Deprecated. Please use --K8sServiceAccount"`
 	OutputLocationPrefix       string `json:"outputLocationPrefix" pflag:",Custom output location prefix for offloaded types (files/schemas)."`
 	DeprecatedSourceUploadPath string `json:"sourceUploadPath" pflag:",Deprecated: Update flyte admin to avoid having to configure storage access from flytectl."`
+	Fast                       bool   `json:"fast" pflag:",Fast register the source dir without a source code archive from pyflyte."`
+	Source                     string `json:"source" pflag:",Source dir archived by a fast registration. Defaults to the current dir."`
+	MaxSourceSizeMB            int    `json:"maxSourceSizeMB" pflag:",Maximum size in MB of the files archived from the source dir."`
+	Uploader                   string `json:"uploader" pflag:",Uploader of the source code of fast registrations: dataProxy storage or local. Defaults to the data proxy with a fallback to storage."`
+	LocalUploadPath            string `json:"localUploadPath" pflag:",Dir the local uploader copies the source code to. Defaults to ~/.flyte/fast."`
 	DestinationDirectory       string `json:"destinationDirectory" pflag:",Location of source code in container."`
 	DryRun                     bool   `json:"dryRun" pflag:",Execute command without making any modifications."`
+	Manifest                   string `json:"manifest" pflag:",Write the manifest of the registration to this JSON file for a later rollback. Not written on dry runs."`
 	EnableSchedule             bool   `json:"enableSchedule" pflag:",Enable the schedule if the files contain schedulable launchplan."`
 }
 
@@ -51,6 +51,8 @@ func (FilesConfig) mustMarshalJSON(v json.Marshaler) string {
 func (cfg FilesConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags := pflag.NewFlagSet("FilesConfig", pflag.ExitOnError)
 	cmdFlags.StringVar(&DefaultFilesConfig.Version, fmt.Sprintf("%v%v", prefix, "version"), DefaultFilesConfig.Version, "Version of the entity to be registered with flyte which are un-versioned after serialization.")
+	cmdFlags.StringVar(&DefaultFilesConfig.VersionFrom, fmt.Sprintf("%v%v", prefix, "versionFrom"), DefaultFilesConfig.VersionFrom, "Derive the version of the un-versioned entities from either git or the content hash of each entity.")
+	cmdFlags.StringVar(&DefaultFilesConfig.VersionTemplate, fmt.Sprintf("%v%v", prefix, "versionTemplate"), DefaultFilesConfig.VersionTemplate, "Go template of the version of the un-versioned entities with the fields GitSHA Date Hash and Version.")
 	cmdFlags.BoolVar(&DefaultFilesConfig.Force, fmt.Sprintf("%v%v", prefix, "force"), DefaultFilesConfig.Force, "Force use of version number on entities registered with flyte.")
 	cmdFlags.BoolVar(&DefaultFilesConfig.ContinueOnError, fmt.Sprintf("%v%v", prefix, "continueOnError"), DefaultFilesConfig.ContinueOnError, "Continue on error when registering files.")
 	cmdFlags.BoolVar(&DefaultFilesConfig.Archive, fmt.Sprintf("%v%v", prefix, "archive"), DefaultFilesConfig.Archive, "Pass in archive file either an http link or local path.")
@@ -59,8 +61,14 @@ func (cfg FilesConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.StringVar(&DefaultFilesConfig.K8ServiceAccount, fmt.Sprintf("%v%v", prefix, "k8ServiceAccount"), DefaultFilesConfig.K8ServiceAccount, "Deprecated. Please use --K8sServiceAccount")
 	cmdFlags.StringVar(&DefaultFilesConfig.OutputLocationPrefix, fmt.Sprintf("%v%v", prefix, "outputLocationPrefix"), DefaultFilesConfig.OutputLocationPrefix, "Custom output location prefix for offloaded types (files/schemas).")
 	cmdFlags.StringVar(&DefaultFilesConfig.DeprecatedSourceUploadPath, fmt.Sprintf("%v%v", prefix, "sourceUploadPath"), DefaultFilesConfig.DeprecatedSourceUploadPath, "Deprecated: Update flyte admin to avoid having to configure storage access from flytectl.")
+	cmdFlags.BoolVar(&DefaultFilesConfig.Fast, fmt.Sprintf("%v%v", prefix, "fast"), DefaultFilesConfig.Fast, "Fast register the source dir without a source code archive from pyflyte.")
+	cmdFlags.StringVar(&DefaultFilesConfig.Source, fmt.Sprintf("%v%v", prefix, "source"), DefaultFilesConfig.Source, "Source dir archived by a fast registration. Defaults to the current dir.")
+	cmdFlags.IntVar(&DefaultFilesConfig.MaxSourceSizeMB, fmt.Sprintf("%v%v", prefix, "maxSourceSizeMB"), DefaultFilesConfig.MaxSourceSizeMB, "Maximum size in MB of the files archived from the source dir.")
+	cmdFlags.StringVar(&DefaultFilesConfig.Uploader, fmt.Sprintf("%v%v", prefix, "uploader"), DefaultFilesConfig.Uploader, "Uploader of the source code of fast registrations: dataProxy storage or local. Defaults to the data proxy with a fallback to storage.")
+	cmdFlags.StringVar(&DefaultFilesConfig.LocalUploadPath, fmt.Sprintf("%v%v", prefix, "localUploadPath"), DefaultFilesConfig.LocalUploadPath, "Dir the local uploader copies the source code to. Defaults to ~/.flyte/fast.")
 	cmdFlags.StringVar(&DefaultFilesConfig.DestinationDirectory, fmt.Sprintf("%v%v", prefix, "destinationDirectory"), DefaultFilesConfig.DestinationDirectory, "Location of source code in container.")
 	cmdFlags.BoolVar(&DefaultFilesConfig.DryRun, fmt.Sprintf("%v%v", prefix, "dryRun"), DefaultFilesConfig.DryRun, "Execute command without making any modifications.")
+	cmdFlags.StringVar(&DefaultFilesConfig.Manifest, fmt.Sprintf("%v%v", prefix, "manifest"), DefaultFilesConfig.Manifest, "Write the manifest of the registration to this JSON file for a later rollback. Not written on dry runs.")
 	cmdFlags.BoolVar(&DefaultFilesConfig.EnableSchedule, fmt.Sprintf("%v%v", prefix, "enableSchedule"), DefaultFilesConfig.EnableSchedule, "Enable the schedule if the files contain schedulable launchplan.")
 	return cmdFlags
 }
@@ -0,0 +1,14 @@
+package register
+
+//go:generate pflags RollbackConfig --default-var DefaultRollbackConfig --bind-default-var
+
+var (
+	DefaultRollbackConfig = &RollbackConfig{}
+)
+
+// RollbackConfig containing flags used for rolling back a registration
+type RollbackConfig struct {
+	Manifest        string `json:"manifest" pflag:",Manifest of the registration to roll back to."`
+	CurrentManifest string `json:"currentManifest" pflag:",Manifest of the registration rolled back. Its activated launch plans missing from the manifest are deactivated."`
+	DryRun          bool   `json:"dryRun" pflag:",Execute command without making any modifications."`
+}
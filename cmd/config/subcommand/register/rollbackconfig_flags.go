@@ -0,0 +1,57 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package register
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (RollbackConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (RollbackConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (RollbackConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in RollbackConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg RollbackConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("RollbackConfig", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultRollbackConfig.Manifest, fmt.Sprintf("%v%v", prefix, "manifest"), DefaultRollbackConfig.Manifest, "Manifest of the registration to roll back to.")
+	cmdFlags.StringVar(&DefaultRollbackConfig.CurrentManifest, fmt.Sprintf("%v%v", prefix, "currentManifest"), DefaultRollbackConfig.CurrentManifest, "Manifest of the registration rolled back. Its activated launch plans missing from the manifest are deactivated.")
+	cmdFlags.BoolVar(&DefaultRollbackConfig.DryRun, fmt.Sprintf("%v%v", prefix, "dryRun"), DefaultRollbackConfig.DryRun, "Execute command without making any modifications.")
+	return cmdFlags
+}
@@ -58,5 +58,9 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Var(&DefaultConfig.ImagePullPolicy, fmt.Sprintf("%v%v", prefix, "imagePullPolicy"), "Optional. Defines the image pull behavior [Always/IfNotPresent/Never]")
 	cmdFlags.StringVar(&DefaultConfig.ImagePullOptions.RegistryAuth, fmt.Sprintf("%v%v", prefix, "imagePullOptions.registryAuth"), DefaultConfig.ImagePullOptions.RegistryAuth, "The base64 encoded credentials for the registry.")
 	cmdFlags.StringVar(&DefaultConfig.ImagePullOptions.Platform, fmt.Sprintf("%v%v", prefix, "imagePullOptions.platform"), DefaultConfig.ImagePullOptions.Platform, "Forces a specific platform's image to be pulled.'")
+	cmdFlags.StringVar(&DefaultConfig.Name, fmt.Sprintf("%v%v", prefix, "name"), DefaultConfig.Name, "Optional. Name of the sandbox. Named sandboxes can run side by side with the default one.")
+	cmdFlags.Var(&DefaultConfig.Timeout, fmt.Sprintf("%v%v", prefix, "timeout"), "Optional. Maximum time to wait for the Flyte deployment to be ready.")
+	cmdFlags.StringVar(&DefaultConfig.ConfigFile, fmt.Sprintf("%v%v", prefix, "config"), DefaultConfig.ConfigFile, "Optional. Path to a sandbox configuration file declaring resources and ports and volumes and registry mirrors and Helm values.")
+	cmdFlags.StringVar(&DefaultConfig.Runtime, fmt.Sprintf("%v%v", prefix, "runtime"), DefaultConfig.Runtime, "Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set.")
 	return cmdFlags
 }
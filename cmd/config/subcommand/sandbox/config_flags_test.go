@@ -209,4 +209,58 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_name", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("name", testValue)
+			if vString, err := cmdFlags.GetString("name"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Name)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.Timeout.String()
+
+			cmdFlags.Set("timeout", testValue)
+			if v := cmdFlags.Lookup("timeout"); v != nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", v.Value.String()), &actual.Timeout)
+
+			}
+		})
+	})
+	t.Run("Test_config", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("config", testValue)
+			if vString, err := cmdFlags.GetString("config"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ConfigFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_runtime", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("runtime", testValue)
+			if vString, err := cmdFlags.GetString("runtime"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Runtime)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -0,0 +1,13 @@
+package sandbox
+
+//go:generate pflags InstanceConfig --default-var DefaultInstanceConfig --bind-default-var
+var (
+	DefaultInstanceConfig = &InstanceConfig{}
+)
+
+// InstanceConfig selects the sandbox which the status, teardown, exec, doctor and load-image commands apply to, and the container
+// runtime running it. The list command only uses the runtime.
+type InstanceConfig struct {
+	Name    string `json:"name" pflag:",Optional. Name of the sandbox. Uses the default sandbox if not set."`
+	Runtime string `json:"runtime" pflag:",Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set."`
+}
@@ -0,0 +1,56 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package sandbox
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (InstanceConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (InstanceConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (InstanceConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in InstanceConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg InstanceConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("InstanceConfig", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultInstanceConfig.Name, fmt.Sprintf("%v%v", prefix, "name"), DefaultInstanceConfig.Name, "Optional. Name of the sandbox. Uses the default sandbox if not set.")
+	cmdFlags.StringVar(&DefaultInstanceConfig.Runtime, fmt.Sprintf("%v%v", prefix, "runtime"), DefaultInstanceConfig.Runtime, "Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set.")
+	return cmdFlags
+}
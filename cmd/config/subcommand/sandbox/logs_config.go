@@ -0,0 +1,17 @@
+package sandbox
+
+import "github.com/flyteorg/flytestdlib/config"
+
+//go:generate pflags LogsConfig --default-var DefaultLogsConfig --bind-default-var
+var (
+	DefaultLogsConfig = &LogsConfig{}
+)
+
+// LogsConfig holds the flags of the logs command.
+type LogsConfig struct {
+	Name      string          `json:"name" pflag:",Optional. Name of the sandbox. Uses the default sandbox if not set."`
+	Follow    bool            `json:"follow" pflag:",Optional. Stream the logs until interrupted."`
+	Since     config.Duration `json:"since" pflag:",Optional. Only return the logs newer than the duration e.g. 10m."`
+	Execution string          `json:"execution" pflag:",Optional. Name of the execution whose task pod logs are returned."`
+	Runtime   string          `json:"runtime" pflag:",Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set."`
+}
@@ -0,0 +1,59 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package sandbox
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (LogsConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (LogsConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (LogsConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in LogsConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg LogsConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("LogsConfig", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultLogsConfig.Name, fmt.Sprintf("%v%v", prefix, "name"), DefaultLogsConfig.Name, "Optional. Name of the sandbox. Uses the default sandbox if not set.")
+	cmdFlags.BoolVar(&DefaultLogsConfig.Follow, fmt.Sprintf("%v%v", prefix, "follow"), DefaultLogsConfig.Follow, "Optional. Stream the logs until interrupted.")
+	cmdFlags.Var(&DefaultLogsConfig.Since, fmt.Sprintf("%v%v", prefix, "since"), "Optional. Only return the logs newer than the duration e.g. 10m.")
+	cmdFlags.StringVar(&DefaultLogsConfig.Execution, fmt.Sprintf("%v%v", prefix, "execution"), DefaultLogsConfig.Execution, "Optional. Name of the execution whose task pod logs are returned.")
+	cmdFlags.StringVar(&DefaultLogsConfig.Runtime, fmt.Sprintf("%v%v", prefix, "runtime"), DefaultLogsConfig.Runtime, "Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set.")
+	return cmdFlags
+}
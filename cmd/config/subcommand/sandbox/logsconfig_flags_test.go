@@ -0,0 +1,170 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+)
+
+var dereferencableKindsLogsConfig = map[reflect.Kind]struct{}{
+	reflect.Array: {}, reflect.Chan: {}, reflect.Map: {}, reflect.Ptr: {}, reflect.Slice: {},
+}
+
+// Checks if t is a kind that can be dereferenced to get its underlying type.
+func canGetElementLogsConfig(t reflect.Kind) bool {
+	_, exists := dereferencableKindsLogsConfig[t]
+	return exists
+}
+
+// This decoder hook tests types for json unmarshaling capability. If implemented, it uses json unmarshal to build the
+// object. Otherwise, it'll just pass on the original data.
+func jsonUnmarshalerHookLogsConfig(_, to reflect.Type, data interface{}) (interface{}, error) {
+	unmarshalerType := reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	if to.Implements(unmarshalerType) || reflect.PtrTo(to).Implements(unmarshalerType) ||
+		(canGetElementLogsConfig(to.Kind()) && to.Elem().Implements(unmarshalerType)) {
+
+		raw, err := json.Marshal(data)
+		if err != nil {
+			fmt.Printf("Failed to marshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		res := reflect.New(to).Interface()
+		err = json.Unmarshal(raw, &res)
+		if err != nil {
+			fmt.Printf("Failed to umarshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		return res, nil
+	}
+
+	return data, nil
+}
+
+func decode_LogsConfig(input, result interface{}) error {
+	config := &mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		Result:           result,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			jsonUnmarshalerHookLogsConfig,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
+
+func join_LogsConfig(arr interface{}, sep string) string {
+	listValue := reflect.ValueOf(arr)
+	strs := make([]string, 0, listValue.Len())
+	for i := 0; i < listValue.Len(); i++ {
+		strs = append(strs, fmt.Sprintf("%v", listValue.Index(i)))
+	}
+
+	return strings.Join(strs, sep)
+}
+
+func testDecodeJson_LogsConfig(t *testing.T, val, result interface{}) {
+	assert.NoError(t, decode_LogsConfig(val, result))
+}
+
+func testDecodeRaw_LogsConfig(t *testing.T, vStringSlice, result interface{}) {
+	assert.NoError(t, decode_LogsConfig(vStringSlice, result))
+}
+
+func TestLogsConfig_GetPFlagSet(t *testing.T) {
+	val := LogsConfig{}
+	cmdFlags := val.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+}
+
+func TestLogsConfig_SetFlags(t *testing.T) {
+	actual := LogsConfig{}
+	cmdFlags := actual.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+
+	t.Run("Test_name", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("name", testValue)
+			if vString, err := cmdFlags.GetString("name"); err == nil {
+				testDecodeJson_LogsConfig(t, fmt.Sprintf("%v", vString), &actual.Name)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_follow", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("follow", testValue)
+			if vBool, err := cmdFlags.GetBool("follow"); err == nil {
+				testDecodeJson_LogsConfig(t, fmt.Sprintf("%v", vBool), &actual.Follow)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_since", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultLogsConfig.Since.String()
+
+			cmdFlags.Set("since", testValue)
+			if v := cmdFlags.Lookup("since"); v != nil {
+				testDecodeJson_LogsConfig(t, fmt.Sprintf("%v", v.Value.String()), &actual.Since)
+
+			}
+		})
+	})
+	t.Run("Test_execution", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("execution", testValue)
+			if vString, err := cmdFlags.GetString("execution"); err == nil {
+				testDecodeJson_LogsConfig(t, fmt.Sprintf("%v", vString), &actual.Execution)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_runtime", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("runtime", testValue)
+			if vString, err := cmdFlags.GetString("runtime"); err == nil {
+				testDecodeJson_LogsConfig(t, fmt.Sprintf("%v", vString), &actual.Runtime)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+}
@@ -1,8 +1,13 @@
 package sandbox
 
-import "github.com/flyteorg/flytectl/pkg/docker"
+import (
+	"time"
 
-//Config holds configuration flags for sandbox command.
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+// Config holds configuration flags for sandbox command.
 type Config struct {
 	Source string `json:"source" pflag:",Path of your source code"`
 
@@ -27,9 +32,26 @@ type Config struct {
 	ImagePullPolicy docker.ImagePullPolicy `json:"imagePullPolicy" pflag:",Optional. Defines the image pull behavior [Always/IfNotPresent/Never]"`
 
 	ImagePullOptions docker.ImagePullOptions `json:"imagePullOptions" pflag:",Optional. Defines image pull options (e.g. auth)"`
+
+	// Optionally it is possible to run several sandboxes side by side. Every named sandbox gets its own container, host
+	// ports, kube context and config file.
+	Name string `json:"name" pflag:",Optional. Name of the sandbox. Named sandboxes can run side by side with the default one."`
+
+	// Maximum time to wait for all the pods of the Flyte deployment to be ready once the cluster is up.
+	Timeout config.Duration `json:"timeout" pflag:",Optional. Maximum time to wait for the Flyte deployment to be ready."`
+
+	// Optionally it is possible to declare the resources, ports, volumes, registry mirrors and Helm values of the
+	// sandbox in a yaml file, which can be shared to reproduce the same local setup. The flag shadows the global
+	// --config of the flytectl config, which the start commands don't read.
+	ConfigFile string `json:"config" pflag:",Optional. Path to a sandbox configuration file declaring resources and ports and volumes and registry mirrors and Helm values."`
+
+	// Optionally it is possible to run the sandbox with podman through its docker compatible API socket.
+	Runtime string `json:"runtime" pflag:",Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set."`
 }
 
 //go:generate pflags Config --default-var DefaultConfig --bind-default-var
 var (
-	DefaultConfig = &Config{}
+	DefaultConfig = &Config{
+		Timeout: config.Duration{Duration: 15 * time.Minute},
+	}
 )
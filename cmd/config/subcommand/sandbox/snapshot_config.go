@@ -0,0 +1,13 @@
+package sandbox
+
+//go:generate pflags SnapshotConfig --default-var DefaultSnapshotConfig --bind-default-var
+var (
+	DefaultSnapshotConfig = &SnapshotConfig{}
+)
+
+// SnapshotConfig holds the flags of the snapshot save and restore commands.
+type SnapshotConfig struct {
+	Sandbox string `json:"sandbox" pflag:",Optional. Name of the sandbox to snapshot. Uses the default sandbox if not set."`
+	Force   bool   `json:"force" pflag:",Optional. Overwrite the snapshot if it already exists."`
+	Runtime string `json:"runtime" pflag:",Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set."`
+}
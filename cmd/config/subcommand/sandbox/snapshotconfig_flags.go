@@ -0,0 +1,57 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package sandbox
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (SnapshotConfig) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (SnapshotConfig) mustJsonMarshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+func (SnapshotConfig) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in SnapshotConfig and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg SnapshotConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("SnapshotConfig", pflag.ExitOnError)
+	cmdFlags.StringVar(&DefaultSnapshotConfig.Sandbox, fmt.Sprintf("%v%v", prefix, "sandbox"), DefaultSnapshotConfig.Sandbox, "Optional. Name of the sandbox to snapshot. Uses the default sandbox if not set.")
+	cmdFlags.BoolVar(&DefaultSnapshotConfig.Force, fmt.Sprintf("%v%v", prefix, "force"), DefaultSnapshotConfig.Force, "Optional. Overwrite the snapshot if it already exists.")
+	cmdFlags.StringVar(&DefaultSnapshotConfig.Runtime, fmt.Sprintf("%v%v", prefix, "runtime"), DefaultSnapshotConfig.Runtime, "Optional. Container runtime running the sandbox: docker or podman. Detected from the available sockets if not set.")
+	return cmdFlags
+}
@@ -141,6 +141,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_usedBy", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("usedBy", testValue)
+			if vBool, err := cmdFlags.GetBool("usedBy"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.UsedBy)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_filter.fieldSelector", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -211,4 +225,18 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_fromPackage", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("fromPackage", testValue)
+			if vString, err := cmdFlags.GetString("fromPackage"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.FromPackage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
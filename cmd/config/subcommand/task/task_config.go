@@ -11,8 +11,10 @@ var (
 
 // Config
 type Config struct {
-	ExecFile string          `json:"execFile" pflag:",execution file name to be used for generating execution spec of a single task."`
-	Version  string          `json:"version" pflag:",version of the task to be fetched."`
-	Latest   bool            `json:"latest" pflag:", flag to indicate to fetch the latest version, version flag will be ignored in this case"`
-	Filter   filters.Filters `json:"filter" pflag:","`
+	ExecFile    string          `json:"execFile" pflag:",execution file name to be used for generating execution spec of a single task."`
+	Version     string          `json:"version" pflag:",version of the task to be fetched."`
+	Latest      bool            `json:"latest" pflag:", flag to indicate to fetch the latest version, version flag will be ignored in this case"`
+	UsedBy      bool            `json:"usedBy" pflag:", list the workflows and launch plans which use the task (restricted to the given version if any)."`
+	Filter      filters.Filters `json:"filter" pflag:","`
+	FromPackage string          `json:"fromPackage" pflag:",registration package tgz to read the tasks from instead of admin."`
 }
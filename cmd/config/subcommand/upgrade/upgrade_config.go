@@ -0,0 +1,16 @@
+package upgrade
+
+import "github.com/flyteorg/flytectl/pkg/github"
+
+//go:generate pflags Config --default-var DefaultConfig --bind-default-var
+var (
+	DefaultConfig = &Config{
+		Channel: github.ChannelStable,
+	}
+)
+
+// Config holds the flags of the upgrade command.
+type Config struct {
+	Version string `json:"version" pflag:",Optional. Version of flytectl to upgrade or downgrade to e.g. v0.5.0 or v0.5.x for the latest v0.5 patch. Takes precedence over the channel."`
+	Channel string `json:"channel" pflag:",Optional. Release channel to upgrade from: stable or prerelease."`
+}
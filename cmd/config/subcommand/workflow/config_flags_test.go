@@ -127,6 +127,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_dependencies", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("dependencies", testValue)
+			if vBool, err := cmdFlags.GetBool("dependencies"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Dependencies)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_filter.fieldSelector", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -197,4 +211,18 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_fromPackage", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("fromPackage", testValue)
+			if vString, err := cmdFlags.GetString("fromPackage"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.FromPackage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
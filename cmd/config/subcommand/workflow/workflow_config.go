@@ -18,7 +18,9 @@ var (
 
 // Config commandline configuration
 type Config struct {
-	Version string          `json:"version" pflag:",version of the workflow to be fetched."`
-	Latest  bool            `json:"latest" pflag:", flag to indicate to fetch the latest version, version flag will be ignored in this case"`
-	Filter  filters.Filters `json:"filter" pflag:","`
+	Version      string          `json:"version" pflag:",version of the workflow to be fetched."`
+	Latest       bool            `json:"latest" pflag:", flag to indicate to fetch the latest version, version flag will be ignored in this case"`
+	Dependencies bool            `json:"dependencies" pflag:", print the tasks and subworkflows and launch plans used by the workflow as a tree."`
+	Filter       filters.Filters `json:"filter" pflag:","`
+	FromPackage  string          `json:"fromPackage" pflag:",registration package tgz to read the workflows from instead of admin."`
 }
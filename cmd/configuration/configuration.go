@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/flyteorg/flytectl/pkg/util"
@@ -14,8 +15,11 @@ import (
 
 	initConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/config"
 	cmdcore "github.com/flyteorg/flytectl/cmd/core"
-	cmdUtil "github.com/flyteorg/flytectl/pkg/commandutils"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
+	// Registers the storage section for the generated config to be validated
+	_ "github.com/flyteorg/flytestdlib/storage"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/spf13/cobra"
 )
@@ -48,10 +52,39 @@ Generate remote cluster config with insecure connection:
 
  flytectl config init --host=flyte.myexample.com --insecure 
 
-Generate Flytectl config with a storage provider:
+Generate remote cluster config authenticating with a client secret instead of Pkce:
 ::
 
- flytectl config init --host=flyte.myexample.com --storage
+ flytectl config init --host=flyte.myexample.com --auth-type ClientSecret --client-id flytectl --client-secret-location /etc/secrets/client_secret
+
+The other supported auth types are DeviceFlow, and ExternalCommand which runs the command passed with --command to get the token:
+::
+
+ flytectl config init --host=flyte.myexample.com --auth-type ExternalCommand --command gcloud --command auth --command print-identity-token
+
+Generate Flytectl config with an s3 storage provider using the IAM credentials:
+::
+
+ flytectl config init --host=flyte.myexample.com --storage.type s3 --storage.bucket my-flyte-bucket --storage.region us-east-2
+
+Generate Flytectl config with a minio storage provider:
+::
+
+ flytectl config init --host=flyte.myexample.com --storage.type minio --storage.endpoint http://localhost:30084 --storage.bucket my-s3-bucket --storage.access-key minio --storage.secret-key miniostorage
+
+Generate Flytectl config with a gcs storage provider using the application default credentials:
+::
+
+ flytectl config init --host=flyte.myexample.com --storage.type gcs --storage.bucket my-flyte-bucket --storage.project-id my-project
+
+Prompt for the settings, the flags providing the default answers:
+::
+
+ flytectl config init --interactive
+
+The generated config is validated before it is written.
+
+Usage
 `
 )
 
@@ -65,9 +98,35 @@ func CreateConfigCommand() *cobra.Command {
 		"init": {CmdFunc: configInitFunc, Aliases: []string{""}, ProjectDomainNotRequired: true,
 			Short: initCmdShort,
 			Long:  initCmdLong, PFlagProvider: initConfig.DefaultConfig},
+		"get-contexts": {CmdFunc: getContextsFunc, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: getContextsCmdShort,
+			Long:  getContextsCmdLong, DisableFlyteClient: true},
+		"use-context": {CmdFunc: useContextFunc, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: useContextCmdShort,
+			Long:  useContextCmdLong, DisableFlyteClient: true},
+		"set-context": {CmdFunc: setContextFunc, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: setContextCmdShort,
+			Long:  setContextCmdLong, PFlagProvider: initConfig.DefaultContextConfig, DisableFlyteClient: true},
+		"view": {CmdFunc: viewConfigFunc, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: viewCmdShort,
+			Long:  viewCmdLong, PFlagProvider: initConfig.DefaultViewConfig, DisableFlyteClient: true},
+		"validate": {CmdFunc: validateConfigFunc, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: validateCmdShort,
+			Long:  validateCmdLong, DisableFlyteClient: true},
 	}
 
+	// Replaces the validate command of flytestdlib, which only reports the first error of the config.
+	for _, cmd := range configCmd.Commands() {
+		if cmd.Name() == "validate" {
+			configCmd.RemoveCommand(cmd)
+		}
+	}
 	cmdcore.AddCommands(configCmd, getResourcesFuncs)
+	for _, cmd := range configCmd.Commands() {
+		if cmd.Name() == "validate" {
+			cmd.PersistentPreRunE = skipConfigLoading
+		}
+	}
 	return configCmd
 }
 
@@ -81,36 +140,213 @@ func initFlytectlConfig(reader io.Reader) error {
 		return err
 	}
 
-	templateValues := configutil.ConfigTemplateSpec{
-		Host:     "dns:///localhost:30081",
-		Insecure: true,
+	p := newPrompter(reader)
+	cfg := *initConfig.DefaultConfig
+	if cfg.Interactive {
+		promptConfig(p, &cfg)
+	}
+	templateValues, err := configTemplateSpec(cfg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(configutil.ConfigFile); err == nil {
+		if !p.confirm(fmt.Sprintf("This action will overwrite an existing config file at [%s]. Do you want to continue?", configutil.ConfigFile), false) {
+			return nil
+		}
+	}
+	if err := writeConfig(configutil.ConfigFile, templateValues); err != nil {
+		return err
+	}
+	fmt.Printf("Init flytectl config file at [%s]", configutil.ConfigFile)
+	return nil
+}
+
+// promptConfig asks for the settings of the config, the values of the flags being the defaults of the answers
+func promptConfig(p *prompter, cfg *initConfig.Config) {
+	cfg.Host = p.ask("Endpoint of flyte admin", cfg.Host)
+	cfg.Insecure = p.confirm("Use an insecure connection?", cfg.Insecure)
+	cfg.AuthType = p.ask("Auth type (Pkce, ClientSecret, ExternalCommand or DeviceFlow)", or(cfg.AuthType, admin.AuthTypePkce.String()))
+	switch cfg.AuthType {
+	case admin.AuthTypeClientSecret.String():
+		cfg.ClientID = p.ask("Client ID", cfg.ClientID)
+		cfg.ClientSecretLocation = p.ask("File containing the client secret (leave empty to read it from an env var)", cfg.ClientSecretLocation)
+		if len(cfg.ClientSecretLocation) == 0 {
+			cfg.ClientSecretEnvVar = p.ask("Env var containing the client secret", cfg.ClientSecretEnvVar)
+		}
+	case admin.AuthTypeExternalCommand.String():
+		cfg.Command = p.askList("Command printing the token", " ", cfg.Command)
+	default:
+		cfg.ClientID = p.ask("Client ID (leave empty to use the one advertised by flyte admin)", cfg.ClientID)
 	}
-	templateStr := configutil.GetTemplate()
+	cfg.Scopes = p.askList("Scopes (comma separated, leave empty for the default ones)", ",", cfg.Scopes)
+	cfg.CACertPath = p.ask("Certificate file of flyte admin (leave empty to use the system certificates)", cfg.CACertPath)
 
-	if len(initConfig.DefaultConfig.Host) > 0 {
-		trimHost := trimEndpoint(initConfig.DefaultConfig.Host)
+	cfg.Storage.Type = p.ask("Storage type (s3, gcs or minio, leave empty to skip)", cfg.Storage.Type)
+	switch cfg.Storage.Type {
+	case storageTypeS3:
+		cfg.Storage.Bucket = p.ask("Bucket", cfg.Storage.Bucket)
+		cfg.Storage.Region = p.ask("Region", cfg.Storage.Region)
+		cfg.Storage.Endpoint = p.ask("Endpoint (leave empty for AWS)", cfg.Storage.Endpoint)
+		cfg.Storage.AuthType = p.ask("Credentials source (iam or accesskey)", or(cfg.Storage.AuthType, storageAuthTypeIAM))
+	case storageTypeMinio:
+		cfg.Storage.Endpoint = p.ask("Endpoint", or(cfg.Storage.Endpoint, defaultMinioEndpoint))
+		cfg.Storage.Bucket = p.ask("Bucket", or(cfg.Storage.Bucket, defaultMinioBucket))
+		cfg.Storage.AuthType = storageAuthTypeAccessKey
+	case storageTypeGCS:
+		cfg.Storage.Bucket = p.ask("Bucket", cfg.Storage.Bucket)
+		cfg.Storage.ProjectID = p.ask("Google Cloud project", cfg.Storage.ProjectID)
+	}
+	if cfg.Storage.AuthType == storageAuthTypeAccessKey {
+		cfg.Storage.AccessKey = p.ask("Access key", cfg.Storage.AccessKey)
+		cfg.Storage.SecretKey = p.ask("Secret key", cfg.Storage.SecretKey)
+	}
+}
+
+// configTemplateSpec validates the flags of config init and returns the values of the config template
+func configTemplateSpec(cfg initConfig.Config) (configutil.ConfigTemplateSpec, error) {
+	spec := configutil.ConfigTemplateSpec{
+		Host:                 "dns:///localhost:30081",
+		Insecure:             true,
+		AuthType:             or(cfg.AuthType, admin.AuthTypePkce.String()),
+		ClientID:             cfg.ClientID,
+		ClientSecretLocation: cfg.ClientSecretLocation,
+		ClientSecretEnvVar:   cfg.ClientSecretEnvVar,
+		Scopes:               cfg.Scopes,
+		CACertFilePath:       cfg.CACertPath,
+		Command:              cfg.Command,
+	}
+	if len(cfg.Host) > 0 {
+		trimHost := trimEndpoint(cfg.Host)
 		if !validateEndpointName(trimHost) {
-			return errors.New("Please use a valid endpoint")
+			return spec, errors.New("Please use a valid endpoint")
+		}
+		spec.Host = fmt.Sprintf("dns:///%s", trimHost)
+		spec.Insecure = cfg.Insecure
+	}
+
+	authType, err := admin.AuthTypeString(spec.AuthType)
+	if err != nil {
+		return spec, fmt.Errorf("unsupported auth type %q. Supported auth types are %v", spec.AuthType, admin.AuthTypeValues())
+	}
+	hasSecret := len(cfg.ClientSecretLocation) > 0 || len(cfg.ClientSecretEnvVar) > 0
+	switch authType {
+	case admin.AuthTypeClientSecret:
+		if len(cfg.ClientID) == 0 || !hasSecret {
+			return spec, errors.New("the ClientSecret auth type requires a client-id and a client-secret-location or client-secret-env-var")
+		}
+	case admin.AuthTypeExternalCommand:
+		if len(cfg.Command) == 0 {
+			return spec, errors.New("the ExternalCommand auth type requires a command")
+		}
+	}
+	if authType != admin.AuthTypeClientSecret && hasSecret {
+		return spec, fmt.Errorf("the client secret is only used by the ClientSecret auth type, not by %v", authType)
+	}
+	if authType != admin.AuthTypeExternalCommand && len(cfg.Command) > 0 {
+		return spec, fmt.Errorf("the command is only used by the ExternalCommand auth type, not by %v", authType)
+	}
+
+	if len(cfg.Storage.Type) > 0 {
+		spec.Storage, err = storageTemplateSpec(cfg.Storage)
+		if err != nil {
+			return spec, err
+		}
+	}
+	return spec, nil
+}
+
+const (
+	storageTypeS3    = "s3"
+	storageTypeGCS   = "gcs"
+	storageTypeMinio = "minio"
+
+	storageAuthTypeIAM       = "iam"
+	storageAuthTypeAccessKey = "accesskey"
+
+	defaultMinioEndpoint = "http://localhost:30084"
+	defaultMinioBucket   = "my-s3-bucket"
+	gcsScope             = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// storageTemplateSpec returns the storage section for the storage flags. The s3 and minio stores are configured through
+// the s3 connection of the flytestdlib storage, and gcs through the google stow kind using the default credentials.
+func storageTemplateSpec(cfg initConfig.StorageConfig) (*configutil.StorageTemplateSpec, error) {
+	if len(cfg.Bucket) == 0 {
+		return nil, fmt.Errorf("the %v storage requires a bucket", cfg.Type)
+	}
+	spec := &configutil.StorageTemplateSpec{Container: cfg.Bucket}
+	switch cfg.Type {
+	case storageTypeS3, storageTypeMinio:
+		spec.Type = cfg.Type
+		spec.Endpoint = cfg.Endpoint
+		spec.Region = cfg.Region
+		spec.AuthType = or(cfg.AuthType, storageAuthTypeIAM)
+		if cfg.Type == storageTypeMinio {
+			if len(cfg.Endpoint) == 0 {
+				return nil, errors.New("the minio storage requires an endpoint")
+			}
+			spec.AuthType = or(cfg.AuthType, storageAuthTypeAccessKey)
+			spec.Region = or(cfg.Region, "us-east-1")
 		}
-		templateValues.Host = fmt.Sprintf("dns:///%s", trimHost)
-		templateValues.Insecure = initConfig.DefaultConfig.Insecure
-	}
-	var _err error
-	if _, err := os.Stat(configutil.ConfigFile); os.IsNotExist(err) {
-		_err = configutil.SetupConfig(configutil.ConfigFile, templateStr, templateValues)
-	} else {
-		if cmdUtil.AskForConfirmation(fmt.Sprintf("This action will overwrite an existing config file at [%s]. Do you want to continue?", configutil.ConfigFile), reader) {
-			if err := os.Remove(configutil.ConfigFile); err != nil {
-				return err
+		switch spec.AuthType {
+		case storageAuthTypeIAM:
+		case storageAuthTypeAccessKey:
+			if len(cfg.AccessKey) == 0 || len(cfg.SecretKey) == 0 {
+				return nil, errors.New("the accesskey storage auth type requires an access-key and a secret-key")
 			}
-			_err = configutil.SetupConfig(configutil.ConfigFile, templateStr, templateValues)
+			spec.AccessKey = cfg.AccessKey
+			spec.SecretKey = cfg.SecretKey
+		default:
+			return nil, fmt.Errorf("unsupported storage auth type %q. Supported auth types are iam and accesskey", spec.AuthType)
 		}
+		spec.DisableSSL = strings.HasPrefix(cfg.Endpoint, "http://")
+	case storageTypeGCS:
+		if len(cfg.ProjectID) == 0 {
+			return nil, errors.New("the gcs storage requires a project-id")
+		}
+		spec.Type = "stow"
+		spec.StowKind = "google"
+		spec.StowConfig = map[string]string{
+			"json":       "",
+			"project_id": cfg.ProjectID,
+			"scopes":     gcsScope,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported storage type %q. Supported storage types are s3, gcs and minio", cfg.Type)
+	}
+	return spec, nil
+}
+
+// writeConfig renders the config to a temporary file and validates it by loading it through the config accessor, before
+// replacing the config file with it.
+func writeConfig(configFile string, templateValues configutil.ConfigTemplateSpec) error {
+	// The accessor infers the format of the file from its extension
+	f, err := os.CreateTemp(filepath.Dir(configFile), "config-*.yaml")
+	if err != nil {
+		return err
 	}
-	if _err != nil {
-		return _err
+	tmpFile := f.Name()
+	_ = f.Close()
+	defer os.Remove(tmpFile)
+	if err := configutil.SetupConfig(tmpFile, configutil.GetTemplate(), templateValues); err != nil {
+		return err
 	}
-	fmt.Printf("Init flytectl config file at [%s]", configutil.ConfigFile)
-	return nil
+	accessor := viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{tmpFile},
+	})
+	if err := accessor.UpdateConfig(context.TODO()); err != nil {
+		return fmt.Errorf("the generated config is invalid: %w", err)
+	}
+	return os.Rename(tmpFile, configFile)
+}
+
+func or(value, defaultValue string) string {
+	if len(value) > 0 {
+		return value
+	}
+	return defaultValue
 }
 
 func trimEndpoint(hostname string) string {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -25,7 +26,7 @@ func TestCreateInitCommand(t *testing.T) {
 	assert.Equal(t, configCmd.Use, "config")
 	assert.Equal(t, configCmd.Short, "Runs various config commands, look at the help of this command to get a list of available commands..")
 	fmt.Println(configCmd.Commands())
-	assert.Equal(t, 4, len(configCmd.Commands()))
+	assert.Equal(t, 8, len(configCmd.Commands()))
 	cmdNouns := configCmd.Commands()
 	// Sort by Use value.
 	sort.Slice(cmdNouns, func(i, j int) bool {
@@ -37,10 +38,18 @@ func TestCreateInitCommand(t *testing.T) {
 	assert.Equal(t, "docs", cmdNouns[1].Use)
 	assert.Equal(t, "Generate configuration documetation in rst format", cmdNouns[1].Short)
 
-	assert.Equal(t, "init", cmdNouns[2].Use)
-	assert.Equal(t, initCmdShort, cmdNouns[2].Short)
-	assert.Equal(t, "validate", cmdNouns[3].Use)
-	assert.Equal(t, "Validates the loaded config.", cmdNouns[3].Short)
+	assert.Equal(t, "get-contexts", cmdNouns[2].Use)
+	assert.Equal(t, getContextsCmdShort, cmdNouns[2].Short)
+	assert.Equal(t, "init", cmdNouns[3].Use)
+	assert.Equal(t, initCmdShort, cmdNouns[3].Short)
+	assert.Equal(t, "set-context", cmdNouns[4].Use)
+	assert.Equal(t, setContextCmdShort, cmdNouns[4].Short)
+	assert.Equal(t, "use-context", cmdNouns[5].Use)
+	assert.Equal(t, useContextCmdShort, cmdNouns[5].Short)
+	assert.Equal(t, "validate", cmdNouns[6].Use)
+	assert.Equal(t, "Validates the loaded config.", cmdNouns[6].Short)
+	assert.Equal(t, "view", cmdNouns[7].Use)
+	assert.Equal(t, viewCmdShort, cmdNouns[7].Short)
 
 }
 
@@ -68,6 +77,162 @@ func TestSetupConfigFunc(t *testing.T) {
 	assert.Nil(t, initFlytectlConfig(yes))
 }
 
+func withConfigFile(t *testing.T) string {
+	configFile := configutil.ConfigFile
+	defaultConfig := *initConfig.DefaultConfig
+	t.Cleanup(func() {
+		configutil.ConfigFile = configFile
+		*initConfig.DefaultConfig = defaultConfig
+	})
+	configutil.ConfigFile = filepath.Join(t.TempDir(), "config.yaml")
+	return configutil.ConfigFile
+}
+
+func TestInitFlytectlConfigAuthTypes(t *testing.T) {
+	t.Run("ClientSecret", func(t *testing.T) {
+		configFile := withConfigFile(t)
+		initConfig.DefaultConfig.Host = "flyte.example.com"
+		initConfig.DefaultConfig.AuthType = "ClientSecret"
+		initConfig.DefaultConfig.ClientID = "flytectl"
+		initConfig.DefaultConfig.ClientSecretLocation = "/etc/secrets/client-secret"
+		initConfig.DefaultConfig.Scopes = []string{"all"}
+		initConfig.DefaultConfig.CACertPath = "/etc/certs/ca.pem"
+		assert.Nil(t, initFlytectlConfig(strings.NewReader("")))
+		data, err := os.ReadFile(configFile)
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), "endpoint: dns:///flyte.example.com")
+		assert.Contains(t, string(data), "authType: ClientSecret")
+		assert.Contains(t, string(data), `clientId: "flytectl"`)
+		assert.Contains(t, string(data), `clientSecretLocation: "/etc/secrets/client-secret"`)
+		assert.Contains(t, string(data), `scopes: ["all"]`)
+		assert.Contains(t, string(data), `caCertFilePath: "/etc/certs/ca.pem"`)
+		assert.NotContains(t, string(data), "storage:")
+	})
+	t.Run("ExternalCommand", func(t *testing.T) {
+		configFile := withConfigFile(t)
+		initConfig.DefaultConfig.AuthType = "ExternalCommand"
+		initConfig.DefaultConfig.Command = []string{"gcloud", "auth", "print-identity-token"}
+		assert.Nil(t, initFlytectlConfig(strings.NewReader("")))
+		data, err := os.ReadFile(configFile)
+		assert.Nil(t, err)
+		assert.Contains(t, string(data), "authType: ExternalCommand")
+		assert.Contains(t, string(data), `command: ["gcloud","auth","print-identity-token"]`)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		tests := []struct {
+			config initConfig.Config
+			err    string
+		}{
+			{initConfig.Config{AuthType: "Basic"}, `unsupported auth type "Basic"`},
+			{initConfig.Config{AuthType: "ClientSecret", ClientID: "flytectl"}, "the ClientSecret auth type requires"},
+			{initConfig.Config{AuthType: "ExternalCommand"}, "the ExternalCommand auth type requires a command"},
+			{initConfig.Config{ClientSecretEnvVar: "SECRET"}, "the client secret is only used by the ClientSecret auth type"},
+			{initConfig.Config{AuthType: "DeviceFlow", Command: []string{"echo"}}, "the command is only used by the ExternalCommand auth type"},
+		}
+		for _, test := range tests {
+			configFile := withConfigFile(t)
+			*initConfig.DefaultConfig = test.config
+			err := initFlytectlConfig(strings.NewReader(""))
+			if assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+			_, err = os.Stat(configFile)
+			assert.True(t, os.IsNotExist(err))
+		}
+	})
+}
+
+func TestInitFlytectlConfigStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  initConfig.StorageConfig
+		contains []string
+		err      string
+	}{
+		{
+			name:     "s3 iam",
+			storage:  initConfig.StorageConfig{Type: "s3", Bucket: "flyte-data", Region: "us-west-2"},
+			contains: []string{"type: s3", `container: "flyte-data"`, "auth-type: iam", `region: "us-west-2"`},
+		},
+		{
+			name: "minio",
+			storage: initConfig.StorageConfig{Type: "minio", Bucket: "my-s3-bucket", Endpoint: "http://localhost:30084",
+				AccessKey: "minio", SecretKey: "miniostorage"},
+			contains: []string{"type: minio", `endpoint: "http://localhost:30084"`, "auth-type: accesskey",
+				`access-key: "minio"`, `secret-key: "miniostorage"`, "disable-ssl: true"},
+		},
+		{
+			name:    "gcs",
+			storage: initConfig.StorageConfig{Type: "gcs", Bucket: "flyte-data", ProjectID: "my-project"},
+			contains: []string{"type: stow", `container: "flyte-data"`, "kind: google", `project_id: "my-project"`,
+				`scopes: "https://www.googleapis.com/auth/cloud-platform"`},
+		},
+		{name: "missing bucket", storage: initConfig.StorageConfig{Type: "s3"}, err: "the s3 storage requires a bucket"},
+		{name: "missing endpoint", storage: initConfig.StorageConfig{Type: "minio", Bucket: "b"}, err: "the minio storage requires an endpoint"},
+		{name: "missing keys", storage: initConfig.StorageConfig{Type: "s3", Bucket: "b", AuthType: "accesskey"}, err: "requires an access-key and a secret-key"},
+		{name: "missing project", storage: initConfig.StorageConfig{Type: "gcs", Bucket: "b"}, err: "the gcs storage requires a project-id"},
+		{name: "unsupported type", storage: initConfig.StorageConfig{Type: "azure", Bucket: "b"}, err: `unsupported storage type "azure"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configFile := withConfigFile(t)
+			initConfig.DefaultConfig.Storage = test.storage
+			err := initFlytectlConfig(strings.NewReader(""))
+			if len(test.err) > 0 {
+				if assert.NotNil(t, err) {
+					assert.Contains(t, err.Error(), test.err)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			data, err := os.ReadFile(configFile)
+			assert.Nil(t, err)
+			for _, s := range test.contains {
+				assert.Contains(t, string(data), s)
+			}
+		})
+	}
+}
+
+func TestInitFlytectlConfigInteractive(t *testing.T) {
+	configFile := withConfigFile(t)
+	initConfig.DefaultConfig.Interactive = true
+	answers := strings.Join([]string{
+		"flyte.example.com",
+		"n",
+		"ClientSecret",
+		"flytectl",
+		"",
+		"FLYTE_CLIENT_SECRET",
+		"all, offline",
+		"",
+		"minio",
+		"",
+		"",
+		"minio",
+		"miniostorage",
+	}, "\n")
+	assert.Nil(t, initFlytectlConfig(strings.NewReader(answers)))
+	data, err := os.ReadFile(configFile)
+	assert.Nil(t, err)
+	for _, s := range []string{"endpoint: dns:///flyte.example.com", "insecure: false", "authType: ClientSecret",
+		`clientId: "flytectl"`, `clientSecretEnvVar: "FLYTE_CLIENT_SECRET"`, `scopes: ["all","offline"]`, "type: minio",
+		`container: "my-s3-bucket"`, `endpoint: "http://localhost:30084"`, `access-key: "minio"`} {
+		assert.Contains(t, string(data), s)
+	}
+
+	// The existing file is kept unless the overwrite is confirmed
+	initConfig.DefaultConfig.Interactive = false
+	assert.Nil(t, initFlytectlConfig(strings.NewReader("n")))
+	kept, err := os.ReadFile(configFile)
+	assert.Nil(t, err)
+	assert.Equal(t, data, kept)
+	assert.Nil(t, initFlytectlConfig(strings.NewReader("y")))
+	overwritten, err := os.ReadFile(configFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(overwritten), "endpoint: dns:///localhost:30081")
+}
+
 func TestTrimFunc(t *testing.T) {
 	assert.Equal(t, trimEndpoint("dns:///localhost"), "localhost")
 	assert.Equal(t, trimEndpoint("http://localhost"), "localhost")
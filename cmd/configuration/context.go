@@ -0,0 +1,185 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	initConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/config"
+	cmdcore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/printer"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using Sphinx.
+const (
+	getContextsCmdShort = `Lists the contexts of the Flytectl config file.`
+	getContextsCmdLong  = `
+Lists the contexts of the config file, the current one being marked with a *. A context holds the admin endpoint, the
+auth settings, the default project and domain and the storage settings of a Flyte deployment, kubeconfig style:
+::
+
+ current-context: sandbox
+ contexts:
+   - name: sandbox
+     admin:
+       endpoint: dns:///localhost:30081
+       insecure: true
+     project: flytesnacks
+     domain: development
+   - name: production
+     admin:
+       endpoint: dns:///flyte.myexample.com
+       authType: Pkce
+     storage:
+       type: s3
+       container: my-bucket
+ logger:
+   level: 0
+
+The admin and storage settings of the context are merged over the top-level ones of the file.
+::
+
+ flytectl config get-contexts
+
+A context can be used for a single command with the --context flag or the FLYTECTL_CONTEXT env var:
+::
+
+ flytectl get projects --context production
+`
+	useContextCmdShort = `Sets the current context of the Flytectl config file.`
+	useContextCmdLong  = `
+Sets the context used by the Flytectl commands which aren't passed the --context flag.
+::
+
+ flytectl config use-context production
+`
+	setContextCmdShort = `Creates or updates a context of the Flytectl config file.`
+	setContextCmdLong  = `
+Creates a context, or updates the settings of an existing one which are passed as flags. The context is used once
+selected with flytectl config use-context or the --context flag.
+::
+
+ flytectl config set-context production --endpoint dns:///flyte.myexample.com --authType Pkce --project flytesnacks --domain production
+
+Creates a context for the sandbox:
+::
+
+ flytectl config set-context sandbox --endpoint dns:///localhost:30081 --insecure
+
+The tokens of every context are kept apart in the keyring. The storage settings of a context are edited in the file.
+`
+)
+
+// contextSummary is the view of a context printed by get-contexts
+type contextSummary struct {
+	Current  string `json:"current"`
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+	Project  string `json:"project"`
+	Domain   string `json:"domain"`
+}
+
+var contextColumns = []printer.Column{
+	{Header: "Current", JSONPath: "$.current"},
+	{Header: "Name", JSONPath: "$.name"},
+	{Header: "Endpoint", JSONPath: "$.endpoint"},
+	{Header: "Project", JSONPath: "$.project"},
+	{Header: "Domain", JSONPath: "$.domain"},
+}
+
+func getContextsFunc(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+	if err != nil {
+		return err
+	}
+	current := configutil.CurrentContext
+	if len(current) == 0 {
+		current = file.CurrentContext
+	}
+	summaries := make([]contextSummary, 0, len(file.Contexts))
+	for _, c := range file.Contexts {
+		summary := contextSummary{Name: c.Name, Endpoint: c.Endpoint(), Project: c.Project, Domain: c.Domain}
+		if c.Name == current {
+			summary.Current = "*"
+		}
+		summaries = append(summaries, summary)
+	}
+	p := printer.Printer{}
+	return p.PrintInterface(config.GetConfig().MustOutputFormat(), contextColumns, summaries)
+}
+
+func useContextFunc(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected the name of the context. Please check usage examples by running flytectl config use-context --help")
+	}
+	file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+	if err != nil {
+		return err
+	}
+	if err := file.UseContext(args[0]); err != nil {
+		return fmt.Errorf("%w in %v", err, configutil.ConfigFileUsed)
+	}
+	if err := file.Write(configutil.ConfigFileUsed); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to context %q\n", args[0])
+	return nil
+}
+
+func setContextFunc(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected the name of the context. Please check usage examples by running flytectl config set-context --help")
+	}
+	file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+	if err != nil {
+		return err
+	}
+	c := configutil.Context{Name: args[0]}
+	existing, exists := file.GetContext(args[0])
+	if exists {
+		c = *existing
+	}
+	if err := applyContextConfig(&c, initConfig.DefaultContextConfig, !exists); err != nil {
+		return err
+	}
+	file.SetContext(c)
+	if err := file.Write(configutil.ConfigFileUsed); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Printf("Context %q modified\n", c.Name)
+	} else {
+		fmt.Printf("Context %q created\n", c.Name)
+	}
+	return nil
+}
+
+func applyContextConfig(c *configutil.Context, cfg *initConfig.ContextConfig, created bool) error {
+	if c.Admin == nil {
+		c.Admin = map[string]interface{}{}
+	}
+	if len(cfg.Endpoint) > 0 {
+		trimHost := trimEndpoint(cfg.Endpoint)
+		if !validateEndpointName(trimHost) {
+			return fmt.Errorf("please use a valid endpoint")
+		}
+		c.Admin["endpoint"] = fmt.Sprintf("dns:///%s", trimHost)
+		c.Admin["insecure"] = cfg.Insecure
+	} else if created && cfg.Insecure {
+		c.Admin["insecure"] = true
+	}
+	if len(cfg.AuthType) > 0 {
+		c.Admin["authType"] = cfg.AuthType
+	}
+	if len(cfg.ClientID) > 0 {
+		c.Admin["clientId"] = cfg.ClientID
+	}
+	if len(cfg.Project) > 0 {
+		c.Project = cfg.Project
+	}
+	if len(cfg.Domain) > 0 {
+		c.Domain = cfg.Domain
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package configuration
+
+import (
+	"path/filepath"
+	"testing"
+
+	initConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/config"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextCommands(t *testing.T) {
+	s := testutils.Setup()
+	configFileUsed := configutil.ConfigFileUsed
+	configutil.ConfigFileUsed = filepath.Join(t.TempDir(), "config.yaml")
+	defer func() {
+		configutil.ConfigFileUsed = configFileUsed
+		*initConfig.DefaultContextConfig = initConfig.ContextConfig{}
+	}()
+
+	t.Run("Set context", func(t *testing.T) {
+		*initConfig.DefaultContextConfig = initConfig.ContextConfig{Endpoint: "localhost:30081", Insecure: true,
+			Project: "flytesnacks", Domain: "development"}
+		assert.Nil(t, setContextFunc(s.Ctx, []string{"sandbox"}, s.CmdCtx))
+		*initConfig.DefaultContextConfig = initConfig.ContextConfig{Endpoint: "https://flyte.myexample.com", AuthType: "Pkce"}
+		assert.Nil(t, setContextFunc(s.Ctx, []string{"production"}, s.CmdCtx))
+
+		file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+		assert.Nil(t, err)
+		assert.Empty(t, file.CurrentContext)
+		sandbox, ok := file.GetContext("sandbox")
+		assert.True(t, ok)
+		assert.Equal(t, configutil.Context{Name: "sandbox", Project: "flytesnacks", Domain: "development",
+			Admin: map[string]interface{}{"endpoint": "dns:///localhost:30081", "insecure": true}}, *sandbox)
+		production, ok := file.GetContext("production")
+		assert.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"endpoint": "dns:///flyte.myexample.com", "insecure": false, "authType": "Pkce"},
+			production.Admin)
+	})
+	t.Run("Update context", func(t *testing.T) {
+		*initConfig.DefaultContextConfig = initConfig.ContextConfig{Domain: "staging", ClientID: "flytectl"}
+		assert.Nil(t, setContextFunc(s.Ctx, []string{"sandbox"}, s.CmdCtx))
+
+		file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+		assert.Nil(t, err)
+		sandbox, _ := file.GetContext("sandbox")
+		assert.Equal(t, configutil.Context{Name: "sandbox", Project: "flytesnacks", Domain: "staging",
+			Admin: map[string]interface{}{"endpoint": "dns:///localhost:30081", "insecure": true, "clientId": "flytectl"}}, *sandbox)
+	})
+	t.Run("Set context with invalid endpoint", func(t *testing.T) {
+		*initConfig.DefaultContextConfig = initConfig.ContextConfig{Endpoint: "flyte.myexample.com:81/console"}
+		assert.EqualError(t, setContextFunc(s.Ctx, []string{"invalid"}, s.CmdCtx), "please use a valid endpoint")
+	})
+	t.Run("Use context", func(t *testing.T) {
+		assert.Nil(t, useContextFunc(s.Ctx, []string{"production"}, s.CmdCtx))
+		file, err := configutil.LoadContextsFile(configutil.ConfigFileUsed)
+		assert.Nil(t, err)
+		assert.Equal(t, "production", file.CurrentContext)
+
+		err = useContextFunc(s.Ctx, []string{"staging"}, s.CmdCtx)
+		assert.EqualError(t, err, "context \"staging\" not found in "+configutil.ConfigFileUsed)
+		assert.NotNil(t, useContextFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Get contexts", func(t *testing.T) {
+		assert.Nil(t, getContextsFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+}
@@ -0,0 +1,68 @@
+package configuration
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prompter asks the questions of the interactive config init, all of them reading the answers from the same reader
+type prompter struct {
+	scanner *bufio.Scanner
+}
+
+func newPrompter(reader io.Reader) *prompter {
+	return &prompter{scanner: bufio.NewScanner(reader)}
+}
+
+// ask returns the answer to the question, or the default value if the answer is empty
+func (p *prompter) ask(question, defaultValue string) string {
+	if len(defaultValue) > 0 {
+		fmt.Printf("%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+	if !p.scanner.Scan() {
+		return defaultValue
+	}
+	if answer := strings.TrimSpace(p.scanner.Text()); len(answer) > 0 {
+		return answer
+	}
+	return defaultValue
+}
+
+// askList returns the answer to the question split on the separator
+func (p *prompter) askList(question, sep string, defaultValue []string) []string {
+	answer := p.ask(question, strings.Join(defaultValue, sep))
+	var values []string
+	for _, v := range strings.Split(answer, sep) {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// confirm asks a yes or no question until it gets an answer, the default value being returned for an empty answer or
+// at the end of the input
+func (p *prompter) confirm(question string, defaultValue bool) bool {
+	choices := "y/N"
+	if defaultValue {
+		choices = "Y/n"
+	}
+	for {
+		fmt.Printf("%s [%s]: ", question, choices)
+		if !p.scanner.Scan() {
+			return defaultValue
+		}
+		switch strings.ToLower(strings.TrimSpace(p.scanner.Text())) {
+		case "":
+			return defaultValue
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+	}
+}
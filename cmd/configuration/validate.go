@@ -0,0 +1,75 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	cmdcore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/spf13/cobra"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using Sphinx.
+const (
+	validateCmdShort = `Validates the loaded config.`
+	validateCmdLong  = `
+Validates the config file in use, i.e. the one passed with --config, else the one of the FLYTECTL_CONFIG env var, else
+$HOME/.flyte/config.yaml. It reports with their line the unknown keys, the invalid values and the deprecated keys, which
+are warnings. The settings of the contexts of the file are validated too.
+::
+
+ flytectl config validate
+
+Validates another config file:
+::
+
+ flytectl config validate ~/.flyte/config-sandbox.yaml
+
+The command fails when an error is found.
+
+Usage
+`
+)
+
+var problemColumns = []printer.Column{
+	{Header: "Line", JSONPath: "$.line"},
+	{Header: "Severity", JSONPath: "$.severity"},
+	{Header: "Key", JSONPath: "$.key"},
+	{Header: "Message", JSONPath: "$.message"},
+}
+
+// skipConfigLoading replaces the loading of the config before running the command by the resolution of the config file,
+// for config validate to report all the problems of the file instead of failing on the first one.
+func skipConfigLoading(cmd *cobra.Command, _ []string) error {
+	configFile, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return err
+	}
+	configutil.ConfigFileUsed = configutil.ResolveConfigFile(configFile)
+	return nil
+}
+
+func validateConfigFunc(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	configFile := configutil.ConfigFileUsed
+	if len(args) > 0 {
+		configFile = args[0]
+	}
+	problems, err := configutil.ValidateConfigFile(configFile, stdConfig.GetRootSection())
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		p := printer.Printer{}
+		if err := p.PrintInterface(config.GetConfig().MustOutputFormat(), problemColumns, problems); err != nil {
+			return err
+		}
+	}
+	if errorCount := configutil.ErrorCount(problems); errorCount > 0 {
+		return fmt.Errorf("%v errors found in config file %v", errorCount, configFile)
+	}
+	fmt.Printf("Config file %v is valid\n", configFile)
+	return nil
+}
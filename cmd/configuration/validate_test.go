@@ -0,0 +1,46 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigFunc(t *testing.T) {
+	s := testutils.Setup()
+	configFileUsed := configutil.ConfigFileUsed
+	defer func() { configutil.ConfigFileUsed = configFileUsed }()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, os.WriteFile(valid, []byte("admin:\n  endpoint: dns:///localhost:30081\n  insecure: true\n"), 0600))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.Nil(t, os.WriteFile(invalid, []byte("admin:\n  endpoint: dns:///localhost:30081\n  insecure: maybe\n  useAuth: true\nfoo: bar\n"), 0600))
+
+	configutil.ConfigFileUsed = valid
+	assert.Nil(t, validateConfigFunc(s.Ctx, []string{}, s.CmdCtx))
+	err := validateConfigFunc(s.Ctx, []string{invalid}, s.CmdCtx)
+	assert.EqualError(t, err, fmt.Sprintf("2 errors found in config file %v", invalid))
+	_, err = os.Stat(valid)
+	assert.Nil(t, err)
+}
+
+func TestSkipConfigLoading(t *testing.T) {
+	configFileUsed := configutil.ConfigFileUsed
+	defer func() { configutil.ConfigFileUsed = configFileUsed }()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+
+	t.Setenv("FLYTECTL_CONFIG", "/etc/flyte/config.yaml")
+	assert.Nil(t, skipConfigLoading(cmd, nil))
+	assert.Equal(t, "/etc/flyte/config.yaml", configutil.ConfigFileUsed)
+	assert.Nil(t, cmd.Flags().Set("config", "/tmp/config.yaml"))
+	assert.Nil(t, skipConfigLoading(cmd, nil))
+	assert.Equal(t, "/tmp/config.yaml", configutil.ConfigFileUsed)
+}
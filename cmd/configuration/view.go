@@ -0,0 +1,74 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	initConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/config"
+	cmdcore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"sigs.k8s.io/yaml"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using Sphinx.
+const (
+	viewCmdShort = `Prints the Flytectl config file or the config in effect.`
+	viewCmdLong  = `
+Prints the config file in use, i.e. the one passed with --config, else the one of the FLYTECTL_CONFIG env var, else
+$HOME/.flyte/config.yaml. The values of the secrets are redacted.
+::
+
+ flytectl config view
+
+Prints the settings in effect of every config section, e.g. root, admin, storage, files and logger, once the config file,
+the selected context, the env vars and the flags are merged, with the source of every value:
+::
+
+ flytectl config view --effective
+
+The source of a value is flag, env, context <name>, file or default, the first one being the one in effect. The env var
+of a setting is its key in upper case, e.g. ADMIN.ENDPOINT. The settings can be printed in YAML too:
+::
+
+ flytectl config view --effective -o yaml --admin.endpoint dns:///flyte.myexample.com
+
+Usage
+`
+)
+
+var settingColumns = []printer.Column{
+	{Header: "Key", JSONPath: "$.key"},
+	{Header: "Value", JSONPath: "$.value"},
+	{Header: "Source", JSONPath: "$.source"},
+}
+
+func viewConfigFunc(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	if initConfig.DefaultViewConfig.Effective {
+		settings, err := configutil.EffectiveSettings(stdConfig.GetRootSection(), configutil.ConfigFlags,
+			configutil.ConfigFileUsed, configutil.CurrentContext)
+		if err != nil {
+			return err
+		}
+		p := printer.Printer{}
+		return p.PrintInterface(config.GetConfig().MustOutputFormat(), settingColumns, settings)
+	}
+
+	data, err := os.ReadFile(configutil.ConfigFileUsed)
+	if err != nil {
+		return err
+	}
+	var file interface{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse config file %v: %w", configutil.ConfigFileUsed, err)
+	}
+	data, err = yaml.Marshal(configutil.RedactSecrets(file))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("# %v\n%s", configutil.ConfigFileUsed, data)
+	return nil
+}
@@ -0,0 +1,42 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	initConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/config"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewConfigFunc(t *testing.T) {
+	s := testutils.Setup()
+	configFileUsed := configutil.ConfigFileUsed
+	defer func() {
+		configutil.ConfigFileUsed = configFileUsed
+		*initConfig.DefaultViewConfig = initConfig.ViewConfig{}
+	}()
+	configutil.ConfigFileUsed = filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(configutil.ConfigFileUsed, []byte(`
+admin:
+  endpoint: dns:///localhost:30081
+storage:
+  connection:
+    secret-key: miniostorage
+`), 0600))
+
+	t.Run("File", func(t *testing.T) {
+		assert.Nil(t, viewConfigFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Effective", func(t *testing.T) {
+		initConfig.DefaultViewConfig.Effective = true
+		assert.Nil(t, viewConfigFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Missing file", func(t *testing.T) {
+		initConfig.DefaultViewConfig.Effective = false
+		configutil.ConfigFileUsed = filepath.Join(t.TempDir(), "config.yaml")
+		assert.NotNil(t, viewConfigFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+}
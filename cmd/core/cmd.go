@@ -9,7 +9,8 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/flyteorg/flytectl/cmd/config"
-	"github.com/flyteorg/flytectl/pkg/pkce"
+	"github.com/flyteorg/flytectl/pkg/auth"
+	"github.com/flyteorg/flytectl/pkg/configutil"
 	"github.com/flyteorg/flyteidl/clients/go/admin"
 
 	"github.com/spf13/cobra"
@@ -72,11 +73,12 @@ func generateCommandFunc(cmdEntry CommandEntry) func(cmd *cobra.Command, args []
 
 		cmdCtx := NewCommandContextNoClient(cmd.OutOrStdout())
 		if !cmdEntry.DisableFlyteClient {
+			tokenCache, err := auth.NewTokenCache(adminCfg, configutil.CurrentContext)
+			if err != nil {
+				return err
+			}
 			clientSet, err := admin.ClientSetBuilder().WithConfig(admin.GetConfig(ctx)).
-				WithTokenCache(pkce.TokenCacheKeyringProvider{
-					ServiceUser: fmt.Sprintf("%s:%s", adminCfg.Endpoint.String(), pkce.KeyRingServiceUser),
-					ServiceName: pkce.KeyRingServiceName,
-				}).Build(ctx)
+				WithTokenCache(tokenCache).Build(ctx)
 			if err != nil {
 				return err
 			}
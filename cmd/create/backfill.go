@@ -0,0 +1,177 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytectl/clierrors"
+	"github.com/flyteorg/flytectl/cmd/config"
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/backfill"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/clients/go/coreutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	backfillShort = "Creates executions for the missed schedules of a launch plan."
+	backfillLong  = `
+Create one execution per schedule slot of a scheduled launch plan within a time window.
+The fire times are computed from the launch plan's cron or fixed rate schedule, and the kickoff time
+input defined by the schedule is set to the slot time for every execution.
+
+::
+
+ flytectl create backfill -p flytesnacks -d development --launchplan core.scheduled_workflows.lp_schedules.daily_wf --version v1 --from 2026-01-01 --to 2026-02-01
+
+The window includes --from and excludes --to. Both accept RFC3339 timestamps or dates in YYYY-MM-DD format and are interpreted in UTC.
+
+Preview the executions without creating them:
+
+::
+
+ flytectl create backfill -p flytesnacks -d development --launchplan core.scheduled_workflows.lp_schedules.daily_wf --version v1 --from 2026-01-01 --to 2026-02-01 --dryRun
+
+Create up to four executions concurrently and record progress in a resume file. Re-running the same command
+with the same resume file skips the slots that already have an execution. The resume file records the launch plan
+version and the window, and resuming it with others fails:
+
+::
+
+ flytectl create backfill -p flytesnacks -d development --launchplan core.scheduled_workflows.lp_schedules.daily_wf --version v1 --from 2026-01-01 --to 2026-02-01 --parallelism 4 --resumeFile backfill.yaml
+
+Usage
+`
+)
+
+func createBackfillCommand(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	project := config.GetConfig().Project
+	domain := config.GetConfig().Domain
+	cfg := backfill.DefaultConfig
+	if len(cfg.LaunchPlan) == 0 {
+		return fmt.Errorf(clierrors.ErrLPNotPassed)
+	}
+	if cfg.Parallelism < 1 {
+		return fmt.Errorf("parallelism should be at least 1, got %v", cfg.Parallelism)
+	}
+	from, err := parseBackfillTime(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid from time: %w", err)
+	}
+	to, err := parseBackfillTime(cfg.To)
+	if err != nil {
+		return fmt.Errorf("invalid to time: %w", err)
+	}
+	if !from.Before(to) {
+		return fmt.Errorf("from time %v should be before to time %v", cfg.From, cfg.To)
+	}
+
+	var lp *admin.LaunchPlan
+	if len(cfg.Version) > 0 {
+		lp, err = cmdCtx.AdminFetcherExt().FetchLPVersion(ctx, cfg.LaunchPlan, cfg.Version, project, domain)
+	} else {
+		lp, err = cmdCtx.AdminFetcherExt().FetchLPLatestVersion(ctx, cfg.LaunchPlan, project, domain, filters.Filters{})
+	}
+	if err != nil {
+		return err
+	}
+	schedule := lp.GetSpec().GetEntityMetadata().GetSchedule()
+	if schedule == nil {
+		return fmt.Errorf("launch plan %v version %v doesn't have a schedule", lp.Id.Name, lp.Id.Version)
+	}
+	slots, err := scheduleSlots(schedule, from, to)
+	if err != nil {
+		return err
+	}
+
+	progress, err := readBackfillProgress(cfg.ResumeFile, backfillRun{
+		Project:    project,
+		Domain:     domain,
+		LaunchPlan: lp.Id.Name,
+		Version:    lp.Id.Version,
+		From:       from.Format(time.RFC3339),
+		To:         to.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	var pending []time.Time
+	for _, slot := range slots {
+		if _, ok := progress.Executions[slot.Format(time.RFC3339)]; ok {
+			logger.Debugf(ctx, "skipping slot %v as it was already backfilled", slot)
+			continue
+		}
+		pending = append(pending, slot)
+	}
+	fmt.Printf("backfilling %v of %v slots of launch plan %v version %v\n", len(pending), len(slots), lp.Id.Name, lp.Id.Version)
+
+	if cfg.DryRun {
+		for _, slot := range pending {
+			fmt.Printf("%v: skipping CreateExecution request (DryRun)\n", slot.Format(time.RFC3339))
+		}
+		return nil
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, cfg.Parallelism)
+	)
+	for _, slot := range pending {
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(slot time.Time) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			exec, err := createBackfillExecution(ctx, cmdCtx, lp, schedule.GetKickoffTimeInputArg(), slot, project, domain)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to create execution for slot %v due to %w", slot.Format(time.RFC3339), err)
+				}
+				return
+			}
+			fmt.Printf("%v: execution identifier %v\n", slot.Format(time.RFC3339), exec.Id)
+			progress.Executions[slot.Format(time.RFC3339)] = exec.Id.GetName()
+			if err := writeBackfillProgress(cfg.ResumeFile, progress); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}(slot)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func createBackfillExecution(ctx context.Context, cmdCtx cmdCore.CommandContext, lp *admin.LaunchPlan,
+	kickoffTimeInputArg string, slot time.Time, project, domain string) (*admin.ExecutionCreateResponse, error) {
+	inputs := &core.LiteralMap{Literals: map[string]*core.Literal{}}
+	if len(kickoffTimeInputArg) > 0 {
+		kickoffTime, err := coreutils.MakeLiteral(slot)
+		if err != nil {
+			return nil, err
+		}
+		inputs.Literals[kickoffTimeInputArg] = kickoffTime
+	}
+	request := createExecutionRequest(lp.Id, inputs, nil, nil, "")
+	request.Project = project
+	request.Domain = domain
+	request.Spec.Metadata.ScheduledAt = timestamppb.New(slot)
+	return cmdCtx.AdminClient().CreateExecution(ctx, request)
+}
@@ -0,0 +1,184 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/backfill"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func scheduledLaunchPlan(schedule *admin.Schedule) *admin.LaunchPlan {
+	return &admin.LaunchPlan{
+		Id: &core.Identifier{
+			ResourceType: core.ResourceType_LAUNCH_PLAN,
+			Name:         "daily_wf",
+			Version:      "v1",
+		},
+		Spec: &admin.LaunchPlanSpec{
+			EntityMetadata: &admin.LaunchPlanMetadata{
+				Schedule: schedule,
+			},
+		},
+	}
+}
+
+func setBackfillConfig(t *testing.T, cfg backfill.Config) {
+	orig := *backfill.DefaultConfig
+	*backfill.DefaultConfig = cfg
+	t.Cleanup(func() {
+		*backfill.DefaultConfig = orig
+	})
+}
+
+func TestScheduleSlots(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	t.Run("cron schedule", func(t *testing.T) {
+		slots, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{CronSchedule: &admin.CronSchedule{Schedule: "0 6 * * *"}},
+		}, from, to)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{from.Add(6 * time.Hour), from.Add(30 * time.Hour), from.Add(54 * time.Hour)}, slots)
+	})
+	t.Run("cron schedule including from", func(t *testing.T) {
+		slots, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{CronSchedule: &admin.CronSchedule{Schedule: "@daily"}},
+		}, from, to)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{from, from.Add(24 * time.Hour), from.Add(48 * time.Hour)}, slots)
+	})
+	t.Run("deprecated cron expression", func(t *testing.T) {
+		slots, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronExpression{CronExpression: "0 0 2 * *"},
+		}, from, to)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{from.Add(24 * time.Hour)}, slots)
+	})
+	t.Run("fixed rate", func(t *testing.T) {
+		slots, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_Rate{Rate: &admin.FixedRate{Value: 36, Unit: admin.FixedRateUnit_HOUR}},
+		}, from, to)
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{from, from.Add(36 * time.Hour)}, slots)
+	})
+	t.Run("cron offset", func(t *testing.T) {
+		_, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{CronSchedule: &admin.CronSchedule{Schedule: "@daily", Offset: "P1D"}},
+		}, from, to)
+		assert.EqualError(t, err, "cron schedules with an offset are not supported for backfill")
+	})
+	t.Run("invalid cron", func(t *testing.T) {
+		_, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{CronSchedule: &admin.CronSchedule{Schedule: "invalid"}},
+		}, from, to)
+		assert.NotNil(t, err)
+	})
+	t.Run("zero rate", func(t *testing.T) {
+		_, err := scheduleSlots(&admin.Schedule{
+			ScheduleExpression: &admin.Schedule_Rate{Rate: &admin.FixedRate{Value: 0}},
+		}, from, to)
+		assert.EqualError(t, err, "fixed rate value should be greater than 0")
+	})
+}
+
+func TestParseBackfillTime(t *testing.T) {
+	parsed, err := parseBackfillTime("2026-01-02")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), parsed)
+
+	parsed, err = parseBackfillTime("2026-01-02T10:00:00+02:00")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC), parsed)
+
+	_, err = parseBackfillTime("")
+	assert.NotNil(t, err)
+	_, err = parseBackfillTime("yesterday")
+	assert.NotNil(t, err)
+}
+
+func TestCreateBackfillFunc(t *testing.T) {
+	run := backfillRun{Project: "dummyProject", Domain: "dummyDomain", LaunchPlan: "daily_wf", Version: "v1",
+		From: "2026-01-01T00:00:00Z", To: "2026-01-04T00:00:00Z"}
+	resumeFileContent := "project: dummyProject\ndomain: dummyDomain\nlaunchPlan: daily_wf\nversion: v1\n" +
+		"from: \"2026-01-01T00:00:00Z\"\nto: \"2026-01-04T00:00:00Z\"\n"
+	schedule := &admin.Schedule{
+		ScheduleExpression:  &admin.Schedule_CronSchedule{CronSchedule: &admin.CronSchedule{Schedule: "@daily"}},
+		KickoffTimeInputArg: "kickoff_time",
+	}
+	t.Run("missing launch plan", func(t *testing.T) {
+		s := setup()
+		setBackfillConfig(t, backfill.Config{Parallelism: 1})
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.EqualError(t, err, "launch plan name wasn't passed\n")
+	})
+	t.Run("invalid window", func(t *testing.T) {
+		s := setup()
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Parallelism: 1, From: "2026-01-02", To: "2026-01-01"})
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.EqualError(t, err, "from time 2026-01-02 should be before to time 2026-01-01")
+	})
+	t.Run("launch plan without schedule", func(t *testing.T) {
+		s := setup()
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Version: "v1", Parallelism: 1, From: "2026-01-01", To: "2026-01-04"})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "daily_wf", "v1", "dummyProject", "dummyDomain").Return(scheduledLaunchPlan(nil), nil)
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.EqualError(t, err, "launch plan daily_wf version v1 doesn't have a schedule")
+	})
+	t.Run("dry run", func(t *testing.T) {
+		s := setup()
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Version: "v1", Parallelism: 1, From: "2026-01-01", To: "2026-01-04", DryRun: true})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "daily_wf", "v1", "dummyProject", "dummyDomain").Return(scheduledLaunchPlan(schedule), nil)
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "CreateExecution", mock.Anything, mock.Anything)
+	})
+	t.Run("create and resume", func(t *testing.T) {
+		s := setup()
+		resumeFile := filepath.Join(t.TempDir(), "backfill.yaml")
+		assert.Nil(t, os.WriteFile(resumeFile, []byte(resumeFileContent+"executions:\n  \"2026-01-01T00:00:00Z\": fexisting\n"), 0600))
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Parallelism: 2, From: "2026-01-01", To: "2026-01-04", ResumeFile: resumeFile})
+		s.FetcherExt.OnFetchLPLatestVersionMatch(s.Ctx, "daily_wf", "dummyProject", "dummyDomain", mock.Anything).Return(scheduledLaunchPlan(schedule), nil)
+		s.MockAdminClient.OnCreateExecutionMatch(s.Ctx, mock.MatchedBy(func(request *admin.ExecutionCreateRequest) bool {
+			kickoff := request.Inputs.Literals["kickoff_time"].GetScalar().GetPrimitive().GetDatetime()
+			return request.Project == "dummyProject" && request.Domain == "dummyDomain" &&
+				kickoff.AsTime().Equal(request.Spec.Metadata.ScheduledAt.AsTime())
+		})).Return(&admin.ExecutionCreateResponse{Id: &core.WorkflowExecutionIdentifier{Name: "fnew"}}, nil).Twice()
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertExpectations(t)
+		progress, err := readBackfillProgress(resumeFile, run)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{
+			"2026-01-01T00:00:00Z": "fexisting",
+			"2026-01-02T00:00:00Z": "fnew",
+			"2026-01-03T00:00:00Z": "fnew",
+		}, progress.Executions)
+	})
+	t.Run("resume another run", func(t *testing.T) {
+		s := setup()
+		resumeFile := filepath.Join(t.TempDir(), "backfill.yaml")
+		assert.Nil(t, os.WriteFile(resumeFile, []byte(resumeFileContent+"executions:\n  \"2026-01-01T00:00:00Z\": fexisting\n"), 0600))
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Version: "v1", Parallelism: 1, From: "2026-01-02", To: "2026-01-04", ResumeFile: resumeFile})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "daily_wf", "v1", "dummyProject", "dummyDomain").Return(scheduledLaunchPlan(schedule), nil)
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.EqualError(t, err, fmt.Sprintf("resume file %v is of launch plan dummyProject/dummyDomain/daily_wf version v1 from "+
+			"2026-01-01T00:00:00Z to 2026-01-04T00:00:00Z, not of launch plan dummyProject/dummyDomain/daily_wf version v1 from "+
+			"2026-01-02T00:00:00Z to 2026-01-04T00:00:00Z", resumeFile))
+		s.MockAdminClient.AssertNotCalled(t, "CreateExecution", mock.Anything, mock.Anything)
+	})
+	t.Run("create execution error", func(t *testing.T) {
+		s := setup()
+		setBackfillConfig(t, backfill.Config{LaunchPlan: "daily_wf", Version: "v1", Parallelism: 1, From: "2026-01-01", To: "2026-01-04"})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "daily_wf", "v1", "dummyProject", "dummyDomain").Return(scheduledLaunchPlan(schedule), nil)
+		s.MockAdminClient.OnCreateExecutionMatch(s.Ctx, mock.Anything).Return(nil, fmt.Errorf("failed")).Once()
+		err := createBackfillCommand(s.Ctx, nil, s.CmdCtx)
+		assert.EqualError(t, err, "failed to create execution for slot 2026-01-01T00:00:00Z due to failed")
+	})
+}
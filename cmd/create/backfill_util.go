@@ -0,0 +1,130 @@
+package create
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/robfig/cron/v3"
+	"sigs.k8s.io/yaml"
+)
+
+const backfillDateFormat = "2006-01-02"
+
+// backfillRun identifies the launch plan version and the window of a backfill.
+type backfillRun struct {
+	Project    string `json:"project"`
+	Domain     string `json:"domain"`
+	LaunchPlan string `json:"launchPlan"`
+	Version    string `json:"version"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+func (r backfillRun) String() string {
+	return fmt.Sprintf("launch plan %v/%v/%v version %v from %v to %v", r.Project, r.Domain, r.LaunchPlan, r.Version, r.From, r.To)
+}
+
+// backfillProgress is persisted in the resume file and maps every backfilled slot of the run to its execution name.
+type backfillProgress struct {
+	backfillRun
+	Executions map[string]string `json:"executions"`
+}
+
+func parseBackfillTime(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, fmt.Errorf("time wasn't passed")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.ParseInLocation(backfillDateFormat, value, time.UTC)
+}
+
+// scheduleSlots returns the fire times of the schedule in the window [from, to).
+func scheduleSlots(schedule *admin.Schedule, from, to time.Time) ([]time.Time, error) {
+	var slots []time.Time
+	switch {
+	case schedule.GetRate() != nil:
+		interval, err := fixedRateInterval(schedule.GetRate())
+		if err != nil {
+			return nil, err
+		}
+		for t := from; t.Before(to); t = t.Add(interval) {
+			slots = append(slots, t)
+		}
+	case schedule.GetCronSchedule() != nil || len(schedule.GetCronExpression()) > 0:
+		expression := schedule.GetCronExpression()
+		if schedule.GetCronSchedule() != nil {
+			if len(schedule.GetCronSchedule().GetOffset()) > 0 {
+				return nil, fmt.Errorf("cron schedules with an offset are not supported for backfill")
+			}
+			expression = schedule.GetCronSchedule().GetSchedule()
+		}
+		cronSchedule, err := cron.ParseStandard(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %v: %w", expression, err)
+		}
+		for t := cronSchedule.Next(from.Add(-time.Second)); t.Before(to); t = cronSchedule.Next(t) {
+			slots = append(slots, t)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported schedule %v", schedule)
+	}
+	return slots, nil
+}
+
+func fixedRateInterval(rate *admin.FixedRate) (time.Duration, error) {
+	if rate.GetValue() == 0 {
+		return 0, fmt.Errorf("fixed rate value should be greater than 0")
+	}
+	value := time.Duration(rate.GetValue())
+	switch rate.GetUnit() {
+	case admin.FixedRateUnit_MINUTE:
+		return value * time.Minute, nil
+	case admin.FixedRateUnit_HOUR:
+		return value * time.Hour, nil
+	case admin.FixedRateUnit_DAY:
+		return value * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("unsupported fixed rate unit %v", rate.GetUnit())
+}
+
+// readBackfillProgress reads the progress of the run from the resume file. Resuming a different run fails, as its
+// slots would be mistaken for the ones of the run.
+func readBackfillProgress(fileName string, run backfillRun) (*backfillProgress, error) {
+	progress := &backfillProgress{backfillRun: run, Executions: map[string]string{}}
+	if len(fileName) == 0 {
+		return progress, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read from %v resume file", fileName)
+	}
+	if err = yaml.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+	if progress.backfillRun != run {
+		return nil, fmt.Errorf("resume file %v is of %v, not of %v", fileName, progress.backfillRun, run)
+	}
+	if progress.Executions == nil {
+		progress.Executions = map[string]string{}
+	}
+	return progress, nil
+}
+
+func writeBackfillProgress(fileName string, progress *backfillProgress) error {
+	if len(fileName) == 0 {
+		return nil
+	}
+	data, err := yaml.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0600)
+}
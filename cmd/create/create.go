@@ -1,6 +1,7 @@
 package create
 
 import (
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/backfill"
 	"github.com/flyteorg/flytectl/cmd/config/subcommand/project"
 	cmdcore "github.com/flyteorg/flytectl/cmd/core"
 
@@ -30,6 +31,8 @@ func RemoteCreateCommand() *cobra.Command {
 			Long: projectLong},
 		"execution": {CmdFunc: createExecutionCommand, Aliases: []string{"executions"}, ProjectDomainNotRequired: false, PFlagProvider: executionConfig, Short: executionShort,
 			Long: executionLong},
+		"backfill": {CmdFunc: createBackfillCommand, ProjectDomainNotRequired: false, PFlagProvider: backfill.DefaultConfig, Short: backfillShort,
+			Long: backfillLong},
 	}
 	cmdcore.AddCommands(createCmd, createResourcesFuncs)
 	return createCmd
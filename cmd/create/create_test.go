@@ -17,16 +17,18 @@ func TestCreateCommand(t *testing.T) {
 	createCommand := RemoteCreateCommand()
 	assert.Equal(t, createCommand.Use, "create")
 	assert.Equal(t, createCommand.Short, "Creates various Flyte resources such as tasks, workflows, launch plans, executions, and projects.")
-	assert.Equal(t, len(createCommand.Commands()), 2)
+	assert.Equal(t, len(createCommand.Commands()), 3)
 	cmdNouns := createCommand.Commands()
 	// Sort by Use value.
 	sort.Slice(cmdNouns, func(i, j int) bool {
 		return cmdNouns[i].Use < cmdNouns[j].Use
 	})
-	assert.Equal(t, cmdNouns[0].Use, "execution")
-	assert.Equal(t, cmdNouns[0].Aliases, []string{"executions"})
-	assert.Equal(t, cmdNouns[0].Short, executionShort)
-	assert.Equal(t, cmdNouns[1].Use, "project")
-	assert.Equal(t, cmdNouns[1].Aliases, []string{"projects"})
-	assert.Equal(t, cmdNouns[1].Short, "Creates project resources.")
+	assert.Equal(t, cmdNouns[0].Use, "backfill")
+	assert.Equal(t, cmdNouns[0].Short, backfillShort)
+	assert.Equal(t, cmdNouns[1].Use, "execution")
+	assert.Equal(t, cmdNouns[1].Aliases, []string{"executions"})
+	assert.Equal(t, cmdNouns[1].Short, executionShort)
+	assert.Equal(t, cmdNouns[2].Use, "project")
+	assert.Equal(t, cmdNouns[2].Aliases, []string{"projects"})
+	assert.Equal(t, cmdNouns[2].Short, "Creates project resources.")
 }
@@ -49,13 +49,13 @@ func CreateDemoCommand() *cobra.Command {
 			Long:  startLong, PFlagProvider: sandboxCmdConfig.DefaultConfig, DisableFlyteClient: true},
 		"teardown": {CmdFunc: teardownDemoCluster, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: teardownShort,
-			Long:  teardownLong, DisableFlyteClient: true},
+			Long:  teardownLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
 		"status": {CmdFunc: demoClusterStatus, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: statusShort,
-			Long:  statusLong},
+			Long:  statusLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig},
 		"exec": {CmdFunc: demoClusterExec, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: execShort,
-			Long:  execLong, DisableFlyteClient: true},
+			Long:  execLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
 	}
 
 	cmdcore.AddCommands(demo, demoResourcesFuncs)
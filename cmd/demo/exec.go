@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
 	"github.com/flyteorg/flytectl/pkg/docker"
 )
@@ -22,18 +23,18 @@ Usage`
 )
 
 func demoClusterExec(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
 	if err != nil {
 		return err
 	}
 	if len(args) > 0 {
-		return execute(ctx, cli, args)
+		return execute(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name, args)
 	}
 	return fmt.Errorf("missing argument. Please check usage examples by running flytectl demo exec --help")
 }
 
-func execute(ctx context.Context, cli docker.Docker, args []string) error {
-	c, err := docker.GetSandbox(ctx, cli)
+func execute(ctx context.Context, cli docker.Docker, name string, args []string) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
 	if err != nil {
 		return err
 	}
@@ -69,6 +69,52 @@ eg : for passing multiple environment variables
 
  flytectl demo start --env USER=foo --env PASSWORD=bar
 
+Wait at most 10 minutes for the Flyte deployment to be ready. The readiness of every pod is reported along with the
+reason it is waiting for, and the command fails early if a pod is crash looping. When the output isn't a terminal, e.g.
+in CI logs, a line is printed for every change instead of redrawing a table:
+::
+
+ flytectl demo start --timeout 10m
+
+Start the sandbox from a configuration file, which can be shared to reproduce the same local setup. Flags take
+precedence over the image, version and env of the file. Remapped host ports avoid conflicts with local services, and
+relative volume sources are resolved from the directory of the file:
+::
+
+ flytectl demo start --config sandbox.yaml
+
+An example of a configuration file:
+::
+
+ resources:
+   cpus: 4
+   memory: 8Gi
+ hostPorts:
+   30081: 31081
+ ports:
+   - 0.0.0.0:30100:30100
+ volumes:
+   - source: ./data
+     target: /data
+     readOnly: true
+ registryMirrors:
+   docker.io:
+     - https://mirror.gcr.io
+ helmValues:
+   flyteadmin:
+     replicaCount: 2
+
+Start the sandbox with podman instead of docker. The runtime is detected from the available sockets if not set, the
+podman socket being found from CONTAINER_HOST or the rootless podman service of the user:
+::
+
+ systemctl --user start podman.socket
+ flytectl demo start --runtime podman
+
+Rootless podman can't publish the host ports below 1024 and requires cgroup v2 to run the privileged sandbox container.
+Bind mounts are relabeled when SELinux is enabled. Pass the same runtime to the other demo commands, e.g.
+flytectl demo status --runtime podman.
+
 
 Usage
 `
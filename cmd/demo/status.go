@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/enescakir/emoji"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
 	"github.com/flyteorg/flytectl/pkg/docker"
 )
@@ -23,16 +24,16 @@ Usage
 )
 
 func demoClusterStatus(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
 	if err != nil {
 		return err
 	}
 
-	return printStatus(ctx, cli)
+	return printStatus(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name)
 }
 
-func printStatus(ctx context.Context, cli docker.Docker) error {
-	c, err := docker.GetSandbox(ctx, cli)
+func printStatus(ctx context.Context, cli docker.Docker, name string) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/flyteorg/flytectl/pkg/docker"
 
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
 )
 
@@ -24,9 +25,9 @@ Usage
 )
 
 func teardownDemoCluster(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
 	if err != nil {
 		return err
 	}
-	return sandbox.Teardown(ctx, cli)
+	return sandbox.Teardown(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name)
 }
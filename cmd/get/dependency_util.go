@@ -0,0 +1,246 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/ext"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flytectl/pkg/workflowutil"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/disiqueira/gotree"
+	"github.com/golang/protobuf/proto"
+)
+
+var usedByColumns = []printer.Column{
+	{Header: "Type", JSONPath: "$.Type"},
+	{Header: "Name", JSONPath: "$.Name"},
+	{Header: "Version", JSONPath: "$.Version"},
+	{Header: "Task Version", JSONPath: "$.TaskVersion"},
+}
+
+// Dependent is a workflow or launch plan version which uses a task
+type Dependent struct {
+	Type        string
+	Name        string
+	Version     string
+	TaskVersion string
+}
+
+// fetchTaskUsedBy returns the workflows and launch plans of the project and domain which pin a version of the task,
+// either directly or through a subworkflow or launch plan node. Every version of the workflows and launch plans is
+// considered.
+func fetchTaskUsedBy(ctx context.Context, cmdCtx cmdCore.CommandContext, name, version, project, domain string) ([]Dependent, error) {
+	workflows, err := fetchAllWorkflowVersions(ctx, cmdCtx, project, domain)
+	if err != nil {
+		return nil, err
+	}
+	launchPlans, err := fetchAllLaunchPlans(ctx, cmdCtx, project, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// taskVersions maps the workflows and launch plans using the task to the task version they pin.
+	taskVersions := map[string]string{}
+	pinnedVersion := func(id *core.Identifier) (string, bool) {
+		if id.ResourceType == core.ResourceType_TASK && id.Project == project && id.Domain == domain && id.Name == name &&
+			(len(version) == 0 || id.Version == version) {
+			return id.Version, true
+		}
+		taskVersion, ok := taskVersions[identifierKey(id.ResourceType, id)]
+		return taskVersion, ok
+	}
+	var dependents []Dependent
+	// Workflows can use the task through launch plans which themselves are only found to use it once their workflow is,
+	// hence the lookup is repeated until no new dependent is found.
+	for found := true; found; {
+		found = false
+		for _, wf := range workflows {
+			if _, ok := taskVersions[identifierKey(core.ResourceType_WORKFLOW, wf.Id)]; ok {
+				continue
+			}
+			for _, ref := range workflowReferences(wf) {
+				if taskVersion, ok := pinnedVersion(ref); ok {
+					taskVersions[identifierKey(core.ResourceType_WORKFLOW, wf.Id)] = taskVersion
+					dependents = append(dependents, Dependent{Type: "workflow", Name: wf.Id.Name, Version: wf.Id.Version, TaskVersion: taskVersion})
+					found = true
+					break
+				}
+			}
+		}
+		for _, lp := range launchPlans {
+			if _, ok := taskVersions[identifierKey(core.ResourceType_LAUNCH_PLAN, lp.Id)]; ok || lp.Spec.GetWorkflowId() == nil {
+				continue
+			}
+			if taskVersion, ok := taskVersions[identifierKey(core.ResourceType_WORKFLOW, lp.Spec.WorkflowId)]; ok {
+				taskVersions[identifierKey(core.ResourceType_LAUNCH_PLAN, lp.Id)] = taskVersion
+				dependents = append(dependents, Dependent{Type: "launchplan", Name: lp.Id.Name, Version: lp.Id.Version, TaskVersion: taskVersion})
+				found = true
+			}
+		}
+	}
+	return dependents, nil
+}
+
+// fetchAllWorkflowVersions returns every version of the workflows of the project and domain, following the page tokens.
+// The versions are fetched one by one, since listings don't have their compiled closure.
+func fetchAllWorkflowVersions(ctx context.Context, cmdCtx cmdCore.CommandContext, project, domain string) ([]*admin.Workflow, error) {
+	entitiesRequest, err := filters.BuildNamedEntityListRequest(filters.DefaultFilter, project, domain, core.ResourceType_WORKFLOW)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []*admin.Workflow
+	for {
+		entities, err := cmdCtx.AdminClient().ListNamedEntities(ctx, entitiesRequest)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities.Entities {
+			versionsRequest, err := filters.BuildResourceListRequestWithName(filters.DefaultFilter, project, domain, entity.Id.Name)
+			if err != nil {
+				return nil, err
+			}
+			for {
+				versions, err := cmdCtx.AdminClient().ListWorkflows(ctx, versionsRequest)
+				if err != nil {
+					return nil, err
+				}
+				for _, v := range versions.Workflows {
+					wf, err := cmdCtx.AdminFetcherExt().FetchWorkflowVersion(ctx, v.Id.Name, v.Id.Version, project, domain)
+					if err != nil {
+						return nil, err
+					}
+					workflows = append(workflows, wf)
+				}
+				if len(versions.Token) == 0 {
+					break
+				}
+				versionsRequest.Token = versions.Token
+			}
+		}
+		if len(entities.Token) == 0 {
+			return workflows, nil
+		}
+		entitiesRequest.Token = entities.Token
+	}
+}
+
+// fetchAllLaunchPlans returns every version of the launch plans of the project and domain, following the page tokens.
+func fetchAllLaunchPlans(ctx context.Context, cmdCtx cmdCore.CommandContext, project, domain string) ([]*admin.LaunchPlan, error) {
+	request, err := filters.BuildResourceListRequestWithName(filters.DefaultFilter, project, domain, "")
+	if err != nil {
+		return nil, err
+	}
+	var launchPlans []*admin.LaunchPlan
+	for {
+		list, err := cmdCtx.AdminClient().ListLaunchPlans(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		launchPlans = append(launchPlans, list.LaunchPlans...)
+		if len(list.Token) == 0 {
+			return launchPlans, nil
+		}
+		request.Token = list.Token
+	}
+}
+
+// workflowReferences returns the identifiers of the tasks, subworkflows and launch plans referenced by the compiled
+// workflow, including the ones referenced by its subworkflows.
+func workflowReferences(wf *admin.Workflow) []*core.Identifier {
+	compiled := wf.GetClosure().GetCompiledWorkflow()
+	var refs []*core.Identifier
+	visit := func(id *core.Identifier) {
+		refs = append(refs, id)
+	}
+	for _, task := range compiled.GetTasks() {
+		if id := task.GetTemplate().GetId(); id != nil {
+			refs = append(refs, id)
+		}
+	}
+	workflowutil.VisitReferences(compiled.GetPrimary().GetTemplate(), visit)
+	for _, subWorkflow := range compiled.GetSubWorkflows() {
+		workflowutil.VisitReferences(subWorkflow.GetTemplate(), visit)
+	}
+	return refs
+}
+
+// createDependencyTreeView returns the tree of the tasks, subworkflows and launch plans used by the workflow.
+// Launch plans are fetched from admin to expand the workflow they launch.
+func createDependencyTreeView(ctx context.Context, fetcher ext.AdminFetcherExtInterface, wf *admin.Workflow) (gotree.Tree, error) {
+	rootView := gotree.New(identifierLabel(core.ResourceType_WORKFLOW, wf.Id))
+	visited := map[string]bool{identifierKey(core.ResourceType_WORKFLOW, wf.Id): true}
+	err := addDependencies(ctx, fetcher, rootView, wf.GetClosure().GetCompiledWorkflow().GetPrimary().GetTemplate(),
+		wf.GetClosure().GetCompiledWorkflow(), visited)
+	return rootView, err
+}
+
+func addDependencies(ctx context.Context, fetcher ext.AdminFetcherExtInterface, view gotree.Tree, template *core.WorkflowTemplate,
+	compiled *core.CompiledWorkflowClosure, visited map[string]bool) error {
+	var refs []*core.Identifier
+	seen := map[string]bool{}
+	workflowutil.VisitReferences(template, func(id *core.Identifier) {
+		if !seen[identifierKey(id.ResourceType, id)] {
+			seen[identifierKey(id.ResourceType, id)] = true
+			refs = append(refs, id)
+		}
+	})
+	for _, ref := range refs {
+		refView := view.Add(identifierLabel(ref.ResourceType, ref))
+		switch ref.ResourceType {
+		case core.ResourceType_WORKFLOW:
+			for _, subWorkflow := range compiled.GetSubWorkflows() {
+				if proto.Equal(subWorkflow.GetTemplate().GetId(), ref) {
+					if err := addDependencies(ctx, fetcher, refView, subWorkflow.Template, compiled, visited); err != nil {
+						return err
+					}
+				}
+			}
+		case core.ResourceType_LAUNCH_PLAN:
+			lp, err := fetcher.FetchLPVersion(ctx, ref.Name, ref.Version, ref.Project, ref.Domain)
+			if err != nil {
+				return err
+			}
+			workflowID := lp.GetSpec().GetWorkflowId()
+			if workflowID == nil || visited[identifierKey(core.ResourceType_WORKFLOW, workflowID)] {
+				continue
+			}
+			visited[identifierKey(core.ResourceType_WORKFLOW, workflowID)] = true
+			wf, err := fetcher.FetchWorkflowVersion(ctx, workflowID.Name, workflowID.Version, workflowID.Project, workflowID.Domain)
+			if err != nil {
+				return err
+			}
+			wfView := refView.Add(identifierLabel(core.ResourceType_WORKFLOW, workflowID))
+			err = addDependencies(ctx, fetcher, wfView, wf.GetClosure().GetCompiledWorkflow().GetPrimary().GetTemplate(),
+				wf.GetClosure().GetCompiledWorkflow(), visited)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// identifierKey takes the resource type separately since admin doesn't always set it in the identifiers it returns.
+func identifierKey(resourceType core.ResourceType, id *core.Identifier) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%v", resourceType, id.Project, id.Domain, id.Name, id.Version)
+}
+
+func identifierLabel(resourceType core.ResourceType, id *core.Identifier) string {
+	var kind string
+	switch resourceType {
+	case core.ResourceType_TASK:
+		kind = "task"
+	case core.ResourceType_WORKFLOW:
+		kind = "workflow"
+	case core.ResourceType_LAUNCH_PLAN:
+		kind = "launchplan"
+	default:
+		kind = resourceType.String()
+	}
+	return fmt.Sprintf("%v %v:%v", kind, id.Name, id.Version)
+}
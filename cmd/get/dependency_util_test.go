@@ -0,0 +1,158 @@
+package get
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func dependencyID(resourceType core.ResourceType, name string) *core.Identifier {
+	return &core.Identifier{ResourceType: resourceType, Project: projectValue, Domain: domainValue, Name: name, Version: "v1"}
+}
+
+func dependencyWorkflow(name string, nodes ...*core.Node) *admin.Workflow {
+	var tasks []*core.CompiledTask
+	for _, node := range nodes {
+		if id := node.GetTaskNode().GetReferenceId(); id != nil {
+			tasks = append(tasks, &core.CompiledTask{Template: &core.TaskTemplate{Id: id}})
+		}
+	}
+	return &admin.Workflow{
+		Id: &core.Identifier{Project: projectValue, Domain: domainValue, Name: name, Version: "v1"},
+		Closure: &admin.WorkflowClosure{CompiledWorkflow: &core.CompiledWorkflowClosure{
+			Primary: &core.CompiledWorkflow{Template: &core.WorkflowTemplate{Nodes: nodes}},
+			Tasks:   tasks,
+		}},
+	}
+}
+
+func dependencyTaskNode(name string) *core.Node {
+	return &core.Node{Target: &core.Node_TaskNode{TaskNode: &core.TaskNode{
+		Reference: &core.TaskNode_ReferenceId{ReferenceId: dependencyID(core.ResourceType_TASK, name)},
+	}}}
+}
+
+func dependencyLPNode(name string) *core.Node {
+	return &core.Node{Target: &core.Node_WorkflowNode{WorkflowNode: &core.WorkflowNode{
+		Reference: &core.WorkflowNode_LaunchplanRef{LaunchplanRef: dependencyID(core.ResourceType_LAUNCH_PLAN, name)},
+	}}}
+}
+
+func dependencyLP(name, workflow string) *admin.LaunchPlan {
+	return &admin.LaunchPlan{
+		Id:   &core.Identifier{Project: projectValue, Domain: domainValue, Name: name, Version: "v1"},
+		Spec: &admin.LaunchPlanSpec{WorkflowId: dependencyID(core.ResourceType_WORKFLOW, workflow)},
+	}
+}
+
+func TestFetchTaskUsedBy(t *testing.T) {
+	wfA2 := dependencyWorkflow("wf_a", dependencyTaskNode("t2"))
+	wfA2.Id.Version = "v2"
+	workflows := map[string][]*admin.Workflow{
+		"wf_a": {dependencyWorkflow("wf_a", dependencyTaskNode("t1")), wfA2},
+		"wf_b": {dependencyWorkflow("wf_b", dependencyLPNode("lp_a"))},
+		"wf_c": {dependencyWorkflow("wf_c", dependencyTaskNode("t2"))},
+	}
+	// Every listing is split in pages of one element.
+	mockWorkflows := func(s *testutils.TestStruct) {
+		s.MockAdminClient.OnListNamedEntitiesMatch(s.Ctx, mock.MatchedBy(func(request *admin.NamedEntityListRequest) bool {
+			return request.Token == ""
+		})).Return(&admin.NamedEntityList{Entities: []*admin.NamedEntity{
+			{Id: &admin.NamedEntityIdentifier{Name: "wf_b"}},
+			{Id: &admin.NamedEntityIdentifier{Name: "wf_a"}},
+		}, Token: "2"}, nil)
+		s.MockAdminClient.OnListNamedEntitiesMatch(s.Ctx, mock.MatchedBy(func(request *admin.NamedEntityListRequest) bool {
+			return request.Token == "2"
+		})).Return(&admin.NamedEntityList{Entities: []*admin.NamedEntity{{Id: &admin.NamedEntityIdentifier{Name: "wf_c"}}}}, nil)
+		for name, versions := range workflows {
+			for i, wf := range versions {
+				token, next := "", ""
+				if i > 0 {
+					token = fmt.Sprint(i)
+				}
+				if i < len(versions)-1 {
+					next = fmt.Sprint(i + 1)
+				}
+				name, token := name, token
+				s.MockAdminClient.OnListWorkflowsMatch(s.Ctx, mock.MatchedBy(func(request *admin.ResourceListRequest) bool {
+					return request.Id.Name == name && request.Token == token
+				})).Return(&admin.WorkflowList{Workflows: []*admin.Workflow{{Id: wf.Id}}, Token: next}, nil)
+				s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, name, wf.Id.Version, projectValue, domainValue).Return(wf, nil)
+			}
+		}
+	}
+	t.Run("used by", func(t *testing.T) {
+		s := setup()
+		mockWorkflows(&s)
+		s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, mock.MatchedBy(func(request *admin.ResourceListRequest) bool {
+			return request.Token == ""
+		})).Return(&admin.LaunchPlanList{LaunchPlans: []*admin.LaunchPlan{dependencyLP("lp_c", "wf_c")}, Token: "1"}, nil)
+		s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, mock.MatchedBy(func(request *admin.ResourceListRequest) bool {
+			return request.Token == "1"
+		})).Return(&admin.LaunchPlanList{LaunchPlans: []*admin.LaunchPlan{dependencyLP("lp_a", "wf_a")}}, nil)
+		dependents, err := fetchTaskUsedBy(s.Ctx, s.CmdCtx, "t1", "", projectValue, domainValue)
+		assert.Nil(t, err)
+		assert.Equal(t, []Dependent{
+			{Type: "workflow", Name: "wf_a", Version: "v1", TaskVersion: "v1"},
+			{Type: "launchplan", Name: "lp_a", Version: "v1", TaskVersion: "v1"},
+			{Type: "workflow", Name: "wf_b", Version: "v1", TaskVersion: "v1"},
+		}, dependents)
+
+		dependents, err = fetchTaskUsedBy(s.Ctx, s.CmdCtx, "t2", "", projectValue, domainValue)
+		assert.Nil(t, err)
+		assert.Equal(t, []Dependent{
+			{Type: "workflow", Name: "wf_a", Version: "v2", TaskVersion: "v1"},
+			{Type: "workflow", Name: "wf_c", Version: "v1", TaskVersion: "v1"},
+			{Type: "launchplan", Name: "lp_c", Version: "v1", TaskVersion: "v1"},
+		}, dependents)
+
+		dependents, err = fetchTaskUsedBy(s.Ctx, s.CmdCtx, "t1", "v2", projectValue, domainValue)
+		assert.Nil(t, err)
+		assert.Empty(t, dependents)
+	})
+	t.Run("list launch plans error", func(t *testing.T) {
+		s := setup()
+		mockWorkflows(&s)
+		s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, mock.Anything).Return(nil, fmt.Errorf("failed"))
+		_, err := fetchTaskUsedBy(s.Ctx, s.CmdCtx, "t1", "", projectValue, domainValue)
+		assert.EqualError(t, err, "failed")
+	})
+}
+
+func TestCreateDependencyTreeView(t *testing.T) {
+	subWorkflowID := dependencyID(core.ResourceType_WORKFLOW, "sub_wf")
+	parent := dependencyWorkflow("parent_wf", dependencyTaskNode("t1"), dependencyLPNode("lp_a"), dependencyTaskNode("t1"),
+		&core.Node{Target: &core.Node_WorkflowNode{WorkflowNode: &core.WorkflowNode{
+			Reference: &core.WorkflowNode_SubWorkflowRef{SubWorkflowRef: subWorkflowID},
+		}}})
+	parent.Closure.CompiledWorkflow.SubWorkflows = []*core.CompiledWorkflow{
+		{Template: &core.WorkflowTemplate{Id: subWorkflowID, Nodes: []*core.Node{dependencyTaskNode("t2")}}},
+	}
+	t.Run("tree", func(t *testing.T) {
+		s := setup()
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "lp_a", "v1", projectValue, domainValue).Return(dependencyLP("lp_a", "wf_a"), nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "wf_a", "v1", projectValue, domainValue).Return(
+			dependencyWorkflow("wf_a", dependencyTaskNode("t3")), nil)
+		tree, err := createDependencyTreeView(s.Ctx, s.FetcherExt, parent)
+		assert.Nil(t, err)
+		assert.Equal(t, `workflow parent_wf:v1
+└── task t1:v1
+└── launchplan lp_a:v1
+│   ├── workflow wf_a:v1
+│       └── task t3:v1
+└── workflow sub_wf:v1
+    └── task t2:v1
+`, tree.Print())
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		s := setup()
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "lp_a", "v1", projectValue, domainValue).Return(nil, fmt.Errorf("failed"))
+		_, err := createDependencyTreeView(s.Ctx, s.FetcherExt, parent)
+		assert.EqualError(t, err, "failed")
+	})
+}
@@ -117,6 +117,13 @@ The generated file would look similar to this:
 	 workflow: core.control_flow.merge_sort.merge_sort
 
 Check the :ref:` + "`create execution section<flytectl_create_execution>`" + ` on how to launch one using the generated file.
+
+Retrieve the launch plans of a registration package without a cluster, e.g. to review what it contains:
+
+::
+
+ flytectl get launchplan -p flytesnacks -d development --fromPackage flyte-package.tgz
+
 Usage
 `
 )
@@ -170,10 +177,13 @@ func getLaunchPlanFunc(ctx context.Context, args []string, cmdCtx cmdCore.Comman
 	var launchPlans []*admin.LaunchPlan
 	project := config.GetConfig().Project
 	domain := config.GetConfig().Domain
+	fetcher, err := adminFetcher(ctx, cmdCtx, launchplan.DefaultConfig.FromPackage)
+	if err != nil {
+		return err
+	}
 	if len(args) == 1 {
 		name := args[0]
-		var err error
-		if launchPlans, err = FetchLPForName(ctx, cmdCtx.AdminFetcherExt(), name, project, domain); err != nil {
+		if launchPlans, err = FetchLPForName(ctx, fetcher, name, project, domain); err != nil {
 			return err
 		}
 		logger.Debugf(ctx, "Retrieved %v launch plans", len(launchPlans))
@@ -197,7 +207,7 @@ func getLaunchPlanFunc(ctx context.Context, args []string, cmdCtx cmdCore.Comman
 		launchplan.DefaultConfig.Filter.FieldSelector = fmt.Sprintf("workflow.name=%s", launchplan.DefaultConfig.Workflow)
 	}
 
-	launchPlans, err := cmdCtx.AdminFetcherExt().FetchAllVerOfLP(ctx, "", config.GetConfig().Project, config.GetConfig().Domain, launchplan.DefaultConfig.Filter)
+	launchPlans, err = fetcher.FetchAllVerOfLP(ctx, "", config.GetConfig().Project, config.GetConfig().Domain, launchplan.DefaultConfig.Filter)
 	if err != nil {
 		return err
 	}
@@ -126,6 +126,12 @@ func getExecutionDetails(ctx context.Context, project, domain, execName, nodeNam
 	return nExecDetailsForView, nil
 }
 
+// FetchExecutionDetails fetches all the node executions of an execution along with their child nodes, task executions,
+// inputs and outputs.
+func FetchExecutionDetails(ctx context.Context, project, domain, execName string, cmdCtx cmdCore.CommandContext) ([]*NodeExecutionClosure, error) {
+	return getExecutionDetails(ctx, project, domain, execName, "", cmdCtx)
+}
+
 func getNodeExecDetailsInt(ctx context.Context, project, domain, execName, nodeName, uniqueParentID string,
 	nodeExecDetailsMap map[string]*NodeExecutionClosure, cmdCtx cmdCore.CommandContext) ([]*NodeExecutionClosure, error) {
 
@@ -0,0 +1,330 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/cmd/register"
+	"github.com/flyteorg/flytectl/pkg/ext"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytepropeller/pkg/compiler"
+	"github.com/flyteorg/flytepropeller/pkg/compiler/common"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// packageFetcher fetches the tasks, workflows and launch plans of a registration package instead of admin, for get to
+// inspect a package without a cluster. The entities are hydrated with the project and domain the way register does, and
+// their closures are compiled locally. Only the entities of a package can be fetched.
+type packageFetcher struct {
+	path        string
+	tasks       []*admin.Task
+	workflows   []*admin.Workflow
+	launchPlans []*admin.LaunchPlan
+}
+
+// adminFetcher returns the fetcher of the package if one is given, else the one of admin
+func adminFetcher(ctx context.Context, cmdCtx cmdCore.CommandContext, packagePath string) (ext.AdminFetcherExtInterface, error) {
+	if len(packagePath) == 0 {
+		return cmdCtx.AdminFetcherExt(), nil
+	}
+	return newPackageFetcher(ctx, packagePath)
+}
+
+func newPackageFetcher(ctx context.Context, packagePath string) (*packageFetcher, error) {
+	fileList, tmpDir, err := register.GetSerializeOutputFiles(ctx, []string{packagePath}, true)
+	defer os.RemoveAll(tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract package %v. Error: %w", packagePath, err)
+	}
+	p := &packageFetcher{path: packagePath}
+	var taskSpecs []*admin.TaskSpec
+	var workflowSpecs []*admin.WorkflowSpec
+	for _, file := range fileList {
+		if !strings.HasSuffix(file, ".pb") {
+			continue
+		}
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		message, err := register.UnMarshalContents(ctx, raw, file)
+		if err != nil {
+			return nil, err
+		}
+		if err := register.HydrateIdentifiers(message, ""); err != nil {
+			return nil, err
+		}
+		switch v := message.(type) {
+		case *admin.TaskSpec:
+			taskSpecs = append(taskSpecs, v)
+		case *admin.WorkflowSpec:
+			workflowSpecs = append(workflowSpecs, v)
+		case *admin.LaunchPlan:
+			p.launchPlans = append(p.launchPlans, v)
+		}
+	}
+
+	compiledTasks := make([]*core.CompiledTask, 0, len(taskSpecs))
+	for _, spec := range taskSpecs {
+		compiledTask, err := compiler.CompileTask(spec.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile task %v. Error: %w", spec.Template.Id.Name, err)
+		}
+		compiledTasks = append(compiledTasks, compiledTask)
+		p.tasks = append(p.tasks, &admin.Task{
+			Id:      compiledTask.Template.Id,
+			Closure: &admin.TaskClosure{CompiledTask: compiledTask},
+		})
+	}
+	var launchPlanInterfaces []common.InterfaceProvider
+	for _, lp := range p.launchPlans {
+		if lp.Closure.GetExpectedInputs() != nil && lp.Closure.GetExpectedOutputs() != nil {
+			launchPlanInterfaces = append(launchPlanInterfaces, compiler.NewLaunchPlanInterfaceProvider(*lp))
+		}
+	}
+	for _, spec := range workflowSpecs {
+		compiledWorkflow, err := compiler.CompileWorkflow(spec.Template, spec.SubWorkflows, compiledTasks, launchPlanInterfaces)
+		if err != nil {
+			// The workflow is still listed with its template, the closure being only used to print it.
+			logger.Warnf(ctx, "Failed to compile workflow %v: %v", spec.Template.Id.Name, err)
+			compiledWorkflow = &core.CompiledWorkflowClosure{Primary: &core.CompiledWorkflow{Template: spec.Template}}
+		}
+		p.workflows = append(p.workflows, &admin.Workflow{
+			Id:      spec.Template.Id,
+			Closure: &admin.WorkflowClosure{CompiledWorkflow: compiledWorkflow},
+		})
+	}
+	logger.Debugf(ctx, "Read %v tasks, %v workflows and %v launch plans from package %v", len(p.tasks),
+		len(p.workflows), len(p.launchPlans), packagePath)
+	return p, nil
+}
+
+// matches tells whether the entity has the name if any, and the fields of the field selector. Only the equality of the
+// name and version fields and of the workflow name of a launch plan are supported offline.
+func matches(id *core.Identifier, workflowName, name string, filter filters.Filters) (bool, error) {
+	if len(name) > 0 && id.Name != name {
+		return false, nil
+	}
+	for _, term := range filters.SplitTerms(filter.FieldSelector) {
+		keyValue := strings.SplitN(term, "=", 2)
+		if len(keyValue) != 2 || strings.ContainsAny(keyValue[0], "<>!") {
+			return false, fmt.Errorf("field selector %v isn't supported with fromPackage. Only equalities are", term)
+		}
+		var field string
+		switch key := strings.TrimSpace(keyValue[0]); key[strings.LastIndex(key, ".")+1:] {
+		case "name":
+			field = id.Name
+			if strings.HasPrefix(key, "workflow.") {
+				field = workflowName
+			}
+		case "version":
+			field = id.Version
+		default:
+			return false, fmt.Errorf("field %v isn't supported with fromPackage. Only name and version are", key)
+		}
+		if field != strings.TrimSpace(keyValue[1]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// page returns the page of the filter, the entities being in the order of the package
+func page(count int, filter filters.Filters) (int, int) {
+	if filter.Limit <= 0 {
+		return 0, count
+	}
+	start := int(filter.Limit) * (int(filter.Page) - 1)
+	if start < 0 || start > count {
+		start = count
+	}
+	end := start + int(filter.Limit)
+	if end > count {
+		end = count
+	}
+	return start, end
+}
+
+func (p *packageFetcher) notFound(resourceType, name, version string) error {
+	if len(version) > 0 {
+		return fmt.Errorf("%v %v with version %v not found in package %v", resourceType, name, version, p.path)
+	}
+	return fmt.Errorf("%v %v not found in package %v", resourceType, name, p.path)
+}
+
+func (p *packageFetcher) unsupported(what string) error {
+	return fmt.Errorf("%v can't be fetched from package %v", what, p.path)
+}
+
+func (p *packageFetcher) AdminServiceClient() service.AdminServiceClient {
+	return nil
+}
+
+func (p *packageFetcher) FetchAllVerOfTask(ctx context.Context, name, project, domain string, filter filters.Filters) ([]*admin.Task, error) {
+	var tasks []*admin.Task
+	for _, task := range p.tasks {
+		ok, err := matches(task.Id, "", name, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tasks = append(tasks, task)
+		}
+	}
+	if len(tasks) == 0 && len(name) > 0 {
+		return nil, p.notFound("task", name, "")
+	}
+	start, end := page(len(tasks), filter)
+	return tasks[start:end], nil
+}
+
+func (p *packageFetcher) FetchTaskLatestVersion(ctx context.Context, name, project, domain string, filter filters.Filters) (*admin.Task, error) {
+	tasks, err := p.FetchAllVerOfTask(ctx, name, project, domain, filters.Filters{FieldSelector: filter.FieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	return tasks[len(tasks)-1], nil
+}
+
+func (p *packageFetcher) FetchTaskVersion(ctx context.Context, name, version, project, domain string) (*admin.Task, error) {
+	for _, task := range p.tasks {
+		if task.Id.Name == name && task.Id.Version == version {
+			return task, nil
+		}
+	}
+	return nil, p.notFound("task", name, version)
+}
+
+func (p *packageFetcher) FetchAllWorkflows(ctx context.Context, project, domain string, filter filters.Filters) ([]*admin.NamedEntity, error) {
+	var entities []*admin.NamedEntity
+	seen := map[string]bool{}
+	for _, workflow := range p.workflows {
+		ok, err := matches(workflow.Id, "", "", filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok && !seen[workflow.Id.Name] {
+			seen[workflow.Id.Name] = true
+			entities = append(entities, &admin.NamedEntity{
+				ResourceType: core.ResourceType_WORKFLOW,
+				Id: &admin.NamedEntityIdentifier{
+					Project: workflow.Id.Project,
+					Domain:  workflow.Id.Domain,
+					Name:    workflow.Id.Name,
+				},
+				Metadata: &admin.NamedEntityMetadata{},
+			})
+		}
+	}
+	start, end := page(len(entities), filter)
+	return entities[start:end], nil
+}
+
+func (p *packageFetcher) FetchAllVerOfWorkflow(ctx context.Context, name, project, domain string, filter filters.Filters) ([]*admin.Workflow, error) {
+	var workflows []*admin.Workflow
+	for _, workflow := range p.workflows {
+		ok, err := matches(workflow.Id, "", name, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			workflows = append(workflows, workflow)
+		}
+	}
+	if len(workflows) == 0 && len(name) > 0 {
+		return nil, p.notFound("workflow", name, "")
+	}
+	start, end := page(len(workflows), filter)
+	return workflows[start:end], nil
+}
+
+func (p *packageFetcher) FetchWorkflowLatestVersion(ctx context.Context, name, project, domain string, filter filters.Filters) (*admin.Workflow, error) {
+	workflows, err := p.FetchAllVerOfWorkflow(ctx, name, project, domain, filters.Filters{FieldSelector: filter.FieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	return workflows[len(workflows)-1], nil
+}
+
+func (p *packageFetcher) FetchWorkflowVersion(ctx context.Context, name, version, project, domain string) (*admin.Workflow, error) {
+	for _, workflow := range p.workflows {
+		if workflow.Id.Name == name && workflow.Id.Version == version {
+			return workflow, nil
+		}
+	}
+	return nil, p.notFound("workflow", name, version)
+}
+
+func (p *packageFetcher) FetchAllVerOfLP(ctx context.Context, lpName, project, domain string, filter filters.Filters) ([]*admin.LaunchPlan, error) {
+	var launchPlans []*admin.LaunchPlan
+	for _, lp := range p.launchPlans {
+		ok, err := matches(lp.Id, lp.Spec.GetWorkflowId().GetName(), lpName, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			launchPlans = append(launchPlans, lp)
+		}
+	}
+	if len(launchPlans) == 0 && len(lpName) > 0 {
+		return nil, p.notFound("launch plan", lpName, "")
+	}
+	start, end := page(len(launchPlans), filter)
+	return launchPlans[start:end], nil
+}
+
+func (p *packageFetcher) FetchLPLatestVersion(ctx context.Context, name, project, domain string, filter filters.Filters) (*admin.LaunchPlan, error) {
+	launchPlans, err := p.FetchAllVerOfLP(ctx, name, project, domain, filters.Filters{FieldSelector: filter.FieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	return launchPlans[len(launchPlans)-1], nil
+}
+
+func (p *packageFetcher) FetchLPVersion(ctx context.Context, name, version, project, domain string) (*admin.LaunchPlan, error) {
+	for _, lp := range p.launchPlans {
+		if lp.Id.Name == name && lp.Id.Version == version {
+			return lp, nil
+		}
+	}
+	return nil, p.notFound("launch plan", name, version)
+}
+
+func (p *packageFetcher) FetchExecution(ctx context.Context, name, project, domain string) (*admin.Execution, error) {
+	return nil, p.unsupported("executions")
+}
+
+func (p *packageFetcher) FetchNodeExecutionDetails(ctx context.Context, name, project, domain, uniqueParentID string) (*admin.NodeExecutionList, error) {
+	return nil, p.unsupported("node executions")
+}
+
+func (p *packageFetcher) FetchNodeExecutionData(ctx context.Context, nodeID, execName, project, domain string) (*admin.NodeExecutionGetDataResponse, error) {
+	return nil, p.unsupported("node executions")
+}
+
+func (p *packageFetcher) FetchTaskExecutionsOnNode(ctx context.Context, nodeID, execName, project, domain string) (*admin.TaskExecutionList, error) {
+	return nil, p.unsupported("task executions")
+}
+
+func (p *packageFetcher) ListExecution(ctx context.Context, project, domain string, filter filters.Filters) (*admin.ExecutionList, error) {
+	return nil, p.unsupported("executions")
+}
+
+func (p *packageFetcher) FetchWorkflowAttributes(ctx context.Context, project, domain, name string, rsType admin.MatchableResource) (*admin.WorkflowAttributesGetResponse, error) {
+	return nil, p.unsupported("workflow attributes")
+}
+
+func (p *packageFetcher) FetchProjectDomainAttributes(ctx context.Context, project, domain string, rsType admin.MatchableResource) (*admin.ProjectDomainAttributesGetResponse, error) {
+	return nil, p.unsupported("project domain attributes")
+}
+
+func (p *packageFetcher) ListProjects(ctx context.Context, filter filters.Filters) (*admin.Projects, error) {
+	return nil, p.unsupported("projects")
+}
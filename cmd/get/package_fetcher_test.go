@@ -0,0 +1,127 @@
+package get
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/launchplan"
+	taskConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/task"
+	workflowconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/workflow"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPackage = "testdata/valid-package.tgz"
+
+func TestPackageFetcher(t *testing.T) {
+	ctx := context.Background()
+	config.GetConfig().Project = projectValue
+	config.GetConfig().Domain = domainValue
+	fetcher, err := newPackageFetcher(ctx, testPackage)
+	assert.Nil(t, err)
+
+	t.Run("Tasks", func(t *testing.T) {
+		tasks, err := fetcher.FetchAllVerOfTask(ctx, "", projectValue, domainValue, filters.DefaultFilter)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, "example.example.generate_normal_df", tasks[0].Id.Name)
+		assert.Equal(t, projectValue, tasks[0].Id.Project)
+		assert.Equal(t, domainValue, tasks[0].Id.Domain)
+		assert.NotNil(t, tasks[0].Closure.CompiledTask.Template.Interface)
+
+		task, err := fetcher.FetchTaskLatestVersion(ctx, "example.example.compute_stats", projectValue, domainValue, filters.DefaultFilter)
+		assert.Nil(t, err)
+		assert.Equal(t, "example.example.compute_stats", task.Id.Name)
+		_, err = fetcher.FetchTaskVersion(ctx, "example.example.compute_stats", "v1", projectValue, domainValue)
+		assert.EqualError(t, err, "task example.example.compute_stats with version v1 not found in package "+testPackage)
+		_, err = fetcher.FetchAllVerOfTask(ctx, "missing", projectValue, domainValue, filters.DefaultFilter)
+		assert.EqualError(t, err, "task missing not found in package "+testPackage)
+	})
+	t.Run("Workflows", func(t *testing.T) {
+		entities, err := fetcher.FetchAllWorkflows(ctx, projectValue, domainValue, filters.DefaultFilter)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(entities))
+		assert.Equal(t, "example.example.wf", entities[0].Id.Name)
+		workflow, err := fetcher.FetchWorkflowLatestVersion(ctx, "example.example.wf", projectValue, domainValue, filters.DefaultFilter)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(workflow.Closure.CompiledWorkflow.Tasks))
+	})
+	t.Run("Launch plans", func(t *testing.T) {
+		launchPlans, err := fetcher.FetchAllVerOfLP(ctx, "", projectValue, domainValue,
+			filters.Filters{FieldSelector: "workflow.name=example.example.wf"})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(launchPlans))
+		launchPlans, err = fetcher.FetchAllVerOfLP(ctx, "", projectValue, domainValue,
+			filters.Filters{FieldSelector: "workflow.name=other"})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(launchPlans))
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := fetcher.FetchAllVerOfTask(ctx, "", projectValue, domainValue,
+			filters.Filters{FieldSelector: "task.created_at>=2021-05-24"})
+		assert.NotNil(t, err)
+		_, err = fetcher.FetchAllVerOfTask(ctx, "", projectValue, domainValue,
+			filters.Filters{FieldSelector: "task.state=1"})
+		assert.EqualError(t, err, "field task.state isn't supported with fromPackage. Only name and version are")
+		_, err = fetcher.ListProjects(ctx, filters.DefaultFilter)
+		assert.EqualError(t, err, "projects can't be fetched from package "+testPackage)
+	})
+	t.Run("Invalid package", func(t *testing.T) {
+		_, err := newPackageFetcher(ctx, "testdata/missing.tgz")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPage(t *testing.T) {
+	start, end := page(5, filters.Filters{Limit: 2, Page: 2})
+	assert.Equal(t, []int{2, 4}, []int{start, end})
+	start, end = page(5, filters.Filters{Limit: 2, Page: 3})
+	assert.Equal(t, []int{4, 5}, []int{start, end})
+	start, end = page(5, filters.Filters{Limit: 2, Page: 4})
+	assert.Equal(t, []int{5, 5}, []int{start, end})
+	start, end = page(5, filters.Filters{})
+	assert.Equal(t, []int{0, 5}, []int{start, end})
+}
+
+func TestGetFromPackage(t *testing.T) {
+	defer func() {
+		*taskConfig.DefaultConfig = taskConfig.Config{Filter: filters.DefaultFilter}
+		*workflowconfig.DefaultConfig = workflowconfig.Config{Filter: filters.DefaultFilter}
+		*launchplan.DefaultConfig = launchplan.Config{Filter: filters.DefaultFilter}
+	}()
+	s := testutils.Setup()
+	config.GetConfig().Project = projectValue
+	config.GetConfig().Domain = domainValue
+	config.GetConfig().Output = printer.OutputFormatTABLE.String()
+	*taskConfig.DefaultConfig = taskConfig.Config{Filter: filters.DefaultFilter, FromPackage: testPackage}
+	*workflowconfig.DefaultConfig = workflowconfig.Config{Filter: filters.DefaultFilter, FromPackage: testPackage}
+	*launchplan.DefaultConfig = launchplan.Config{Filter: filters.DefaultFilter, FromPackage: testPackage}
+
+	t.Run("Tasks", func(t *testing.T) {
+		assert.Nil(t, getTaskFunc(s.Ctx, []string{}, s.CmdCtx))
+		assert.Nil(t, getTaskFunc(s.Ctx, []string{"example.example.compute_stats"}, s.CmdCtx))
+		taskConfig.DefaultConfig.UsedBy = true
+		defer func() { taskConfig.DefaultConfig.UsedBy = false }()
+		assert.EqualError(t, getTaskFunc(s.Ctx, []string{"example.example.compute_stats"}, s.CmdCtx),
+			"usedBy isn't supported with fromPackage")
+	})
+	t.Run("Workflows", func(t *testing.T) {
+		assert.Nil(t, getWorkflowFunc(s.Ctx, []string{}, s.CmdCtx))
+		workflowconfig.DefaultConfig.Latest = true
+		defer func() { workflowconfig.DefaultConfig.Latest = false }()
+		config.GetConfig().Output = printer.OutputFormatDOT.String()
+		defer func() { config.GetConfig().Output = printer.OutputFormatTABLE.String() }()
+		assert.Nil(t, getWorkflowFunc(s.Ctx, []string{"example.example.wf"}, s.CmdCtx))
+	})
+	t.Run("Launch plans", func(t *testing.T) {
+		assert.Nil(t, getLaunchPlanFunc(s.Ctx, []string{}, s.CmdCtx))
+		assert.Nil(t, getLaunchPlanFunc(s.Ctx, []string{"example.example.wf"}, s.CmdCtx))
+	})
+	t.Run("Missing package", func(t *testing.T) {
+		taskConfig.DefaultConfig.FromPackage = "testdata/missing.tgz"
+		assert.NotNil(t, getTaskFunc(s.Ctx, []string{}, s.CmdCtx))
+	})
+}
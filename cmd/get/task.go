@@ -2,6 +2,7 @@ package get
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/flyteorg/flytectl/cmd/config"
 	taskConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/task"
@@ -98,6 +99,24 @@ The generated file would look similar to this:
 
 Check the create execution section on how to launch one using the generated file.
 
+List the workflows and launch plans within project and domain which use any version of the task, either directly or through a subworkflow or launch plan node. Every version of the workflows is inspected:
+
+::
+
+ flytectl get task -p flytesnacks -d development core.control_flow.merge_sort.merge --usedBy
+
+Restrict the listing to the workflows and launch plans which pin a particular version of the task:
+
+::
+
+ flytectl get task -p flytesnacks -d development core.control_flow.merge_sort.merge --usedBy --version v2
+
+Retrieve the tasks of a registration package without a cluster, e.g. to review what it contains. The tasks get the project and domain they would be registered in:
+
+::
+
+ flytectl get task -p flytesnacks -d development --fromPackage flyte-package.tgz
+
 Usage
 `
 )
@@ -148,9 +167,27 @@ func getTaskFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandConte
 	var err error
 	project := config.GetConfig().Project
 	domain := config.GetConfig().Domain
+	fetcher, err := adminFetcher(ctx, cmdCtx, taskConfig.DefaultConfig.FromPackage)
+	if err != nil {
+		return err
+	}
+	if taskConfig.DefaultConfig.UsedBy {
+		if len(taskConfig.DefaultConfig.FromPackage) > 0 {
+			return fmt.Errorf("usedBy isn't supported with fromPackage")
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("task name is required with usedBy")
+		}
+		dependents, err := fetchTaskUsedBy(ctx, cmdCtx, args[0], taskConfig.DefaultConfig.Version, project, domain)
+		if err != nil {
+			return err
+		}
+		logger.Debugf(ctx, "Retrieved %v workflows and launch plans using the task", len(dependents))
+		return taskPrinter.PrintInterface(config.GetConfig().MustOutputFormat(), usedByColumns, dependents)
+	}
 	if len(args) == 1 {
 		name := args[0]
-		if tasks, err = FetchTaskForName(ctx, cmdCtx.AdminFetcherExt(), name, project, domain); err != nil {
+		if tasks, err = FetchTaskForName(ctx, fetcher, name, project, domain); err != nil {
 			return err
 		}
 		logger.Debugf(ctx, "Retrieved Task", tasks)
@@ -160,7 +197,7 @@ func getTaskFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandConte
 		return taskPrinter.Print(config.GetConfig().MustOutputFormat(), taskColumns, TaskToProtoMessages(tasks)...)
 
 	}
-	tasks, err = cmdCtx.AdminFetcherExt().FetchAllVerOfTask(ctx, "", config.GetConfig().Project, config.GetConfig().Domain, taskConfig.DefaultConfig.Filter)
+	tasks, err = fetcher.FetchAllVerOfTask(ctx, "", config.GetConfig().Project, config.GetConfig().Domain, taskConfig.DefaultConfig.Filter)
 	if err != nil {
 		return err
 	}
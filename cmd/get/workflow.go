@@ -2,6 +2,7 @@ package get
 
 import (
 	"context"
+	"fmt"
 
 	workflowconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/workflow"
 	"github.com/flyteorg/flytectl/pkg/ext"
@@ -85,6 +86,24 @@ Visualize the graph for a workflow within project and domain in a dot content re
 
  flytectl get workflow -p flytesnacks -d development  core.flyte_basics.basic_workflow.my_wf --latest -o doturl
 
+Print the tasks, subworkflows and launch plans used by the latest version of a workflow as a tree. The workflows launched by the launch plans are expanded as well:
+
+::
+
+ flytectl get workflow -p flytesnacks -d development  core.flyte_basics.basic_workflow.my_wf --dependencies
+
+Print the dependencies of a particular version of a workflow:
+
+::
+
+ flytectl get workflow -p flytesnacks -d development  core.flyte_basics.basic_workflow.my_wf --dependencies --version v2
+
+Retrieve the workflows of a registration package without a cluster, e.g. to review what it contains. The workflows are compiled locally, which lets them be visualized:
+
+::
+
+ flytectl get workflow -p flytesnacks -d development core.flyte_basics.basic_workflow.my_wf --fromPackage flyte-package.tgz -o doturl
+
 Usage
 `
 )
@@ -153,11 +172,20 @@ func WorkflowToTableProtoMessages(l []*admin.Workflow) []proto.Message {
 func getWorkflowFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
 	adminPrinter := printer.Printer{}
 	var workflows []*admin.Workflow
-	var err error
+	fetcher, err := adminFetcher(ctx, cmdCtx, workflowconfig.DefaultConfig.FromPackage)
+	if err != nil {
+		return err
+	}
+	if workflowconfig.DefaultConfig.Dependencies {
+		if len(args) == 0 {
+			return fmt.Errorf("workflow name is required with dependencies")
+		}
+		return printWorkflowDependencies(ctx, fetcher, args[0], config.GetConfig().Project, config.GetConfig().Domain)
+	}
 	if len(args) > 0 {
 		name := args[0]
 		var isList bool
-		if workflows, isList, err = FetchWorkflowForName(ctx, cmdCtx.AdminFetcherExt(), name, config.GetConfig().Project, config.GetConfig().Domain); err != nil {
+		if workflows, isList, err = FetchWorkflowForName(ctx, fetcher, name, config.GetConfig().Project, config.GetConfig().Domain); err != nil {
 			return err
 		}
 		columns := workflowColumns
@@ -171,7 +199,7 @@ func getWorkflowFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandC
 		return adminPrinter.Print(config.GetConfig().MustOutputFormat(), columns, WorkflowToProtoMessages(workflows)...)
 	}
 
-	nameEntities, err := cmdCtx.AdminFetcherExt().FetchAllWorkflows(ctx, config.GetConfig().Project, config.GetConfig().Domain, workflowconfig.DefaultConfig.Filter)
+	nameEntities, err := fetcher.FetchAllWorkflows(ctx, config.GetConfig().Project, config.GetConfig().Domain, workflowconfig.DefaultConfig.Filter)
 	if err != nil {
 		return err
 	}
@@ -203,3 +231,23 @@ func FetchWorkflowForName(ctx context.Context, fetcher ext.AdminFetcherExtInterf
 	}
 	return workflows, isList, nil
 }
+
+// printWorkflowDependencies prints the dependency tree of the given version of the workflow, or of its latest version.
+func printWorkflowDependencies(ctx context.Context, fetcher ext.AdminFetcherExtInterface, name, project, domain string) error {
+	var workflow *admin.Workflow
+	var err error
+	if len(workflowconfig.DefaultConfig.Version) > 0 && !workflowconfig.DefaultConfig.Latest {
+		workflow, err = fetcher.FetchWorkflowVersion(ctx, name, workflowconfig.DefaultConfig.Version, project, domain)
+	} else {
+		workflow, err = fetcher.FetchWorkflowLatestVersion(ctx, name, project, domain, workflowconfig.DefaultConfig.Filter)
+	}
+	if err != nil {
+		return err
+	}
+	dependencyTree, err := createDependencyTreeView(ctx, fetcher, workflow)
+	if err != nil {
+		return err
+	}
+	fmt.Println(dependencyTree.Print())
+	return nil
+}
@@ -0,0 +1,282 @@
+package promote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flyteorg/flytectl/clierrors"
+	"github.com/flyteorg/flytectl/cmd/config"
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/promote"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flytectl/pkg/workflowutil"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	promoteLPShort = "Promotes a launch plan and the entities it depends on to another domain."
+	promoteLPLong  = `
+Promote a launch plan version from one domain to another within a project. The launch plan, its workflow
+(including subworkflows), the workflow's tasks and any launch plans referenced by the workflow are fetched
+from the source domain, their identifiers are rewritten to the target domain and they are registered there
+with the same versions. No serialized package is needed.
+
+::
+
+ flytectl promote launchplan -p flytesnacks core.basic.lp.go_greet --version v1 --fromDomain development --toDomain production
+
+The source domain defaults to the configured domain:
+
+::
+
+ flytectl promote launchplan -p flytesnacks -d development core.basic.lp.go_greet --version v1 --toDomain production
+
+Activate the launch plan in the target domain, which also enables its schedule if it has one:
+
+::
+
+ flytectl promote launchplan -p flytesnacks core.basic.lp.go_greet --version v1 --fromDomain development --toDomain production --activate
+
+Entities which already exist in the target domain with the same version are left untouched.
+
+Usage
+`
+)
+
+var promoteColumns = []printer.Column{
+	{Header: "Name", JSONPath: "$.Name"},
+	{Header: "Type", JSONPath: "$.Type"},
+	{Header: "Version", JSONPath: "$.Version"},
+	{Header: "Status", JSONPath: "$.Status"},
+}
+
+// Result is the outcome of promoting a single entity
+type Result struct {
+	Name    string
+	Type    string
+	Version string
+	Status  string
+}
+
+// promoter copies entities from the source domain into the target domain of a project
+type promoter struct {
+	cmdCtx     cmdCore.CommandContext
+	project    string
+	fromDomain string
+	toDomain   string
+	dryRun     bool
+	promoted   map[string]bool
+	results    []Result
+}
+
+func promoteLPFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	if len(args) != 1 {
+		return fmt.Errorf(clierrors.ErrLPNotPassed)
+	}
+	name := args[0]
+	cfg := promote.DefaultConfig
+	if len(cfg.Version) == 0 {
+		return fmt.Errorf(clierrors.ErrLPVersionNotPassed)
+	}
+	project := config.GetConfig().Project
+	if len(project) == 0 {
+		return fmt.Errorf(clierrors.ErrProjectNotPassed)
+	}
+	fromDomain := cfg.FromDomain
+	if len(fromDomain) == 0 {
+		fromDomain = config.GetConfig().Domain
+	}
+	if len(fromDomain) == 0 || len(cfg.ToDomain) == 0 {
+		return fmt.Errorf("source and target domains are required parameters")
+	}
+	if fromDomain == cfg.ToDomain {
+		return fmt.Errorf("source and target domains should be different, got %v", fromDomain)
+	}
+
+	p := &promoter{
+		cmdCtx:     cmdCtx,
+		project:    project,
+		fromDomain: fromDomain,
+		toDomain:   cfg.ToDomain,
+		dryRun:     cfg.DryRun,
+		promoted:   map[string]bool{},
+	}
+	err := p.promoteLaunchPlan(ctx, name, cfg.Version)
+	if err == nil && cfg.Activate {
+		err = p.activateLaunchPlan(ctx, name, cfg.Version)
+	}
+	payload, _ := json.Marshal(p.results)
+	promotePrinter := printer.Printer{}
+	_ = promotePrinter.JSONToTable(payload, promoteColumns)
+	return err
+}
+
+func (p *promoter) promoteLaunchPlan(ctx context.Context, name, version string) error {
+	if !p.markPromoted(core.ResourceType_LAUNCH_PLAN, name, version) {
+		return nil
+	}
+	lp, err := p.cmdCtx.AdminFetcherExt().FetchLPVersion(ctx, name, version, p.project, p.fromDomain)
+	if err != nil {
+		return err
+	}
+	spec := proto.Clone(lp.Spec).(*admin.LaunchPlanSpec)
+	if wfID := spec.GetWorkflowId(); wfID != nil && p.isSource(wfID) {
+		if err := p.promoteWorkflow(ctx, wfID.Name, wfID.Version); err != nil {
+			return err
+		}
+		p.rewriteIdentifier(wfID)
+	}
+	return p.create(core.ResourceType_LAUNCH_PLAN, name, version, func() error {
+		_, err := p.cmdCtx.AdminClient().CreateLaunchPlan(ctx, &admin.LaunchPlanCreateRequest{
+			Id:   p.targetIdentifier(core.ResourceType_LAUNCH_PLAN, name, version),
+			Spec: spec,
+		})
+		return err
+	})
+}
+
+func (p *promoter) promoteWorkflow(ctx context.Context, name, version string) error {
+	if !p.markPromoted(core.ResourceType_WORKFLOW, name, version) {
+		return nil
+	}
+	wf, err := p.cmdCtx.AdminFetcherExt().FetchWorkflowVersion(ctx, name, version, p.project, p.fromDomain)
+	if err != nil {
+		return err
+	}
+	compiled := wf.GetClosure().GetCompiledWorkflow()
+	if compiled.GetPrimary().GetTemplate() == nil {
+		return fmt.Errorf("workflow %v version %v doesn't have a compiled template", name, version)
+	}
+	compiled = proto.Clone(compiled).(*core.CompiledWorkflowClosure)
+
+	// Dependencies are registered before the workflow, as admin validates the references on creation.
+	for _, task := range compiled.Tasks {
+		id := task.GetTemplate().GetId()
+		if id != nil && p.isSource(id) {
+			if err := p.promoteTask(ctx, id.Name, id.Version); err != nil {
+				return err
+			}
+		}
+	}
+	templates := []*core.WorkflowTemplate{compiled.Primary.Template}
+	for _, subWorkflow := range compiled.SubWorkflows {
+		templates = append(templates, subWorkflow.Template)
+	}
+	for _, template := range templates {
+		var refErr error
+		workflowutil.VisitReferences(template, func(id *core.Identifier) {
+			if refErr == nil && id.ResourceType == core.ResourceType_LAUNCH_PLAN && p.isSource(id) {
+				refErr = p.promoteLaunchPlan(ctx, id.Name, id.Version)
+			}
+		})
+		if refErr != nil {
+			return refErr
+		}
+	}
+
+	spec := &admin.WorkflowSpec{Template: compiled.Primary.Template, SubWorkflows: templates[1:]}
+	for _, template := range templates {
+		p.rewriteIdentifier(template.Id)
+		workflowutil.VisitReferences(template, p.rewriteIdentifier)
+	}
+	return p.create(core.ResourceType_WORKFLOW, name, version, func() error {
+		_, err := p.cmdCtx.AdminClient().CreateWorkflow(ctx, &admin.WorkflowCreateRequest{
+			Id:   p.targetIdentifier(core.ResourceType_WORKFLOW, name, version),
+			Spec: spec,
+		})
+		return err
+	})
+}
+
+func (p *promoter) promoteTask(ctx context.Context, name, version string) error {
+	if !p.markPromoted(core.ResourceType_TASK, name, version) {
+		return nil
+	}
+	task, err := p.cmdCtx.AdminFetcherExt().FetchTaskVersion(ctx, name, version, p.project, p.fromDomain)
+	if err != nil {
+		return err
+	}
+	template := task.GetClosure().GetCompiledTask().GetTemplate()
+	if template == nil {
+		return fmt.Errorf("task %v version %v doesn't have a compiled template", name, version)
+	}
+	template = proto.Clone(template).(*core.TaskTemplate)
+	p.rewriteIdentifier(template.Id)
+	return p.create(core.ResourceType_TASK, name, version, func() error {
+		_, err := p.cmdCtx.AdminClient().CreateTask(ctx, &admin.TaskCreateRequest{
+			Id:   p.targetIdentifier(core.ResourceType_TASK, name, version),
+			Spec: &admin.TaskSpec{Template: template},
+		})
+		return err
+	})
+}
+
+func (p *promoter) activateLaunchPlan(ctx context.Context, name, version string) error {
+	if p.dryRun {
+		logger.Debugf(ctx, "skipping UpdateLaunchPlan request (DryRun)")
+		return nil
+	}
+	_, err := p.cmdCtx.AdminClient().UpdateLaunchPlan(ctx, &admin.LaunchPlanUpdateRequest{
+		Id:    p.targetIdentifier(core.ResourceType_LAUNCH_PLAN, name, version),
+		State: admin.LaunchPlanState_ACTIVE,
+	})
+	if err != nil {
+		fmt.Printf(clierrors.ErrFailedLPUpdate, name, err)
+	}
+	return err
+}
+
+// create registers an entity in the target domain and records the outcome. Entities which already exist are
+// considered promoted.
+func (p *promoter) create(resourceType core.ResourceType, name, version string, createFunc func() error) error {
+	result := Result{Name: name, Type: resourceType.String(), Version: version, Status: "Promoted"}
+	var err error
+	if p.dryRun {
+		result.Status = "Skipped (DryRun)"
+	} else if err = createFunc(); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			result.Status = "Already exists"
+			err = nil
+		} else {
+			result.Status = fmt.Sprintf("Failed due to %v", err)
+		}
+	}
+	p.results = append(p.results, result)
+	return err
+}
+
+// markPromoted returns false if the entity was already visited during this promotion.
+func (p *promoter) markPromoted(resourceType core.ResourceType, name, version string) bool {
+	key := fmt.Sprintf("%v/%v/%v", resourceType, name, version)
+	if p.promoted[key] {
+		return false
+	}
+	p.promoted[key] = true
+	return true
+}
+
+func (p *promoter) isSource(id *core.Identifier) bool {
+	return id.Project == p.project && id.Domain == p.fromDomain
+}
+
+func (p *promoter) rewriteIdentifier(id *core.Identifier) {
+	if id != nil && p.isSource(id) {
+		id.Domain = p.toDomain
+	}
+}
+
+func (p *promoter) targetIdentifier(resourceType core.ResourceType, name, version string) *core.Identifier {
+	return &core.Identifier{
+		ResourceType: resourceType,
+		Project:      p.project,
+		Domain:       p.toDomain,
+		Name:         name,
+		Version:      version,
+	}
+}
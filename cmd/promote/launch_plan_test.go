@@ -0,0 +1,169 @@
+package promote
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/promote"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	projectValue = "dummyProject"
+	devDomain    = "development"
+	prodDomain   = "production"
+)
+
+func identifier(resourceType core.ResourceType, domain, name string) *core.Identifier {
+	return &core.Identifier{ResourceType: resourceType, Project: projectValue, Domain: domain, Name: name, Version: "v1"}
+}
+
+func launchPlan(name, workflow string) *admin.LaunchPlan {
+	return &admin.LaunchPlan{
+		Id:   identifier(core.ResourceType_LAUNCH_PLAN, devDomain, name),
+		Spec: &admin.LaunchPlanSpec{WorkflowId: identifier(core.ResourceType_WORKFLOW, devDomain, workflow)},
+	}
+}
+
+func workflow(name string, nodes ...*core.Node) *admin.Workflow {
+	return &admin.Workflow{
+		Id: identifier(core.ResourceType_WORKFLOW, devDomain, name),
+		Closure: &admin.WorkflowClosure{
+			CompiledWorkflow: &core.CompiledWorkflowClosure{
+				Primary: &core.CompiledWorkflow{
+					Template: &core.WorkflowTemplate{Id: identifier(core.ResourceType_WORKFLOW, devDomain, name), Nodes: nodes},
+				},
+			},
+		},
+	}
+}
+
+func taskNode(domain, name string) *core.Node {
+	return &core.Node{
+		Target: &core.Node_TaskNode{TaskNode: &core.TaskNode{
+			Reference: &core.TaskNode_ReferenceId{ReferenceId: identifier(core.ResourceType_TASK, domain, name)},
+		}},
+	}
+}
+
+func setPromoteConfig(t *testing.T, cfg promote.Config) {
+	orig := *promote.DefaultConfig
+	*promote.DefaultConfig = cfg
+	t.Cleanup(func() {
+		*promote.DefaultConfig = orig
+	})
+}
+
+func TestPromoteLaunchPlanValidation(t *testing.T) {
+	s := setup()
+	setPromoteConfig(t, promote.Config{Version: "v1", ToDomain: prodDomain})
+	assert.EqualError(t, promoteLPFunc(s.Ctx, nil, s.CmdCtx), "launch plan name wasn't passed\n")
+
+	setPromoteConfig(t, promote.Config{ToDomain: prodDomain})
+	assert.EqualError(t, promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx), "launch plan version wasn't passed\n")
+
+	setPromoteConfig(t, promote.Config{Version: "v1"})
+	assert.EqualError(t, promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx), "source and target domains are required parameters")
+
+	setPromoteConfig(t, promote.Config{Version: "v1", FromDomain: prodDomain, ToDomain: prodDomain})
+	assert.EqualError(t, promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx), "source and target domains should be different, got production")
+}
+
+func TestPromoteLaunchPlan(t *testing.T) {
+	t.Run("promote with dependencies", func(t *testing.T) {
+		s := setup()
+		setPromoteConfig(t, promote.Config{Version: "v1", FromDomain: devDomain, ToDomain: prodDomain, Activate: true})
+
+		childLPNode := &core.Node{
+			Target: &core.Node_WorkflowNode{WorkflowNode: &core.WorkflowNode{
+				Reference: &core.WorkflowNode_LaunchplanRef{LaunchplanRef: identifier(core.ResourceType_LAUNCH_PLAN, devDomain, "child_lp")},
+			}},
+		}
+		parent := workflow("parent_wf", taskNode(devDomain, "t1"), taskNode("shared", "reference_task"), childLPNode)
+		parent.Closure.CompiledWorkflow.Tasks = []*core.CompiledTask{
+			{Template: &core.TaskTemplate{Id: identifier(core.ResourceType_TASK, devDomain, "t1")}},
+			{Template: &core.TaskTemplate{Id: identifier(core.ResourceType_TASK, "shared", "reference_task")}},
+		}
+		child := workflow("child_wf", taskNode(devDomain, "t1"))
+		child.Closure.CompiledWorkflow.Tasks = []*core.CompiledTask{
+			{Template: &core.TaskTemplate{Id: identifier(core.ResourceType_TASK, devDomain, "t1")}},
+		}
+
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "parent_lp", "v1", projectValue, devDomain).Return(launchPlan("parent_lp", "parent_wf"), nil)
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "child_lp", "v1", projectValue, devDomain).Return(launchPlan("child_lp", "child_wf"), nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "parent_wf", "v1", projectValue, devDomain).Return(parent, nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "child_wf", "v1", projectValue, devDomain).Return(child, nil)
+		s.FetcherExt.OnFetchTaskVersionMatch(s.Ctx, "t1", "v1", projectValue, devDomain).Return(&admin.Task{
+			Id:      identifier(core.ResourceType_TASK, devDomain, "t1"),
+			Closure: &admin.TaskClosure{CompiledTask: &core.CompiledTask{Template: &core.TaskTemplate{Id: identifier(core.ResourceType_TASK, devDomain, "t1")}}},
+		}, nil).Once()
+
+		var created []string
+		s.MockAdminClient.OnCreateTaskMatch(s.Ctx, mock.MatchedBy(func(r *admin.TaskCreateRequest) bool {
+			return r.Id.Domain == prodDomain && r.Spec.Template.Id.Domain == prodDomain
+		})).Run(func(args mock.Arguments) {
+			created = append(created, "task "+args.Get(1).(*admin.TaskCreateRequest).Id.Name)
+		}).Return(nil, status.Error(codes.AlreadyExists, "exists")).Once()
+		s.MockAdminClient.OnCreateWorkflowMatch(s.Ctx, mock.Anything).Run(func(args mock.Arguments) {
+			r := args.Get(1).(*admin.WorkflowCreateRequest)
+			assert.Equal(t, prodDomain, r.Id.Domain)
+			assert.Equal(t, prodDomain, r.Spec.Template.Id.Domain)
+			assert.Equal(t, prodDomain, r.Spec.Template.Nodes[0].GetTaskNode().GetReferenceId().Domain)
+			if r.Id.Name == "parent_wf" {
+				assert.Equal(t, "shared", r.Spec.Template.Nodes[1].GetTaskNode().GetReferenceId().Domain)
+				assert.Equal(t, prodDomain, r.Spec.Template.Nodes[2].GetWorkflowNode().GetLaunchplanRef().Domain)
+			}
+			created = append(created, "workflow "+r.Id.Name)
+		}).Return(&admin.WorkflowCreateResponse{}, nil).Twice()
+		s.MockAdminClient.OnCreateLaunchPlanMatch(s.Ctx, mock.Anything).Run(func(args mock.Arguments) {
+			r := args.Get(1).(*admin.LaunchPlanCreateRequest)
+			assert.Equal(t, prodDomain, r.Id.Domain)
+			assert.Equal(t, prodDomain, r.Spec.WorkflowId.Domain)
+			created = append(created, "launchplan "+r.Id.Name)
+		}).Return(&admin.LaunchPlanCreateResponse{}, nil).Twice()
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(s.Ctx, &admin.LaunchPlanUpdateRequest{
+			Id:    identifier(core.ResourceType_LAUNCH_PLAN, prodDomain, "parent_lp"),
+			State: admin.LaunchPlanState_ACTIVE,
+		}).Return(&admin.LaunchPlanUpdateResponse{}, nil).Once()
+
+		err := promoteLPFunc(s.Ctx, []string{"parent_lp"}, s.CmdCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"task t1", "workflow child_wf", "launchplan child_lp", "workflow parent_wf", "launchplan parent_lp"}, created)
+		s.MockAdminClient.AssertExpectations(t)
+		// The parent launch plan must not be fetched from the source domain twice.
+		s.FetcherExt.AssertNumberOfCalls(t, "FetchLPVersion", 2)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		s := setup()
+		setPromoteConfig(t, promote.Config{Version: "v1", FromDomain: devDomain, ToDomain: prodDomain, DryRun: true, Activate: true})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "lp", "v1", projectValue, devDomain).Return(launchPlan("lp", "wf"), nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "wf", "v1", projectValue, devDomain).Return(workflow("wf"), nil)
+		err := promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "CreateWorkflow", mock.Anything, mock.Anything)
+		s.MockAdminClient.AssertNotCalled(t, "CreateLaunchPlan", mock.Anything, mock.Anything)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		s := setup()
+		setPromoteConfig(t, promote.Config{Version: "v1", FromDomain: devDomain, ToDomain: prodDomain})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "lp", "v1", projectValue, devDomain).Return(nil, fmt.Errorf("not found"))
+		err := promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx)
+		assert.EqualError(t, err, "not found")
+	})
+	t.Run("create error", func(t *testing.T) {
+		s := setup()
+		setPromoteConfig(t, promote.Config{Version: "v1", FromDomain: devDomain, ToDomain: prodDomain})
+		s.FetcherExt.OnFetchLPVersionMatch(s.Ctx, "lp", "v1", projectValue, devDomain).Return(launchPlan("lp", "wf"), nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "wf", "v1", projectValue, devDomain).Return(workflow("wf"), nil)
+		s.MockAdminClient.OnCreateWorkflowMatch(s.Ctx, mock.Anything).Return(nil, fmt.Errorf("failed"))
+		err := promoteLPFunc(s.Ctx, []string{"lp"}, s.CmdCtx)
+		assert.EqualError(t, err, "failed")
+		s.MockAdminClient.AssertNotCalled(t, "CreateLaunchPlan", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,35 @@
+package promote
+
+import (
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/promote"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using sphinx.
+const (
+	promoteUse     = "promote"
+	promoteShort   = `Promotes Flyte resources from one domain to another.`
+	promotecmdLong = `
+Promote a registered Flyte resource together with its dependencies from one domain to another; if a launch plan:
+::
+
+ flytectl promote launchplan -p flytesnacks core.basic.lp.go_greet --version v1 --fromDomain development --toDomain production
+`
+)
+
+// CreatePromoteCommand will return promote command
+func CreatePromoteCommand() *cobra.Command {
+	promoteCmd := &cobra.Command{
+		Use:   promoteUse,
+		Short: promoteShort,
+		Long:  promotecmdLong,
+	}
+	promoteResourcesFuncs := map[string]cmdCore.CommandEntry{
+		"launchplan": {CmdFunc: promoteLPFunc, Aliases: []string{"launchplans"}, ProjectDomainNotRequired: true, PFlagProvider: promote.DefaultConfig,
+			Short: promoteLPShort, Long: promoteLPLong},
+	}
+	cmdCore.AddCommands(promoteCmd, promoteResourcesFuncs)
+	return promoteCmd
+}
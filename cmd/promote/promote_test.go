@@ -0,0 +1,23 @@
+package promote
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var setup = testutils.Setup
+
+func TestPromoteCommand(t *testing.T) {
+	promoteCommand := CreatePromoteCommand()
+	assert.Equal(t, promoteCommand.Use, promoteUse)
+	assert.Equal(t, promoteCommand.Short, promoteShort)
+	assert.Equal(t, promoteCommand.Long, promotecmdLong)
+	assert.Equal(t, len(promoteCommand.Commands()), 1)
+	cmdNoun := promoteCommand.Commands()[0]
+	assert.Equal(t, cmdNoun.Use, "launchplan")
+	assert.Equal(t, cmdNoun.Aliases, []string{"launchplans"})
+	assert.Equal(t, cmdNoun.Short, promoteLPShort)
+	assert.Equal(t, cmdNoun.Long, promoteLPLong)
+}
@@ -0,0 +1,88 @@
+package prune
+
+import (
+	"context"
+	"os"
+
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/prune"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/spf13/cobra"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using sphinx.
+const (
+	pruneUse     = "prune"
+	pruneShort   = `Archives stale versions of Flyte resources.`
+	prunecmdLong = `
+Find the stale versions of tasks, workflows and launch plans within a project and domain, and archive the entities
+whose versions are all stale; if tasks:
+::
+
+ flytectl prune task -p flytesnacks -d development --olderThanDays 90 --dryRun
+`
+	pruneResourceLong = `
+A version is stale when it was created more than --olderThanDays days ago and is not one of the newest --keep versions
+of its entity. At least one of the two flags is required; a flag which isn't given doesn't restrict the stale versions.
+
+Versions which are still in use are never considered stale: launch plan versions which are active, workflow versions
+used by an active launch plan or a running execution, and the task versions used by those workflows.
+
+Flyte doesn't support deleting individual versions, so entities which have only stale versions are archived; archived
+entities are hidden from listings but remain available to their existing executions. The stale versions are listed
+first and a confirmation is requested before any entity is archived.
+
+List the stale versions of all tasks in a project and domain:
+
+::
+
+ flytectl prune task -p flytesnacks -d development --olderThanDays 90 --dryRun
+
+Archive the workflows in which every version is older than 30 days, skipping the confirmation prompt:
+
+::
+
+ flytectl prune workflow -p flytesnacks -d development --olderThanDays 30 --force
+
+Only consider the given launch plans, keeping the newest 5 versions of each:
+
+::
+
+ flytectl prune launchplan -p flytesnacks -d development core.basic.lp.go_greet --keep 5
+
+Deactivate the active launch plan versions which are stale instead of skipping them. Their launch plans are archived
+once deactivated if all their versions are stale:
+
+::
+
+ flytectl prune launchplan -p flytesnacks -d development --olderThanDays 90 --deactivate
+
+Usage
+`
+)
+
+// CreatePruneCommand will return prune command
+func CreatePruneCommand() *cobra.Command {
+	pruneCmd := &cobra.Command{
+		Use:   pruneUse,
+		Short: pruneShort,
+		Long:  prunecmdLong,
+	}
+	pruneResourcesFuncs := map[string]cmdCore.CommandEntry{
+		"task": {CmdFunc: getPruneFunc(core.ResourceType_TASK), Aliases: []string{"tasks"}, PFlagProvider: prune.DefaultConfig,
+			Short: "Archives stale task versions.", Long: pruneResourceLong},
+		"workflow": {CmdFunc: getPruneFunc(core.ResourceType_WORKFLOW), Aliases: []string{"workflows"}, PFlagProvider: prune.DefaultConfig,
+			Short: "Archives stale workflow versions.", Long: pruneResourceLong},
+		"launchplan": {CmdFunc: getPruneFunc(core.ResourceType_LAUNCH_PLAN), Aliases: []string{"launchplans"}, PFlagProvider: prune.DefaultConfig,
+			Short: "Archives stale launch plan versions.", Long: pruneResourceLong},
+	}
+	cmdCore.AddCommands(pruneCmd, pruneResourcesFuncs)
+	return pruneCmd
+}
+
+func getPruneFunc(resourceType core.ResourceType) cmdCore.CommandFunc {
+	return func(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+		return pruneEntities(ctx, resourceType, args, cmdCtx, prune.DefaultConfig, os.Stdin)
+	}
+}
@@ -0,0 +1,36 @@
+package prune
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var setup = testutils.Setup
+
+func TestPruneCommand(t *testing.T) {
+	pruneCommand := CreatePruneCommand()
+	assert.Equal(t, pruneCommand.Use, pruneUse)
+	assert.Equal(t, pruneCommand.Short, pruneShort)
+	assert.Equal(t, pruneCommand.Long, prunecmdLong)
+	assert.Equal(t, len(pruneCommand.Commands()), 3)
+	cmdNouns := pruneCommand.Commands()
+	// Sort by Use value.
+	sort.Slice(cmdNouns, func(i, j int) bool {
+		return cmdNouns[i].Use < cmdNouns[j].Use
+	})
+	assert.Equal(t, cmdNouns[0].Use, "launchplan")
+	assert.Equal(t, cmdNouns[0].Aliases, []string{"launchplans"})
+	assert.Equal(t, cmdNouns[0].Short, "Archives stale launch plan versions.")
+	assert.Equal(t, cmdNouns[1].Use, "task")
+	assert.Equal(t, cmdNouns[1].Aliases, []string{"tasks"})
+	assert.Equal(t, cmdNouns[1].Short, "Archives stale task versions.")
+	assert.Equal(t, cmdNouns[2].Use, "workflow")
+	assert.Equal(t, cmdNouns[2].Aliases, []string{"workflows"})
+	assert.Equal(t, cmdNouns[2].Short, "Archives stale workflow versions.")
+	for _, cmdNoun := range cmdNouns {
+		assert.Equal(t, cmdNoun.Long, pruneResourceLong)
+	}
+}
@@ -0,0 +1,354 @@
+package prune
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/prune"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/cmd/update"
+	cmdUtil "github.com/flyteorg/flytectl/pkg/commandutils"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const runningExecutionsSelector = "execution.phase in (UNDEFINED;QUEUED;RUNNING;SUCCEEDING;FAILING;ABORTING)"
+
+var pruneColumns = []printer.Column{
+	{Header: "Name", JSONPath: "$.Name"},
+	{Header: "Version", JSONPath: "$.Version"},
+	{Header: "Created At", JSONPath: "$.CreatedAt"},
+	{Header: "Status", JSONPath: "$.Status"},
+}
+
+// entityVersion is a single version of a task, workflow or launch plan
+type entityVersion struct {
+	Name      string
+	Version   string
+	CreatedAt time.Time
+}
+
+// Result describes whether a version is stale
+type Result struct {
+	Name      string
+	Version   string
+	CreatedAt string
+	Status    string
+}
+
+func pruneEntities(ctx context.Context, resourceType core.ResourceType, args []string, cmdCtx cmdCore.CommandContext,
+	cfg *prune.Config, reader io.Reader) error {
+	if cfg.OlderThanDays <= 0 && cfg.Keep <= 0 {
+		return fmt.Errorf("either olderThanDays or keep should be specified")
+	}
+	if cfg.Deactivate && resourceType != core.ResourceType_LAUNCH_PLAN {
+		return fmt.Errorf("deactivate can only be used to prune launch plans")
+	}
+	project := config.GetConfig().Project
+	domain := config.GetConfig().Domain
+
+	names := args
+	if len(names) == 0 {
+		var err error
+		if names, err = fetchEntityNames(ctx, cmdCtx, resourceType, project, domain, cfg.Limit); err != nil {
+			return err
+		}
+	}
+	inUse, active, err := fetchInUseVersions(ctx, cmdCtx, resourceType, project, domain, cfg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var results []Result
+	var staleEntities []string
+	var deactivations []entityVersion
+	for _, name := range names {
+		versions, err := fetchEntityVersions(ctx, cmdCtx, resourceType, name, project, domain, cfg.Limit)
+		if err != nil {
+			return err
+		}
+		entityResults, entityDeactivations, allStale := staleVersions(versions, inUse, active, cfg, now)
+		results = append(results, entityResults...)
+		deactivations = append(deactivations, entityDeactivations...)
+		if allStale {
+			staleEntities = append(staleEntities, name)
+		}
+	}
+
+	payload, _ := json.Marshal(results)
+	prunePrinter := printer.Printer{}
+	_ = prunePrinter.JSONToTable(payload, pruneColumns)
+
+	if len(staleEntities) == 0 && len(deactivations) == 0 {
+		fmt.Println("no entities to archive")
+		return nil
+	}
+	if len(deactivations) > 0 {
+		fmt.Printf("active launch plan versions to deactivate: %v\n", len(deactivations))
+	}
+	if len(staleEntities) > 0 {
+		fmt.Printf("entities with only stale versions: %v\n", staleEntities)
+	}
+	if cfg.DryRun {
+		logger.Debugf(ctx, "skipping UpdateLaunchPlan and UpdateNamedEntity requests (DryRun)")
+		return nil
+	}
+
+	// The entities are only archived once their active versions are deactivated.
+	if len(deactivations) > 0 {
+		if !cfg.Force && !cmdUtil.AskForConfirmation(fmt.Sprintf("Deactivate %v launch plan versions?", len(deactivations)), reader) {
+			return nil
+		}
+		for _, v := range deactivations {
+			_, err := cmdCtx.AdminClient().UpdateLaunchPlan(ctx, &admin.LaunchPlanUpdateRequest{
+				Id: &core.Identifier{
+					ResourceType: core.ResourceType_LAUNCH_PLAN,
+					Project:      project,
+					Domain:       domain,
+					Name:         v.Name,
+					Version:      v.Version,
+				},
+				State: admin.LaunchPlanState_INACTIVE,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to deactivate %v version %v due to %w", v.Name, v.Version, err)
+			}
+			fmt.Printf("deactivated %v %v version %v\n", resourceType, v.Name, v.Version)
+		}
+	}
+	if len(staleEntities) == 0 {
+		return nil
+	}
+	if !cfg.Force && !cmdUtil.AskForConfirmation(fmt.Sprintf("Archive %v %v entities?", len(staleEntities), resourceType), reader) {
+		return nil
+	}
+	archiveConfig := update.NamedEntityConfig{Archive: true}
+	for _, name := range staleEntities {
+		if err := archiveConfig.UpdateNamedEntity(ctx, name, project, domain, resourceType, cmdCtx); err != nil {
+			return fmt.Errorf("failed to archive %v due to %w", name, err)
+		}
+		fmt.Printf("archived %v %v\n", resourceType, name)
+	}
+	return nil
+}
+
+// staleVersions marks the stale versions of an entity and reports whether all its versions are stale. The active
+// versions which are otherwise stale are returned to be deactivated.
+func staleVersions(versions []entityVersion, inUse map[string]string, active map[string]bool, cfg *prune.Config,
+	now time.Time) ([]Result, []entityVersion, bool) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+	cutoff := now.AddDate(0, 0, -cfg.OlderThanDays)
+	allStale := len(versions) > 0
+	var results []Result
+	var deactivations []entityVersion
+	for i, v := range versions {
+		if (cfg.Keep > 0 && i < cfg.Keep) || (cfg.OlderThanDays > 0 && v.CreatedAt.After(cutoff)) {
+			allStale = false
+			continue
+		}
+		status := "Stale"
+		if reason, ok := inUse[versionKey(v.Name, v.Version)]; ok {
+			status = fmt.Sprintf("Skipped (%v)", reason)
+			allStale = false
+		} else if active[versionKey(v.Name, v.Version)] {
+			status = "Deactivate"
+			deactivations = append(deactivations, v)
+		}
+		results = append(results, Result{Name: v.Name, Version: v.Version, CreatedAt: v.CreatedAt.Format(time.RFC3339), Status: status})
+	}
+	return results, deactivations, allStale
+}
+
+func fetchEntityNames(ctx context.Context, cmdCtx cmdCore.CommandContext, resourceType core.ResourceType, project, domain string,
+	limit int32) ([]string, error) {
+	request, err := filters.BuildNamedEntityListRequest(filters.Filters{Limit: limit}, project, domain, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		entities, err := cmdCtx.AdminClient().ListNamedEntities(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities.Entities {
+			names = append(names, entity.Id.Name)
+		}
+		if len(entities.Token) == 0 {
+			return names, nil
+		}
+		request.Token = entities.Token
+	}
+}
+
+// fetchEntityVersions pages through every version of the named entity, as pruning decisions such as keep or archiving
+// the whole entity are only sound when no version is left out.
+func fetchEntityVersions(ctx context.Context, cmdCtx cmdCore.CommandContext, resourceType core.ResourceType, name, project,
+	domain string, limit int32) ([]entityVersion, error) {
+	request, err := filters.BuildResourceListRequestWithName(filters.Filters{Limit: limit, SortBy: "created_at"}, project, domain, name)
+	if err != nil {
+		return nil, err
+	}
+	var versions []entityVersion
+	for {
+		var token string
+		switch resourceType {
+		case core.ResourceType_TASK:
+			tasks, err := cmdCtx.AdminClient().ListTasks(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range tasks.Tasks {
+				versions = append(versions, entityVersion{Name: name, Version: t.Id.Version, CreatedAt: asTime(t.Closure.GetCreatedAt())})
+			}
+			token = tasks.Token
+		case core.ResourceType_WORKFLOW:
+			workflows, err := cmdCtx.AdminClient().ListWorkflows(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			for _, w := range workflows.Workflows {
+				versions = append(versions, entityVersion{Name: name, Version: w.Id.Version, CreatedAt: asTime(w.Closure.GetCreatedAt())})
+			}
+			token = workflows.Token
+		case core.ResourceType_LAUNCH_PLAN:
+			launchPlans, err := cmdCtx.AdminClient().ListLaunchPlans(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			for _, lp := range launchPlans.LaunchPlans {
+				versions = append(versions, entityVersion{Name: name, Version: lp.Id.Version, CreatedAt: asTime(lp.Closure.GetCreatedAt())})
+			}
+			token = launchPlans.Token
+		default:
+			return nil, fmt.Errorf("unsupported resource type %v", resourceType)
+		}
+		if len(token) == 0 {
+			break
+		}
+		request.Token = token
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no versions retrieved for %v", name)
+	}
+	return versions, nil
+}
+
+// fetchInUseVersions returns the versions of the resource type which are referenced by active launch plans or running
+// executions, keyed by name and version, along with the reason. With the deactivate flag, the active launch plan
+// versions aren't in use by themselves and are returned apart to be deactivated.
+func fetchInUseVersions(ctx context.Context, cmdCtx cmdCore.CommandContext, resourceType core.ResourceType, project,
+	domain string, cfg *prune.Config) (map[string]string, map[string]bool, error) {
+	inUse := map[string]string{}
+	active := map[string]bool{}
+	mark := func(id *core.Identifier, reason string) {
+		if id == nil || id.ResourceType != resourceType || id.Project != project || id.Domain != domain {
+			return
+		}
+		if _, ok := inUse[versionKey(id.Name, id.Version)]; !ok {
+			inUse[versionKey(id.Name, id.Version)] = reason
+		}
+	}
+	// Workflows in use along with the reason, keyed by name and version.
+	workflows := map[string]*core.Identifier{}
+	workflowReasons := map[string]string{}
+	add := func(id *core.Identifier, reason string) {
+		mark(id, reason)
+		if id != nil && id.ResourceType == core.ResourceType_WORKFLOW && id.Project == project && id.Domain == domain {
+			if _, ok := workflows[versionKey(id.Name, id.Version)]; !ok {
+				workflows[versionKey(id.Name, id.Version)] = id
+				workflowReasons[versionKey(id.Name, id.Version)] = reason
+			}
+		}
+	}
+
+	// Every page is fetched, as a version missed here would be reported as stale.
+	request := &admin.ActiveLaunchPlanListRequest{
+		Project: project,
+		Domain:  domain,
+		Limit:   uint32(cfg.Limit),
+	}
+	for {
+		activeLPs, err := cmdCtx.AdminClient().ListActiveLaunchPlans(ctx, request)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, lp := range activeLPs.LaunchPlans {
+			id := &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Project: lp.Id.Project, Domain: lp.Id.Domain,
+				Name: lp.Id.Name, Version: lp.Id.Version}
+			if cfg.Deactivate {
+				active[versionKey(id.Name, id.Version)] = true
+			} else {
+				add(id, "active launch plan")
+			}
+			add(lp.Spec.GetWorkflowId(), "active launch plan")
+		}
+		if len(activeLPs.Token) == 0 {
+			break
+		}
+		request.Token = activeLPs.Token
+	}
+
+	executionFilter := filters.Filters{
+		Limit:         cfg.Limit,
+		Page:          1,
+		FieldSelector: runningExecutionsSelector,
+	}
+	for {
+		executions, err := cmdCtx.AdminFetcherExt().ListExecution(ctx, project, domain, executionFilter)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, execution := range executions.Executions {
+			add(execution.Spec.GetLaunchPlan(), "running execution")
+			add(execution.Closure.GetWorkflowId(), "running execution")
+		}
+		if len(executions.Token) == 0 || len(executions.Executions) == 0 {
+			break
+		}
+		executionFilter.Page++
+	}
+
+	if resourceType == core.ResourceType_LAUNCH_PLAN {
+		return inUse, active, nil
+	}
+	// Tasks and subworkflows are only referenced through the compiled closure of the workflows in use.
+	for key, id := range workflows {
+		reason := workflowReasons[key]
+		wf, err := cmdCtx.AdminFetcherExt().FetchWorkflowVersion(ctx, id.Name, id.Version, project, domain)
+		if err != nil {
+			return nil, nil, err
+		}
+		compiled := wf.GetClosure().GetCompiledWorkflow()
+		for _, task := range compiled.GetTasks() {
+			mark(task.GetTemplate().GetId(), reason)
+		}
+		for _, subWorkflow := range compiled.GetSubWorkflows() {
+			mark(subWorkflow.GetTemplate().GetId(), reason)
+		}
+	}
+	return inUse, active, nil
+}
+
+func versionKey(name, version string) string {
+	return fmt.Sprintf("%v:%v", name, version)
+}
+
+func asTime(t *timestamp.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
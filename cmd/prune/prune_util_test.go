@@ -0,0 +1,256 @@
+package prune
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytectl/cmd/config/subcommand/prune"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	projectValue = "dummyProject"
+	domainValue  = "dummyDomain"
+)
+
+var now = time.Now()
+
+func identifier(resourceType core.ResourceType, name, version string) *core.Identifier {
+	return &core.Identifier{ResourceType: resourceType, Project: projectValue, Domain: domainValue, Name: name, Version: version}
+}
+
+func task(name, version string, age time.Duration) *admin.Task {
+	return &admin.Task{
+		Id:      identifier(core.ResourceType_TASK, name, version),
+		Closure: &admin.TaskClosure{CreatedAt: timestamppb.New(now.Add(-age))},
+	}
+}
+
+func launchPlan(name, version string, age time.Duration) *admin.LaunchPlan {
+	return &admin.LaunchPlan{
+		Id:      identifier(core.ResourceType_LAUNCH_PLAN, name, version),
+		Closure: &admin.LaunchPlanClosure{CreatedAt: timestamppb.New(now.Add(-age))},
+	}
+}
+
+func named(name, token string) interface{} {
+	return mock.MatchedBy(func(request *admin.ResourceListRequest) bool {
+		return request.Id.Name == name && request.Token == token
+	})
+}
+
+func TestStaleVersions(t *testing.T) {
+	day := 24 * time.Hour
+	versions := []entityVersion{
+		{Name: "t", Version: "v1", CreatedAt: now.Add(-40 * day)},
+		{Name: "t", Version: "v3", CreatedAt: now.Add(-day)},
+		{Name: "t", Version: "v2", CreatedAt: now.Add(-35 * day)},
+	}
+	t.Run("older than days", func(t *testing.T) {
+		results, _, allStale := staleVersions(versions, map[string]string{}, nil, &prune.Config{OlderThanDays: 30}, now)
+		assert.False(t, allStale)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, "v2", results[0].Version)
+		assert.Equal(t, "v1", results[1].Version)
+	})
+	t.Run("keep", func(t *testing.T) {
+		results, _, allStale := staleVersions(versions, map[string]string{}, nil, &prune.Config{Keep: 2}, now)
+		assert.False(t, allStale)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "v1", results[0].Version)
+	})
+	t.Run("all stale", func(t *testing.T) {
+		_, _, allStale := staleVersions(versions, map[string]string{}, nil, &prune.Config{OlderThanDays: 0, Keep: 0}, now)
+		assert.True(t, allStale)
+	})
+	t.Run("in use", func(t *testing.T) {
+		results, _, allStale := staleVersions(versions, map[string]string{"t:v1": "running execution"}, nil, &prune.Config{OlderThanDays: 30}, now)
+		assert.False(t, allStale)
+		assert.Equal(t, "Skipped (running execution)", results[1].Status)
+	})
+	t.Run("deactivate", func(t *testing.T) {
+		results, deactivations, allStale := staleVersions(versions, map[string]string{}, map[string]bool{"t:v1": true},
+			&prune.Config{OlderThanDays: 30, Deactivate: true}, now)
+		assert.False(t, allStale)
+		assert.Equal(t, "Deactivate", results[1].Status)
+		assert.Equal(t, []entityVersion{versions[2]}, deactivations)
+	})
+}
+
+func TestPruneEntities(t *testing.T) {
+	day := 24 * time.Hour
+	t.Run("missing flags", func(t *testing.T) {
+		s := setup()
+		err := pruneEntities(s.Ctx, core.ResourceType_TASK, nil, s.CmdCtx, &prune.Config{}, strings.NewReader(""))
+		assert.EqualError(t, err, "either olderThanDays or keep should be specified")
+	})
+
+	t.Run("archive stale task", func(t *testing.T) {
+		s := setup()
+		s.MockAdminClient.OnListNamedEntitiesMatch(s.Ctx, mock.Anything).Return(&admin.NamedEntityList{
+			Entities: []*admin.NamedEntity{
+				{Id: &admin.NamedEntityIdentifier{Name: "old_task"}},
+				{Id: &admin.NamedEntityIdentifier{Name: "new_task"}},
+			},
+		}, nil)
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("old_task", "")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("old_task", "v1", 40*day), task("old_task", "v2", 31*day)}}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("new_task", "")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("new_task", "v1", 40*day), task("new_task", "v2", day)}}, nil)
+		s.MockAdminClient.OnUpdateNamedEntityMatch(s.Ctx, &admin.NamedEntityUpdateRequest{
+			ResourceType: core.ResourceType_TASK,
+			Id:           &admin.NamedEntityIdentifier{Project: projectValue, Domain: domainValue, Name: "old_task"},
+			Metadata:     &admin.NamedEntityMetadata{State: admin.NamedEntityState_NAMED_ENTITY_ARCHIVED},
+		}).Return(&admin.NamedEntityUpdateResponse{}, nil).Once()
+		err := pruneEntities(s.Ctx, core.ResourceType_TASK, nil, s.CmdCtx, &prune.Config{OlderThanDays: 30, Force: true},
+			strings.NewReader(""))
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertExpectations(t)
+	})
+	t.Run("entities and versions past the first page", func(t *testing.T) {
+		s := setup()
+		s.MockAdminClient.OnListNamedEntitiesMatch(s.Ctx, mock.MatchedBy(func(request *admin.NamedEntityListRequest) bool {
+			return request.Token == ""
+		})).Return(&admin.NamedEntityList{Entities: []*admin.NamedEntity{{Id: &admin.NamedEntityIdentifier{Name: "t"}}}, Token: "1"}, nil)
+		s.MockAdminClient.OnListNamedEntitiesMatch(s.Ctx, mock.MatchedBy(func(request *admin.NamedEntityListRequest) bool {
+			return request.Token == "1"
+		})).Return(&admin.NamedEntityList{Entities: []*admin.NamedEntity{{Id: &admin.NamedEntityIdentifier{Name: "u"}}}}, nil)
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("t", "")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("t", "v1", 40*day)}, Token: "1"}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("t", "1")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("t", "v2", day)}}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("u", "")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("u", "v1", 40*day)}}, nil)
+		s.MockAdminClient.OnUpdateNamedEntityMatch(s.Ctx, &admin.NamedEntityUpdateRequest{
+			ResourceType: core.ResourceType_TASK,
+			Id:           &admin.NamedEntityIdentifier{Project: projectValue, Domain: domainValue, Name: "u"},
+			Metadata:     &admin.NamedEntityMetadata{State: admin.NamedEntityState_NAMED_ENTITY_ARCHIVED},
+		}).Return(&admin.NamedEntityUpdateResponse{}, nil).Once()
+		err := pruneEntities(s.Ctx, core.ResourceType_TASK, nil, s.CmdCtx, &prune.Config{OlderThanDays: 30, Limit: 1, Force: true},
+			strings.NewReader(""))
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertExpectations(t)
+		s.MockAdminClient.AssertNumberOfCalls(t, "UpdateNamedEntity", 1)
+	})
+	t.Run("dry run and declined confirmation", func(t *testing.T) {
+		for _, cfg := range []*prune.Config{{Keep: 1, DryRun: true}, {Keep: 1}} {
+			s := setup()
+			s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{}, nil)
+			s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+			s.MockAdminClient.OnListTasksMatch(s.Ctx, named("t", "")).Return(
+				&admin.TaskList{Tasks: []*admin.Task{task("t", "v1", 40*day)}}, nil)
+			err := pruneEntities(s.Ctx, core.ResourceType_TASK, []string{"t"}, s.CmdCtx, cfg, strings.NewReader("n\n"))
+			assert.Nil(t, err)
+			s.MockAdminClient.AssertNotCalled(t, "ListNamedEntities", mock.Anything, mock.Anything)
+			s.MockAdminClient.AssertNotCalled(t, "UpdateNamedEntity", mock.Anything, mock.Anything)
+		}
+	})
+	t.Run("skip versions in use", func(t *testing.T) {
+		s := setup()
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{
+			LaunchPlans: []*admin.LaunchPlan{{
+				Id:   identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v1"),
+				Spec: &admin.LaunchPlanSpec{WorkflowId: identifier(core.ResourceType_WORKFLOW, "wf", "v1")},
+			}},
+		}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "wf", "v1", projectValue, domainValue).Return(&admin.Workflow{
+			Closure: &admin.WorkflowClosure{CompiledWorkflow: &core.CompiledWorkflowClosure{
+				Tasks: []*core.CompiledTask{{Template: &core.TaskTemplate{Id: identifier(core.ResourceType_TASK, "t", "v1")}}},
+			}},
+		}, nil)
+		s.MockAdminClient.OnListTasksMatch(s.Ctx, named("t", "")).Return(
+			&admin.TaskList{Tasks: []*admin.Task{task("t", "v1", 40*day), task("t", "v2", 40*day)}}, nil)
+		err := pruneEntities(s.Ctx, core.ResourceType_TASK, []string{"t"}, s.CmdCtx, &prune.Config{OlderThanDays: 30, Force: true},
+			strings.NewReader(""))
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateNamedEntity", mock.Anything, mock.Anything)
+	})
+	t.Run("skip versions in use past the first page", func(t *testing.T) {
+		s := setup()
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.MatchedBy(func(request *admin.ActiveLaunchPlanListRequest) bool {
+			return request.Token == ""
+		})).Return(&admin.LaunchPlanList{
+			LaunchPlans: []*admin.LaunchPlan{{Id: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v1"), Spec: &admin.LaunchPlanSpec{}}},
+			Token:       "1",
+		}, nil)
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.MatchedBy(func(request *admin.ActiveLaunchPlanListRequest) bool {
+			return request.Token == "1"
+		})).Return(&admin.LaunchPlanList{
+			LaunchPlans: []*admin.LaunchPlan{{Id: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v2"), Spec: &admin.LaunchPlanSpec{}}},
+		}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.MatchedBy(func(filter filters.Filters) bool {
+			return filter.Page == 1
+		})).Return(&admin.ExecutionList{
+			Executions: []*admin.Execution{{Spec: &admin.ExecutionSpec{LaunchPlan: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v3")}}},
+			Token:      "1",
+		}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.MatchedBy(func(filter filters.Filters) bool {
+			return filter.Page == 2
+		})).Return(&admin.ExecutionList{
+			Executions: []*admin.Execution{{Spec: &admin.ExecutionSpec{LaunchPlan: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v4")}}},
+		}, nil)
+		s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, named("lp", "")).Return(
+			&admin.LaunchPlanList{LaunchPlans: []*admin.LaunchPlan{launchPlan("lp", "v1", 40*day), launchPlan("lp", "v2", 40*day), launchPlan("lp", "v3", 40*day),
+				launchPlan("lp", "v4", 40*day)}}, nil)
+		err := pruneEntities(s.Ctx, core.ResourceType_LAUNCH_PLAN, []string{"lp"}, s.CmdCtx, &prune.Config{OlderThanDays: 30, Limit: 1, Force: true},
+			strings.NewReader(""))
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateNamedEntity", mock.Anything, mock.Anything)
+	})
+	t.Run("deactivate stale active launch plans", func(t *testing.T) {
+		for _, cfg := range []*prune.Config{{OlderThanDays: 30, Deactivate: true, Force: true}, {OlderThanDays: 30, Deactivate: true, DryRun: true}} {
+			s := setup()
+			s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{
+				LaunchPlans: []*admin.LaunchPlan{{Id: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v2"), Spec: &admin.LaunchPlanSpec{}}},
+			}, nil)
+			s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+			s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, named("lp", "")).Return(
+				&admin.LaunchPlanList{LaunchPlans: []*admin.LaunchPlan{launchPlan("lp", "v1", 40*day), launchPlan("lp", "v2", 35*day)}}, nil)
+			s.MockAdminClient.OnUpdateLaunchPlanMatch(s.Ctx, &admin.LaunchPlanUpdateRequest{
+				Id:    identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v2"),
+				State: admin.LaunchPlanState_INACTIVE,
+			}).Return(&admin.LaunchPlanUpdateResponse{}, nil)
+			s.MockAdminClient.OnUpdateNamedEntityMatch(s.Ctx, mock.Anything).Return(&admin.NamedEntityUpdateResponse{}, nil)
+			err := pruneEntities(s.Ctx, core.ResourceType_LAUNCH_PLAN, []string{"lp"}, s.CmdCtx, cfg, strings.NewReader(""))
+			assert.Nil(t, err)
+			if cfg.DryRun {
+				s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+				s.MockAdminClient.AssertNotCalled(t, "UpdateNamedEntity", mock.Anything, mock.Anything)
+			} else {
+				s.MockAdminClient.AssertNumberOfCalls(t, "UpdateLaunchPlan", 1)
+				s.MockAdminClient.AssertNumberOfCalls(t, "UpdateNamedEntity", 1)
+			}
+		}
+	})
+	t.Run("declined deactivation", func(t *testing.T) {
+		s := setup()
+		s.MockAdminClient.OnListActiveLaunchPlansMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanList{
+			LaunchPlans: []*admin.LaunchPlan{{Id: identifier(core.ResourceType_LAUNCH_PLAN, "lp", "v1"), Spec: &admin.LaunchPlanSpec{}}},
+		}, nil)
+		s.FetcherExt.OnListExecutionMatch(s.Ctx, projectValue, domainValue, mock.Anything).Return(&admin.ExecutionList{}, nil)
+		s.MockAdminClient.OnListLaunchPlansMatch(s.Ctx, named("lp", "")).Return(
+			&admin.LaunchPlanList{LaunchPlans: []*admin.LaunchPlan{launchPlan("lp", "v1", 40*day)}}, nil)
+		err := pruneEntities(s.Ctx, core.ResourceType_LAUNCH_PLAN, []string{"lp"}, s.CmdCtx,
+			&prune.Config{OlderThanDays: 30, Deactivate: true}, strings.NewReader("n\n"))
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateNamedEntity", mock.Anything, mock.Anything)
+	})
+	t.Run("deactivate other resource types", func(t *testing.T) {
+		s := setup()
+		err := pruneEntities(s.Ctx, core.ResourceType_TASK, []string{"t"}, s.CmdCtx, &prune.Config{Keep: 1, Deactivate: true},
+			strings.NewReader(""))
+		assert.EqualError(t, err, "deactivate can only be used to prune launch plans")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/flyteorg/flytectl/cmd/config"
@@ -12,6 +13,7 @@ import (
 
 	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/oci"
 	"github.com/flyteorg/flytectl/pkg/printer"
 	"github.com/flyteorg/flytestdlib/logger"
 )
@@ -33,12 +35,27 @@ When the user runs pyflyte with --fast flag, then pyflyte creates serialized pro
 Flytectl finds the input file by searching for an archive file whose name starts with "fast" and has .tar.gz extension.
 If Flytectl finds any source code in users' input, it considers the registration as fast registration.
 
+The source code is uploaded through the data proxy of Flyte admin, or with your storage config for the admins without one.
 SourceUploadPath is an optional flag. By default, Flytectl will create SourceUploadPath from your storage config.
-If s3, Flytectl will upload the code base to s3://{{DEFINE_BUCKET_IN_STORAGE_CONFIG}}/fast/{{VERSION}}-fast{{MD5_CREATED_BY_PYFLYTE}.tar.gz}.
+If s3, Flytectl will upload the code base to s3://{{DEFINE_BUCKET_IN_STORAGE_CONFIG}}/fast/{{MD5_OF_THE_SOURCE_CODE}}-fast{{MD5_CREATED_BY_PYFLYTE}.tar.gz}.
+The location only depends on the source code, so an unchanged code base already uploaded there isn't uploaded again.
+Through the data proxy, the location is looked up with your storage config, and the code base is uploaded again if the storage config can't reach it.
 ::
 
  flytectl register file  _pb_output/* -d development  -p flytesnacks  --version v2
 
+Without a source code archive from pyflyte, for tasks serialized for fast registration by other tools, the fast flag archives the source dir, the current dir by default, and uploads it the same way.
+The archive leaves out the files ignored by the .gitignore and .flyteignore files of the dir, and its digest only depends on the paths, the contents and the executable bits of the files.
+Source dirs above maxSourceSizeMB (100 by default) fail the registration with a report of their largest files:
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks  --version v2 --fast --source ./src
+
+Pick the uploader with the uploader flag: dataProxy, storage (s3, gcs, azure or minio with your storage config) or local. The local uploader copies the code base to ~/.flyte/fast, or to the localUploadPath dir, for a sandbox mounting the dir at the same path:
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks  --version v2 --uploader local --localUploadPath /tmp/fast
+
 In case of fast registration, if the SourceUploadPath flag is defined, Flytectl will not use the default directory to upload the source code.
 Instead, it will override the destination path on the registration.
 ::
@@ -57,6 +74,12 @@ Using local tgz file:
 
  flytectl register files  _pb_output.tgz -d development  -p flytesnacks --archive
 
+Packages published as OCI artifacts are pulled from their registry, with the credentials of the Docker config. The tar layers of the artifact, gzipped or not, are registered, and the digest of the artifact is printed with the results:
+
+::
+
+ flytectl register files oci://ghcr.io/flyteorg/flytesnacks-package:v1 -d development  -p flytesnacks
+
 If you wish to continue executing registration on other files by ignoring the errors including the version conflicts, then send the continueOnError flag:
 
 ::
@@ -73,6 +96,21 @@ Override the default version v1 using version string:
 
  flytectl register file  _pb_output/* -d development  -p flytesnacks --version v2
 
+Derive the version from the short SHA of the git commit of the current directory, suffixed with -dirty when it has uncommitted changes:
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks --versionFrom git
+
+Version each entity with the content hash of its spec instead. The hash covers the versions of the entities it references, so only the changed entities and the ones using them get new versions, and registering an unchanged package again is a no-op:
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks --versionFrom hash
+
+Or compose the version with a Go template of the fields GitSHA, Date (YYYYMMDD), Hash and Version (the value of --version):
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks --versionTemplate "{{.GitSHA}}-{{.Date}}"
+
 Changing the o/p format has no effect on the registration. The O/p is currently available only in table format:
 
 ::
@@ -103,11 +141,17 @@ Override Destination dir of source code in container during registration:
 
  flytectl register file  _pb_output/* -d development  -p flytesnacks --continueOnError --version v2 --destinationDirectory "/root" 
 
-Enable schedule for the launchplans part of the serialized protobuf files:
+Enable schedule for the launchplans part of the serialized protobuf files. The launch plans which already exist are activated too:
 
 ::
 
  flytectl register file  _pb_output/* -d development  -p flytesnacks --version v2 --enableSchedule
+
+Record the registration in a JSON manifest listing the identifiers, versions and spec digests of the registered entities, the launch plans activated, the upload location of the source code and the digests of the OCI artifacts.
+The manifest of a previous registration rolls back its launch plans with flytectl register rollback:
+::
+
+ flytectl register file  _pb_output/* -d development  -p flytesnacks --version v2 --enableSchedule --manifest v2.json
 	
 Usage
 `
@@ -125,8 +169,13 @@ func Register(ctx context.Context, args []string, cfg *config.Config, cmdCtx cmd
 	// Deprecated checks for --k8Service
 	deprecatedCheck(ctx, &rconfig.DefaultFilesConfig.K8sServiceAccount, rconfig.DefaultFilesConfig.K8ServiceAccount)
 
+	versions, err := newVersioner(*rconfig.DefaultFilesConfig)
+	if err != nil {
+		return err
+	}
+
 	// getSerializeOutputFiles will return you all proto and  source code compress file in sorted order
-	dataRefs, tmpDir, err := GetSerializeOutputFiles(ctx, args, rconfig.DefaultFilesConfig.Archive)
+	dataRefs, tmpDir, artifacts, err := getSerializeOutputFiles(ctx, args, rconfig.DefaultFilesConfig.Archive)
 	if err != nil {
 		logger.Errorf(ctx, "error while un-archiving files in tmp dir due to %v", err)
 		return err
@@ -141,12 +190,33 @@ func Register(ctx context.Context, args []string, cfg *config.Config, cmdCtx cmd
 		return fmt.Errorf("input package have some invalid files. try to run pyflyte package again %v", InvalidFiles)
 	}
 
+	// In case of --fast, the source code archive is built from the source dir instead of coming with the input files
+	if rconfig.DefaultFilesConfig.Fast {
+		if len(sourceCodePath) > 0 {
+			return fmt.Errorf("input files already have the source code archive %v. Drop the fast flag to register them", sourceCodePath)
+		}
+		source := rconfig.DefaultFilesConfig.Source
+		if len(source) == 0 {
+			source = "."
+		}
+		sourceDir, err := ioutil.TempDir("", "fast-register")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(sourceDir)
+		if sourceCodePath, err = buildSourceArchive(ctx, source, sourceDir, rconfig.DefaultFilesConfig.MaxSourceSizeMB); err != nil {
+			return err
+		}
+	} else if len(rconfig.DefaultFilesConfig.Source) > 0 {
+		return fmt.Errorf("source can only be used with the fast flag")
+	}
+
 	// In case of fast serialize input upload source code to destination bucket
 	var uploadLocation storage.DataReference
 	if len(sourceCodePath) > 0 {
 		logger.Infof(ctx, "Fast Registration detected")
-		uploadLocation, err = uploadFastRegisterArtifact(ctx, cfg.Project, cfg.Domain, sourceCodePath, rconfig.DefaultFilesConfig.Version,
-			cmdCtx.ClientSet().DataProxyClient(), rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath)
+		uploadLocation, err = uploadFastRegisterArtifact(ctx, cfg.Project, cfg.Domain, sourceCodePath,
+			cmdCtx.ClientSet().DataProxyClient(), *rconfig.DefaultFilesConfig)
 		if err != nil {
 			return fmt.Errorf("failed to upload source code from [%v]. Error: %w", sourceCodePath, err)
 		}
@@ -155,9 +225,28 @@ func Register(ctx context.Context, args []string, cfg *config.Config, cmdCtx cmd
 	}
 
 	var registerResults []Result
+	// The digests of the pulled artifacts trace the registered entities back to the exact package.
+	for _, artifact := range artifacts {
+		registerResults = append(registerResults, Result{Name: oci.Scheme + artifact.Reference.String(), Status: "Success",
+			Info: fmt.Sprintf("Pulled artifact with digest %v", artifact.Digest)})
+	}
+	var manifest *Manifest
+	// A dry run registers nothing, and its manifest would make a rollback activate launch plans which don't exist.
+	if len(rconfig.DefaultFilesConfig.Manifest) > 0 && rconfig.DefaultFilesConfig.DryRun {
+		logger.Infof(ctx, "Not writing the manifest of the registration to %v (DryRun)", rconfig.DefaultFilesConfig.Manifest)
+	} else if len(rconfig.DefaultFilesConfig.Manifest) > 0 {
+		manifest = newManifest(cfg.Project, cfg.Domain, artifacts, uploadLocation)
+	}
 	fastFail := !rconfig.DefaultFilesConfig.ContinueOnError
 	for i := 0; i < len(validProto) && !(fastFail && regErr != nil); i++ {
-		registerResults, regErr = registerFile(ctx, validProto[i], registerResults, cmdCtx, uploadLocation, *rconfig.DefaultFilesConfig)
+		registerResults, regErr = registerFile(ctx, validProto[i], registerResults, cmdCtx, uploadLocation, *rconfig.DefaultFilesConfig, versions, manifest)
+	}
+	// The manifest records the entities registered before a failure too, for them to be rolled back.
+	if manifest != nil {
+		if err := manifest.write(rconfig.DefaultFilesConfig.Manifest); err != nil {
+			return fmt.Errorf("failed to write manifest %v. Error: %w", rconfig.DefaultFilesConfig.Manifest, err)
+		}
+		logger.Infof(ctx, "Wrote the manifest of the registration to %v", rconfig.DefaultFilesConfig.Manifest)
 	}
 
 	payload, _ := json.Marshal(registerResults)
@@ -2,9 +2,13 @@ package register
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/flyteorg/flyteidl/clients/go/admin/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 
 	"github.com/flyteorg/flytectl/cmd/config"
@@ -193,4 +197,124 @@ func TestRegisterFromFiles(t *testing.T) {
 		err = registerFromFilesFunc(s.Ctx, args, s.CmdCtx)
 		assert.Nil(t, err)
 	})
+	t.Run("Fast registration of a source dir", func(t *testing.T) {
+		s := setup()
+		registerFilesSetup()
+		testScope := promutils.NewTestScope()
+		labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
+		store, err := storage.NewDataStore(&storage.Config{
+			Type: storage.TypeMemory,
+		}, testScope.NewSubScope("flytectl"))
+		Client = store
+		assert.Nil(t, err)
+		// The protos of the package, without the source code archive pyflyte made
+		files, tmpDir, err := GetSerializeOutputFiles(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, true)
+		assert.Nil(t, err)
+		defer os.RemoveAll(tmpDir)
+		_, protos, _ := segregateSourceAndProtos(files)
+		source := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(source, "workflow.py"), []byte("workflow"), 0600))
+		rconfig.DefaultFilesConfig.Archive = false
+		rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath = s3Output
+		rconfig.DefaultFilesConfig.Fast = true
+		rconfig.DefaultFilesConfig.Source = source
+		defer func() {
+			rconfig.DefaultFilesConfig.Fast = false
+			rconfig.DefaultFilesConfig.Source = ""
+		}()
+
+		var args []string
+		s.MockAdminClient.OnCreateTaskMatch(mock.Anything, mock.Anything).Run(func(call mock.Arguments) {
+			args = append(args, call.Get(1).(*admin.TaskCreateRequest).Spec.Template.GetContainer().GetArgs()...)
+		}).Return(nil, nil)
+		s.MockAdminClient.OnCreateWorkflowMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockAdminClient.OnCreateLaunchPlanMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockClient.DataProxyClient().(*mocks.DataProxyServiceClient).OnCreateUploadLocationMatch(mock.Anything, mock.Anything).Return(&service.CreateUploadLocationResponse{}, nil)
+		err = registerFromFilesFunc(s.Ctx, protos, s.CmdCtx)
+		assert.Nil(t, err)
+		var uploadLocation string
+		for _, arg := range args {
+			if strings.HasPrefix(arg, s3Output+"/") {
+				uploadLocation = arg
+			}
+		}
+		assert.True(t, strings.HasSuffix(uploadLocation, sourceCodeExtension), args)
+		assert.NotContains(t, args, registrationRemotePackagePattern)
+
+		// A package with its own source code archive can't be fast registered from a source dir.
+		rconfig.DefaultFilesConfig.Archive = true
+		err = registerFromFilesFunc(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, s.CmdCtx)
+		assert.NotNil(t, err)
+		rconfig.DefaultFilesConfig.Fast = false
+		err = registerFromFilesFunc(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, s.CmdCtx)
+		assert.NotNil(t, err)
+	})
+	t.Run("Registration with a manifest", func(t *testing.T) {
+		s := setup()
+		registerFilesSetup()
+		testScope := promutils.NewTestScope()
+		labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
+		store, err := storage.NewDataStore(&storage.Config{
+			Type: storage.TypeMemory,
+		}, testScope.NewSubScope("flytectl"))
+		Client = store
+		assert.Nil(t, err)
+		rconfig.DefaultFilesConfig.Archive = true
+		rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath = s3Output
+		rconfig.DefaultFilesConfig.EnableSchedule = true
+		rconfig.DefaultFilesConfig.Version = "v1"
+		rconfig.DefaultFilesConfig.Manifest = filepath.Join(t.TempDir(), "manifest.json")
+		defer func() {
+			rconfig.DefaultFilesConfig.EnableSchedule = false
+			rconfig.DefaultFilesConfig.Version = ""
+			rconfig.DefaultFilesConfig.Manifest = ""
+		}()
+		s.MockAdminClient.OnCreateTaskMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockAdminClient.OnCreateWorkflowMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockAdminClient.OnCreateLaunchPlanMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(mock.Anything, mock.Anything).Return(nil, nil)
+		s.MockClient.DataProxyClient().(*mocks.DataProxyServiceClient).OnCreateUploadLocationMatch(mock.Anything, mock.Anything).Return(&service.CreateUploadLocationResponse{}, nil)
+		err = registerFromFilesFunc(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, s.CmdCtx)
+		assert.Nil(t, err)
+
+		manifest, err := readManifest(rconfig.DefaultFilesConfig.Manifest)
+		assert.Nil(t, err)
+		assert.Equal(t, config.GetConfig().Project, manifest.Project)
+		assert.True(t, strings.HasPrefix(manifest.UploadLocation, s3Output+"/"), manifest.UploadLocation)
+		assert.NotEmpty(t, manifest.Entities)
+		launchPlans := manifest.activatedLaunchPlans()
+		assert.NotEmpty(t, launchPlans)
+		for _, entity := range manifest.Entities {
+			assert.Equal(t, "v1", entity.Version)
+			assert.True(t, strings.HasPrefix(entity.Digest, "sha256:"), entity.Digest)
+		}
+	})
+	t.Run("Dry run with a manifest", func(t *testing.T) {
+		s := setup()
+		registerFilesSetup()
+		store, err := storage.NewDataStore(&storage.Config{
+			Type: storage.TypeMemory,
+		}, promutils.NewTestScope().NewSubScope("flytectl"))
+		assert.Nil(t, err)
+		Client = store
+		rconfig.DefaultFilesConfig.Archive = true
+		rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath = s3Output
+		rconfig.DefaultFilesConfig.DryRun = true
+		rconfig.DefaultFilesConfig.EnableSchedule = true
+		rconfig.DefaultFilesConfig.Version = "v1"
+		rconfig.DefaultFilesConfig.Manifest = filepath.Join(t.TempDir(), "manifest.json")
+		defer func() {
+			rconfig.DefaultFilesConfig.DryRun = false
+			rconfig.DefaultFilesConfig.EnableSchedule = false
+			rconfig.DefaultFilesConfig.Version = ""
+			rconfig.DefaultFilesConfig.Manifest = ""
+		}()
+		s.MockClient.DataProxyClient().(*mocks.DataProxyServiceClient).OnCreateUploadLocationMatch(mock.Anything, mock.Anything).Return(&service.CreateUploadLocationResponse{}, nil)
+		err = registerFromFilesFunc(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, s.CmdCtx)
+		assert.Nil(t, err)
+		_, err = os.Stat(rconfig.DefaultFilesConfig.Manifest)
+		assert.True(t, os.IsNotExist(err))
+		s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+	})
 }
@@ -0,0 +1,118 @@
+package register
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/flyteorg/flytectl/pkg/oci"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+)
+
+// Manifest records a registration, for it to be inspected or rolled back to later
+type Manifest struct {
+	Project        string             `json:"project"`
+	Domain         string             `json:"domain"`
+	RegisteredAt   time.Time          `json:"registeredAt"`
+	Artifacts      []ManifestArtifact `json:"artifacts,omitempty"`
+	UploadLocation string             `json:"uploadLocation,omitempty"`
+	Entities       []ManifestEntity   `json:"entities"`
+}
+
+// ManifestArtifact is an OCI artifact the registered entities were pulled from
+type ManifestArtifact struct {
+	Reference string `json:"reference"`
+	Digest    string `json:"digest"`
+}
+
+// ManifestEntity is a registered entity, with the sha256 digest of its spec. Activated is set for the launch plans the
+// registration activated.
+type ManifestEntity struct {
+	ResourceType string `json:"resourceType"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Digest       string `json:"digest"`
+	Activated    bool   `json:"activated,omitempty"`
+}
+
+func newManifest(project, domain string, artifacts []oci.Artifact, uploadLocation storage.DataReference) *Manifest {
+	manifest := &Manifest{
+		Project:        project,
+		Domain:         domain,
+		RegisteredAt:   time.Now().UTC(),
+		UploadLocation: uploadLocation.String(),
+		Entities:       []ManifestEntity{},
+	}
+	for _, artifact := range artifacts {
+		manifest.Artifacts = append(manifest.Artifacts, ManifestArtifact{
+			Reference: oci.Scheme + artifact.Reference.String(),
+			Digest:    artifact.Digest,
+		})
+	}
+	return manifest
+}
+
+// addEntity records the registered spec
+func (m *Manifest) addEntity(message proto.Message, enableSchedule bool) error {
+	id, err := entityIdentifier(message)
+	if err != nil {
+		return err
+	}
+	digest, err := specDigest(message)
+	if err != nil {
+		return err
+	}
+	resourceType := core.ResourceType_TASK
+	switch message.(type) {
+	case *admin.LaunchPlan:
+		resourceType = core.ResourceType_LAUNCH_PLAN
+	case *admin.WorkflowSpec:
+		resourceType = core.ResourceType_WORKFLOW
+	}
+	m.Entities = append(m.Entities, ManifestEntity{
+		ResourceType: resourceType.String(),
+		Name:         id.Name,
+		Version:      id.Version,
+		Digest:       "sha256:" + digest,
+		Activated:    resourceType == core.ResourceType_LAUNCH_PLAN && enableSchedule,
+	})
+	return nil
+}
+
+// activatedLaunchPlans returns the versions of the launch plans the registration activated, by name
+func (m *Manifest) activatedLaunchPlans() map[string]string {
+	launchPlans := map[string]string{}
+	for _, entity := range m.Entities {
+		if entity.ResourceType == core.ResourceType_LAUNCH_PLAN.String() && entity.Activated {
+			launchPlans[entity.Name] = entity.Version
+		}
+	}
+	return launchPlans
+}
+
+func (m *Manifest) write(path string) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(raw, '\n'), 0600)
+}
+
+func readManifest(path string) (*Manifest, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %v. Error: %w", path, err)
+	}
+	if len(manifest.Project) == 0 || len(manifest.Domain) == 0 {
+		return nil, fmt.Errorf("manifest %v has no project or domain", path)
+	}
+	return &manifest, nil
+}
@@ -30,6 +30,8 @@ func RemoteRegisterCommand() *cobra.Command {
 			Short: registerFilesShort, Long: registerFilesLong},
 		"examples": {CmdFunc: registerExamplesFunc, Aliases: []string{"example", "flytesnack", "flytesnacks"}, PFlagProvider: rconfig.DefaultFilesConfig,
 			Short: registerExampleShort, Long: registerExampleLong},
+		"rollback": {CmdFunc: rollbackFunc, PFlagProvider: rconfig.DefaultRollbackConfig, ProjectDomainNotRequired: true,
+			Short: registerRollbackShort, Long: registerRollbackLong},
 	}
 	cmdcore.AddCommands(registerCmd, registerResourcesFuncs)
 	return registerCmd
@@ -21,7 +21,7 @@ func TestRegisterCommand(t *testing.T) {
 	assert.Equal(t, registerCommand.Use, "register")
 	assert.Equal(t, registerCommand.Short, "Registers tasks, workflows, and launch plans from a list of generated serialized files.")
 	fmt.Println(registerCommand.Commands())
-	assert.Equal(t, len(registerCommand.Commands()), 2)
+	assert.Equal(t, len(registerCommand.Commands()), 3)
 	cmdNouns := registerCommand.Commands()
 	// Sort by Use value.
 	sort.Slice(cmdNouns, func(i, j int) bool {
@@ -35,4 +35,7 @@ func TestRegisterCommand(t *testing.T) {
 	assert.Equal(t, cmdNouns[1].Use, "files")
 	assert.Equal(t, cmdNouns[1].Aliases, []string{"file"})
 	assert.Equal(t, cmdNouns[1].Short, "Registers file resources.")
+
+	assert.Equal(t, cmdNouns[2].Use, "rollback")
+	assert.Equal(t, cmdNouns[2].Short, "Rolls back to the launch plans of a previous registration.")
 }
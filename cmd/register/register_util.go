@@ -2,9 +2,10 @@ package register
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
-	"crypto/md5" //#nosec
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -23,6 +24,8 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 
 	g "github.com/flyteorg/flytectl/pkg/github"
+	"github.com/flyteorg/flytectl/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -61,6 +64,9 @@ var supportedExtensions = []string{".tar", ".tgz", ".tar.gz"}
 // All supported extensions for gzip compress
 var validGzipExtensions = []string{".tgz", ".tar.gz"}
 
+// gzipMagic starts gzip streams, for the gzipped layers of OCI artifacts to be detected whatever their media type
+var gzipMagic = []byte{0x1f, 0x8b}
+
 type SignedURLPatternMatcher = *regexp.Regexp
 
 var (
@@ -144,12 +150,12 @@ func register(ctx context.Context, message proto.Message, cmdCtx cmdCore.Command
 				},
 				Spec: launchPlan.Spec,
 			})
-		if err != nil {
+		if err != nil && status.Code(err) != codes.AlreadyExists {
 			return err
 		}
-		// Activate the launchplan
+		// Activate the launchplan, even if it already exists, for the registration to leave it active
 		if enableSchedule {
-			_, err = cmdCtx.AdminClient().UpdateLaunchPlan(ctx, &admin.LaunchPlanUpdateRequest{
+			_, activateErr := cmdCtx.AdminClient().UpdateLaunchPlan(ctx, &admin.LaunchPlanUpdateRequest{
 				Id: &core.Identifier{
 					Project: config.GetConfig().Project,
 					Domain:  config.GetConfig().Domain,
@@ -158,9 +164,11 @@ func register(ctx context.Context, message proto.Message, cmdCtx cmdCore.Command
 				},
 				State: admin.LaunchPlanState_ACTIVE,
 			})
-			return err
+			if activateErr != nil {
+				return activateErr
+			}
 		}
-		return nil
+		return err
 	case *admin.WorkflowSpec:
 		workflowSpec := message.(*admin.WorkflowSpec)
 		if dryRun {
@@ -203,32 +211,39 @@ func register(ctx context.Context, message proto.Message, cmdCtx cmdCore.Command
 }
 
 func hydrateNode(node *core.Node, version string, force bool) error {
+	return walkNodeIdentifiers(node, func(identifier *core.Identifier) {
+		hydrateIdentifier(identifier, version, force)
+	})
+}
+
+// walkNodeIdentifiers calls the func with the identifiers of the entities the node references, in its branches too
+func walkNodeIdentifiers(node *core.Node, f func(identifier *core.Identifier)) error {
 	targetNode := node.Target
 	switch v := targetNode.(type) {
 	case *core.Node_TaskNode:
 		taskNodeWrapper := targetNode.(*core.Node_TaskNode)
 		taskNodeReference := taskNodeWrapper.TaskNode.Reference.(*core.TaskNode_ReferenceId)
-		hydrateIdentifier(taskNodeReference.ReferenceId, version, force)
+		f(taskNodeReference.ReferenceId)
 	case *core.Node_WorkflowNode:
 		workflowNodeWrapper := targetNode.(*core.Node_WorkflowNode)
 		switch workflowNodeWrapper.WorkflowNode.Reference.(type) {
 		case *core.WorkflowNode_SubWorkflowRef:
 			subWorkflowNodeReference := workflowNodeWrapper.WorkflowNode.Reference.(*core.WorkflowNode_SubWorkflowRef)
-			hydrateIdentifier(subWorkflowNodeReference.SubWorkflowRef, version, force)
+			f(subWorkflowNodeReference.SubWorkflowRef)
 		case *core.WorkflowNode_LaunchplanRef:
 			launchPlanNodeReference := workflowNodeWrapper.WorkflowNode.Reference.(*core.WorkflowNode_LaunchplanRef)
-			hydrateIdentifier(launchPlanNodeReference.LaunchplanRef, version, force)
+			f(launchPlanNodeReference.LaunchplanRef)
 		default:
 			return fmt.Errorf("unknown type %T", workflowNodeWrapper.WorkflowNode.Reference)
 		}
 	case *core.Node_BranchNode:
 		branchNodeWrapper := targetNode.(*core.Node_BranchNode)
-		if err := hydrateNode(branchNodeWrapper.BranchNode.IfElse.Case.ThenNode, version, force); err != nil {
+		if err := walkNodeIdentifiers(branchNodeWrapper.BranchNode.IfElse.Case.ThenNode, f); err != nil {
 			return fmt.Errorf("failed to hydrateNode")
 		}
 		if len(branchNodeWrapper.BranchNode.IfElse.Other) > 0 {
 			for _, ifBlock := range branchNodeWrapper.BranchNode.IfElse.Other {
-				if err := hydrateNode(ifBlock.ThenNode, version, force); err != nil {
+				if err := walkNodeIdentifiers(ifBlock.ThenNode, f); err != nil {
 					return fmt.Errorf("failed to hydrateNode")
 				}
 			}
@@ -236,7 +251,7 @@ func hydrateNode(node *core.Node, version string, force bool) error {
 		switch branchNodeWrapper.BranchNode.IfElse.Default.(type) {
 		case *core.IfElseBlock_ElseNode:
 			elseNodeReference := branchNodeWrapper.BranchNode.IfElse.Default.(*core.IfElseBlock_ElseNode)
-			if err := hydrateNode(elseNodeReference.ElseNode, version, force); err != nil {
+			if err := walkNodeIdentifiers(elseNodeReference.ElseNode, f); err != nil {
 				return fmt.Errorf("failed to hydrateNode")
 			}
 
@@ -433,38 +448,58 @@ func validateSpec(ctx context.Context, message proto.Message, cmdCtx cmdCore.Com
 }
 
 func hydrateSpec(message proto.Message, uploadLocation storage.DataReference, config rconfig.FilesConfig) error {
+	if err := hydrateIdentifiers(message, config.Version, config.Force); err != nil {
+		return err
+	}
 	switch v := message.(type) {
 	case *admin.LaunchPlan:
-		launchPlan := message.(*admin.LaunchPlan)
-		hydrateIdentifier(launchPlan.Id, config.Version, config.Force)
-		hydrateIdentifier(launchPlan.Spec.WorkflowId, config.Version, config.Force)
-		if err := hydrateLaunchPlanSpec(config.AssumableIamRole, config.K8sServiceAccount, config.OutputLocationPrefix, launchPlan.Spec); err != nil {
+		if err := hydrateLaunchPlanSpec(config.AssumableIamRole, config.K8sServiceAccount, config.OutputLocationPrefix, v.Spec); err != nil {
 			return err
 		}
+	case *admin.TaskSpec:
+		// In case of fast serialize input proto also have on additional variable to substitute i.e destination bucket for source code
+		if err := hydrateTaskSpec(v, uploadLocation, config.DestinationDirectory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HydrateIdentifiers sets the project and domain of the config and the version in the identifiers of the entity and of
+// the entities it references, which the package leaves to registration, without hydrating the rest of its spec.
+func HydrateIdentifiers(message proto.Message, version string) error {
+	return hydrateIdentifiers(message, version, false)
+}
+
+func hydrateIdentifiers(message proto.Message, version string, force bool) error {
+	return walkIdentifiers(message, func(identifier *core.Identifier) {
+		hydrateIdentifier(identifier, version, force)
+	})
+}
+
+// walkIdentifiers calls the func with the identifier of the entity and the identifiers of the entities it references
+func walkIdentifiers(message proto.Message, f func(identifier *core.Identifier)) error {
+	switch v := message.(type) {
+	case *admin.LaunchPlan:
+		f(v.Id)
+		f(v.Spec.WorkflowId)
 	case *admin.WorkflowSpec:
-		workflowSpec := message.(*admin.WorkflowSpec)
-		for _, Noderef := range workflowSpec.Template.Nodes {
-			if err := hydrateNode(Noderef, config.Version, config.Force); err != nil {
+		for _, node := range v.Template.Nodes {
+			if err := walkNodeIdentifiers(node, f); err != nil {
 				return err
 			}
 		}
-		hydrateIdentifier(workflowSpec.Template.Id, config.Version, config.Force)
-		for _, subWorkflow := range workflowSpec.SubWorkflows {
-			for _, Noderef := range subWorkflow.Nodes {
-				if err := hydrateNode(Noderef, config.Version, config.Force); err != nil {
+		f(v.Template.Id)
+		for _, subWorkflow := range v.SubWorkflows {
+			for _, node := range subWorkflow.Nodes {
+				if err := walkNodeIdentifiers(node, f); err != nil {
 					return err
 				}
 			}
-			hydrateIdentifier(subWorkflow.Id, config.Version, config.Force)
+			f(subWorkflow.Id)
 		}
 	case *admin.TaskSpec:
-		taskSpec := message.(*admin.TaskSpec)
-		hydrateIdentifier(taskSpec.Template.Id, config.Version, config.Force)
-		// In case of fast serialize input proto also have on additional variable to substitute i.e destination bucket for source code
-		if err := hydrateTaskSpec(taskSpec, uploadLocation, config.DestinationDirectory); err != nil {
-			return err
-		}
-
+		f(v.Template.Id)
 	default:
 		return fmt.Errorf("unknown type %T", v)
 	}
@@ -489,7 +524,14 @@ If the archive flag is on then download the archives to temp directory and extra
 The o/p of this function would be sorted list of the file locations.
 */
 func GetSerializeOutputFiles(ctx context.Context, args []string, archive bool) ([]string, string, error) {
-	if !archive {
+	fileList, tempDir, _, err := getSerializeOutputFiles(ctx, args, archive)
+	return fileList, tempDir, err
+}
+
+// getSerializeOutputFiles also returns the artifacts pulled from OCI registries. The oci:// references are archives
+// whether or not the archive flag is on.
+func getSerializeOutputFiles(ctx context.Context, args []string, archive bool) ([]string, string, []oci.Artifact, error) {
+	if !archive && !hasOCIReference(args) {
 		/*
 		 * Sorting is required for non-archived case since its possible for the user to pass in a list of unordered
 		 * serialized protobuf files , but flyte expects them to be registered in topologically sorted order that it had
@@ -500,33 +542,42 @@ func GetSerializeOutputFiles(ctx context.Context, args []string, archive bool) (
 		for _, arg := range args {
 			matches, err := filepath.Glob(arg)
 			if err != nil {
-				return nil, "", fmt.Errorf("failed to glob [%v]. Error: %w", arg, err)
+				return nil, "", nil, fmt.Errorf("failed to glob [%v]. Error: %w", arg, err)
 			}
 
 			finalList = append(finalList, matches...)
 		}
 
 		sort.Strings(finalList)
-		return finalList, "", nil
+		return finalList, "", nil, nil
 	}
 
 	tempDir, err := ioutil.TempDir("/tmp", "register")
 
 	if err != nil {
-		return nil, tempDir, err
+		return nil, tempDir, nil, err
 	}
 	var unarchivedFiles []string
+	var artifacts []oci.Artifact
 	for _, v := range args {
+		if oci.IsReference(v) {
+			var artifact oci.Artifact
+			if artifact, unarchivedFiles, err = pullArchive(ctx, v, tempDir, unarchivedFiles); err != nil {
+				return unarchivedFiles, tempDir, artifacts, err
+			}
+			artifacts = append(artifacts, artifact)
+			continue
+		}
 		dataRefReaderCloser, err := getArchiveReaderCloser(ctx, v)
 		if err != nil {
-			return unarchivedFiles, tempDir, err
+			return unarchivedFiles, tempDir, artifacts, err
 		}
 		archiveReader := tar.NewReader(dataRefReaderCloser)
 		if unarchivedFiles, err = readAndCopyArchive(archiveReader, tempDir, unarchivedFiles); err != nil {
-			return unarchivedFiles, tempDir, err
+			return unarchivedFiles, tempDir, artifacts, err
 		}
 		if err = dataRefReaderCloser.Close(); err != nil {
-			return unarchivedFiles, tempDir, err
+			return unarchivedFiles, tempDir, artifacts, err
 		}
 	}
 
@@ -535,7 +586,61 @@ func GetSerializeOutputFiles(ctx context.Context, args []string, archive bool) (
 	 * listing order of the serialized files which is required by flyte. Hence we explicitly sort here after unarchiving it.
 	 */
 	sort.Strings(unarchivedFiles)
-	return unarchivedFiles, tempDir, nil
+	return unarchivedFiles, tempDir, artifacts, nil
+}
+
+func hasOCIReference(args []string) bool {
+	for _, arg := range args {
+		if oci.IsReference(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullArchive pulls the artifact of the OCI registry and extracts its tar layers, which may be gzipped, to the temp dir
+func pullArchive(ctx context.Context, ref, tempDir string, unarchivedFiles []string) (oci.Artifact, []string, error) {
+	reference, err := oci.ParseReference(ref)
+	if err != nil {
+		return oci.Artifact{}, unarchivedFiles, err
+	}
+	archives := 0
+	artifact, err := oci.NewClient().Pull(ctx, reference, func(layer ocispec.Descriptor, content io.Reader) error {
+		if !isArchiveLayer(layer) {
+			logger.Debugf(ctx, "Skipping layer %v of media type %v of %v", layer.Digest, layer.MediaType, ref)
+			return nil
+		}
+		archives++
+		reader := bufio.NewReader(content)
+		var src io.Reader = reader
+		if magic, err := reader.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+			gzipReader, err := gzip.NewReader(reader)
+			if err != nil {
+				return err
+			}
+			defer gzipReader.Close()
+			src = gzipReader
+		}
+		unarchivedFiles, err = readAndCopyArchive(tar.NewReader(src), tempDir, unarchivedFiles)
+		return err
+	})
+	if err != nil {
+		return oci.Artifact{}, unarchivedFiles, fmt.Errorf("failed to pull %v. Error: %w", ref, err)
+	}
+	if archives == 0 {
+		return oci.Artifact{}, unarchivedFiles, fmt.Errorf("artifact %v has no tar layers", ref)
+	}
+	logger.Infof(ctx, "Pulled %v with digest %v", ref, artifact.Digest)
+	return artifact, unarchivedFiles, nil
+}
+
+// isArchiveLayer tells whether the layer is a tar archive, from its media type or the file name it was pushed from
+func isArchiveLayer(layer ocispec.Descriptor) bool {
+	if strings.Contains(layer.MediaType, "tar") {
+		return true
+	}
+	isValid, _ := checkSupportedExtensionForCompress(layer.Annotations[ocispec.AnnotationTitle])
+	return isValid
 }
 
 func readAndCopyArchive(src io.Reader, tempDir string, unarchivedFiles []string) ([]string, error) {
@@ -574,7 +679,7 @@ func readAndCopyArchive(src io.Reader, tempDir string, unarchivedFiles []string)
 }
 
 func registerFile(ctx context.Context, fileName string, registerResults []Result,
-	cmdCtx cmdCore.CommandContext, uploadLocation storage.DataReference, config rconfig.FilesConfig) ([]Result, error) {
+	cmdCtx cmdCore.CommandContext, uploadLocation storage.DataReference, config rconfig.FilesConfig, versions *versioner, manifest *Manifest) ([]Result, error) {
 
 	var registerResult Result
 	var fileContents []byte
@@ -591,7 +696,15 @@ func registerFile(ctx context.Context, fileName string, registerResults []Result
 		return registerResults, err
 	}
 
-	if err := hydrateSpec(spec, uploadLocation, config); err != nil {
+	if versions != nil {
+		var version string
+		if version, err = versions.hydrateSpec(spec, uploadLocation, config); err == nil {
+			logger.Infof(ctx, "Derived version %v of %v", version, fileName)
+		}
+	} else {
+		err = hydrateSpec(spec, uploadLocation, config)
+	}
+	if err != nil {
 		registerResult = Result{Name: fileName, Status: "Failed", Info: fmt.Sprintf("Error hydrating spec due to %v", err)}
 		registerResults = append(registerResults, registerResult)
 		return registerResults, err
@@ -607,7 +720,12 @@ func registerFile(ctx context.Context, fileName string, registerResults []Result
 		// If error is AlreadyExists then dont consider this to be an error but just a warning state
 		if grpcError := status.Code(err); grpcError == codes.AlreadyExists {
 			registerResult = Result{Name: fileName, Status: "Success", Info: fmt.Sprintf("%v", grpcError.String())}
-			err = nil
+			// The existing entity is part of the registration, and an existing launch plan is activated too.
+			if manifest != nil {
+				err = manifest.addEntity(spec, config.EnableSchedule)
+			} else {
+				err = nil
+			}
 		} else {
 			registerResult = Result{Name: fileName, Status: "Failed", Info: fmt.Sprintf("Error registering file due to %v", err)}
 		}
@@ -615,6 +733,11 @@ func registerFile(ctx context.Context, fileName string, registerResults []Result
 		return registerResults, err
 	}
 
+	if manifest != nil {
+		if err := manifest.addEntity(spec, config.EnableSchedule); err != nil {
+			return registerResults, err
+		}
+	}
 	registerResult = Result{Name: fileName, Status: "Success", Info: "Successfully registered file"}
 	logger.Debugf(ctx, "Successfully registered %v", fileName)
 	registerResults = append(registerResults, registerResult)
@@ -716,81 +839,17 @@ func getTotalSize(reader io.Reader) (size int64, err error) {
 	return size, err
 }
 
-func uploadFastRegisterArtifact(ctx context.Context, project, domain, sourceCodeFilePath, version string,
-	dataProxyClient service.DataProxyServiceClient, deprecatedSourceUploadPath string) (uploadLocation storage.DataReference, err error) {
-
-	fileHandle, err := os.Open(sourceCodeFilePath)
-	if err != nil {
-		return "", err
-	}
-
-	dataRefReaderCloser, err := gzip.NewReader(fileHandle)
-	if err != nil {
-		return "", err
-	}
-
-	/* #nosec */
-	hash := md5.New()
-	/* #nosec */
-	size, err := io.Copy(hash, dataRefReaderCloser)
-	if err != nil {
-		return "", err
-	}
-
-	_, err = fileHandle.Seek(0, 0)
+func uploadFastRegisterArtifact(ctx context.Context, project, domain, sourceCodeFilePath string,
+	dataProxyClient service.DataProxyServiceClient, config rconfig.FilesConfig) (uploadLocation storage.DataReference, err error) {
+	archive, err := NewSourceArchive(sourceCodeFilePath)
 	if err != nil {
 		return "", err
 	}
-
-	err = dataRefReaderCloser.Reset(fileHandle)
-	if err != nil {
-		return "", err
-	}
-
-	h := hash.Sum(nil)
-	remotePath := storage.DataReference(deprecatedSourceUploadPath)
-	_, fileName := filepath.Split(sourceCodeFilePath)
-	resp, err := dataProxyClient.CreateUploadLocation(ctx, &service.CreateUploadLocationRequest{
-		Project:    project,
-		Domain:     domain,
-		Filename:   fileName,
-		ContentMd5: h,
-	})
-
-	if err != nil {
-		if status.Code(err) == codes.Unimplemented {
-			logger.Infof(ctx, "Using an older version of FlyteAdmin. Falling back to the configured storage client.")
-		} else {
-			return "", fmt.Errorf("failed to create an upload location. Error: %w", err)
-		}
-	}
-
-	if resp != nil && len(resp.SignedUrl) > 0 {
-		return storage.DataReference(resp.NativeUrl), DirectUpload(resp.SignedUrl, h, size, dataRefReaderCloser)
-	}
-
-	dataStore, err := getStorageClient(ctx)
+	uploader, err := NewUploader(ctx, project, domain, dataProxyClient, config)
 	if err != nil {
 		return "", err
 	}
-
-	if len(deprecatedSourceUploadPath) == 0 {
-		remotePath, err = dataStore.ConstructReference(ctx, dataStore.GetBaseContainerFQN(ctx), "fast")
-		if err != nil {
-			return "", err
-		}
-	}
-
-	remotePath, err = getRemoteStoragePath(ctx, dataStore, remotePath.String(), fileName, version)
-	if err != nil {
-		return "", err
-	}
-
-	if err := dataStore.ComposedProtobufStore.WriteRaw(ctx, remotePath, size, storage.Options{}, dataRefReaderCloser); err != nil {
-		return "", err
-	}
-
-	return remotePath, nil
+	return uploader.Upload(ctx, archive)
 }
 
 func DirectUpload(url string, contentMD5 []byte, size int64, data io.Reader) error {
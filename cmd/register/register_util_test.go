@@ -3,9 +3,11 @@ package register
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,6 +33,8 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 
 	"github.com/google/go-github/v42/github"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
@@ -216,6 +220,52 @@ func TestGetSortedArchivedFileThroughValidHttpWithNullContextList(t *testing.T)
 	assert.Nil(t, os.RemoveAll(tmpDir), "unable to delete temp dir %v", tmpDir)
 }
 
+// ociRegistry serves the layers as the oci://<host>/flyte/package:v1 artifact, like a registry without auth does
+func ociRegistry(t *testing.T, layers ...string) (*httptest.Server, string) {
+	blobs := map[string][]byte{}
+	manifest := ocispec.Manifest{}
+	for _, layer := range layers {
+		raw, err := os.ReadFile(layer)
+		assert.Nil(t, err)
+		d := digest.FromBytes(raw)
+		blobs[d.String()] = raw
+		manifest.Layers = append(manifest.Layers, ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayer, Digest: d,
+			Size: int64(len(raw)), Annotations: map[string]string{ocispec.AnnotationTitle: filepath.Base(layer)}})
+	}
+	rawManifest, err := json.Marshal(manifest)
+	assert.Nil(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/v2/flyte/package/manifests/v1" {
+			_, _ = w.Write(rawManifest)
+		} else if blob, ok := blobs[strings.TrimPrefix(req.URL.Path, "/v2/flyte/package/blobs/")]; ok {
+			_, _ = w.Write(blob)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, digest.FromBytes(rawManifest).String()
+}
+
+func TestGetSortedArchivedFileThroughOCIList(t *testing.T) {
+	s := setup()
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	server, manifestDigest := ociRegistry(t, "testdata/valid-register.tgz", "testdata/valid-parent-folder-register.tar")
+	ref := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/flyte/package:v1"
+
+	fileList, tmpDir, artifacts, err := getSerializeOutputFiles(s.Ctx, []string{ref}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(fileList))
+	assert.Equal(t, filepath.Join(tmpDir, "014_recipes.core.basic.basic_workflow.t1_1.pb"), fileList[0])
+	assert.Equal(t, 1, len(artifacts))
+	assert.Equal(t, manifestDigest, artifacts[0].Digest)
+	assert.Nil(t, os.RemoveAll(tmpDir), "unable to delete temp dir %v", tmpDir)
+
+	_, tmpDir, _, err = getSerializeOutputFiles(s.Ctx, []string{strings.Replace(ref, ":v1", ":v2", 1)}, false)
+	assert.NotNil(t, err)
+	assert.Nil(t, os.RemoveAll(tmpDir), "unable to delete temp dir %v", tmpDir)
+}
+
 func Test_getTotalSize(t *testing.T) {
 	b := bytes.NewBufferString("hello world")
 	size, err := getTotalSize(b)
@@ -230,7 +280,7 @@ func TestRegisterFile(t *testing.T) {
 		s.MockAdminClient.OnCreateTaskMatch(mock.Anything, mock.Anything).Return(nil, nil)
 		args := []string{"testdata/69_core.flyte_basics.lp.greet_1.pb"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Nil(t, err)
 	})
@@ -282,7 +332,7 @@ func TestRegisterFile(t *testing.T) {
 		s.FetcherExt.OnFetchWorkflowVersionMatch(s.Ctx, "core.scheduled_workflows.lp_schedules.date_formatter_wf", mock.Anything, "dummyProject", "dummyDomain").Return(wf, nil)
 		args := []string{"testdata/152_my_cron_scheduled_lp_3.pb"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, "Failed", results[0].Status)
 		assert.Contains(t, results[0].Info, "param values are missing on scheduled workflow for the following params")
@@ -293,7 +343,7 @@ func TestRegisterFile(t *testing.T) {
 		registerFilesSetup()
 		args := []string{"testdata/non-existent.pb"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, "Failed", results[0].Status)
 		assert.Equal(t, "Error reading file due to open testdata/non-existent.pb: no such file or directory", results[0].Info)
@@ -304,7 +354,7 @@ func TestRegisterFile(t *testing.T) {
 		registerFilesSetup()
 		args := []string{"testdata/valid-register.tar"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, "Failed", results[0].Status)
 		assert.True(t, strings.HasPrefix(results[0].Info, "Error unmarshalling file due to failed unmarshalling file testdata/valid-register.tar"))
@@ -317,7 +367,7 @@ func TestRegisterFile(t *testing.T) {
 			status.Error(codes.AlreadyExists, "AlreadyExists"))
 		args := []string{"testdata/69_core.flyte_basics.lp.greet_1.pb"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, "Success", results[0].Status)
 		assert.Equal(t, "AlreadyExists", results[0].Info)
@@ -330,7 +380,7 @@ func TestRegisterFile(t *testing.T) {
 			status.Error(codes.InvalidArgument, "Invalid"))
 		args := []string{"testdata/69_core.flyte_basics.lp.greet_1.pb"}
 		var registerResults []Result
-		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig)
+		results, err := registerFile(s.Ctx, args[0], registerResults, s.CmdCtx, "", *rconfig.DefaultFilesConfig, nil, nil)
 		assert.Equal(t, 1, len(results))
 		assert.Equal(t, "Failed", results[0].Status)
 		assert.Equal(t, "Error registering file due to rpc error: code = InvalidArgument desc = Invalid", results[0].Info)
@@ -395,7 +445,7 @@ func TestUploadFastRegisterArtifact(t *testing.T) {
 			Filename:   "flytesnacks-core.tgz",
 			ContentMd5: []uint8{0x19, 0x72, 0x39, 0xcd, 0x85, 0x2d, 0xf1, 0x79, 0x8f, 0x6b, 0x3, 0xb3, 0xa9, 0x6c, 0xec, 0xa0},
 		}).Return(&service.CreateUploadLocationResponse{}, nil)
-		_, err = uploadFastRegisterArtifact(s.Ctx, "flytesnacks", "development", "testdata/flytesnacks-core.tgz", "", s.MockClient.DataProxyClient(), rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath)
+		_, err = uploadFastRegisterArtifact(s.Ctx, "flytesnacks", "development", "testdata/flytesnacks-core.tgz", s.MockClient.DataProxyClient(), *rconfig.DefaultFilesConfig)
 		assert.Nil(t, err)
 	})
 	t.Run("Failed upload", func(t *testing.T) {
@@ -413,7 +463,7 @@ func TestUploadFastRegisterArtifact(t *testing.T) {
 			Filename:   "flytesnacks-core.tgz",
 			ContentMd5: []uint8{0x19, 0x72, 0x39, 0xcd, 0x85, 0x2d, 0xf1, 0x79, 0x8f, 0x6b, 0x3, 0xb3, 0xa9, 0x6c, 0xec, 0xa0},
 		}).Return(&service.CreateUploadLocationResponse{}, nil)
-		_, err = uploadFastRegisterArtifact(context.Background(), "flytesnacks", "development", "testdata/flytesnacks-core.tgz", "", s.MockClient.DataProxyClient(), rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath)
+		_, err = uploadFastRegisterArtifact(context.Background(), "flytesnacks", "development", "testdata/flytesnacks-core.tgz", s.MockClient.DataProxyClient(), *rconfig.DefaultFilesConfig)
 		assert.Nil(t, err)
 	})
 	t.Run("Failed upload", func(t *testing.T) {
@@ -424,7 +474,7 @@ func TestUploadFastRegisterArtifact(t *testing.T) {
 		}, testScope.NewSubScope("flytectl"))
 		assert.Nil(t, err)
 		Client = s
-		_, err = uploadFastRegisterArtifact(context.Background(), "flytesnacks", "development", "testdata/flytesnacksre.tgz", "", nil, rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath)
+		_, err = uploadFastRegisterArtifact(context.Background(), "flytesnacks", "development", "testdata/flytesnacksre.tgz", nil, *rconfig.DefaultFilesConfig)
 		assert.NotNil(t, err)
 	})
 }
@@ -0,0 +1,142 @@
+package register
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	registerRollbackShort = "Rolls back to the launch plans of a previous registration."
+	registerRollbackLong  = `
+Rolls back to a registration recorded by the manifest flag of register files. The launch plans the registration activated are reactivated, and the versions active in their place are deactivated.
+The project and domain are the ones of the manifest.
+::
+
+ flytectl register files _pb_output/* -p flytesnacks -d development --version v1 --enableSchedule --manifest v1.json
+ flytectl register files _pb_output/* -p flytesnacks -d development --version v2 --enableSchedule --manifest v2.json
+ flytectl register rollback --manifest v1.json
+
+The launch plans a newer registration activated under other names are deactivated too when its manifest is passed with currentManifest:
+::
+
+ flytectl register rollback --manifest v1.json --currentManifest v2.json
+
+Print the launch plans rolled back without updating them:
+::
+
+ flytectl register rollback --manifest v1.json --currentManifest v2.json --dryRun
+
+Usage
+`
+)
+
+func rollbackFunc(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	if len(rconfig.DefaultRollbackConfig.Manifest) == 0 {
+		return fmt.Errorf("please pass the manifest of the registration to roll back to with --manifest")
+	}
+	previous, err := readManifest(rconfig.DefaultRollbackConfig.Manifest)
+	if err != nil {
+		return err
+	}
+	var current *Manifest
+	if len(rconfig.DefaultRollbackConfig.CurrentManifest) > 0 {
+		if current, err = readManifest(rconfig.DefaultRollbackConfig.CurrentManifest); err != nil {
+			return err
+		}
+		if current.Project != previous.Project || current.Domain != previous.Domain {
+			return fmt.Errorf("manifest %v is of %v/%v, not of %v/%v like manifest %v", rconfig.DefaultRollbackConfig.CurrentManifest,
+				current.Project, current.Domain, previous.Project, previous.Domain, rconfig.DefaultRollbackConfig.Manifest)
+		}
+	}
+
+	results, rollbackErr := rollback(ctx, cmdCtx, previous, current, rconfig.DefaultRollbackConfig.DryRun)
+	payload, _ := json.Marshal(results)
+	rollbackPrinter := printer.Printer{}
+	_ = rollbackPrinter.JSONToTable(payload, projectColumns)
+	return rollbackErr
+}
+
+// rollback activates the launch plans activated by the previous registration, then deactivates the versions active in
+// their place, and the launch plans activated by the current registration alone. Activating first leaves no window
+// without the schedules of the previous registration.
+func rollback(ctx context.Context, cmdCtx cmdCore.CommandContext, previous, current *Manifest, dryRun bool) ([]Result, error) {
+	var results []Result
+	activated := previous.activatedLaunchPlans()
+	deactivate := map[string]string{}
+	for _, name := range sortedNames(activated) {
+		active, err := cmdCtx.AdminClient().GetActiveLaunchPlan(ctx, &admin.ActiveLaunchPlanRequest{
+			Id: &admin.NamedEntityIdentifier{Project: previous.Project, Domain: previous.Domain, Name: name},
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			results = append(results, Result{Name: name, Status: "Failed", Info: fmt.Sprintf("Error fetching the active version due to %v", err)})
+			return results, err
+		}
+		if active.GetId().GetVersion() == activated[name] {
+			results = append(results, Result{Name: name, Status: "Success", Info: fmt.Sprintf("Version %v is already active", activated[name])})
+			continue
+		}
+		if err := updateLaunchPlanState(ctx, cmdCtx, previous, name, activated[name], admin.LaunchPlanState_ACTIVE, dryRun); err != nil {
+			results = append(results, Result{Name: name, Status: "Failed", Info: fmt.Sprintf("Error activating version %v due to %v", activated[name], err)})
+			return results, err
+		}
+		results = append(results, Result{Name: name, Status: "Success", Info: fmt.Sprintf("Activated version %v", activated[name])})
+		if active != nil {
+			deactivate[name] = active.Id.Version
+		}
+	}
+	if current != nil {
+		for name, version := range current.activatedLaunchPlans() {
+			if _, ok := activated[name]; !ok {
+				deactivate[name] = version
+			}
+		}
+	}
+
+	for _, name := range sortedNames(deactivate) {
+		if err := updateLaunchPlanState(ctx, cmdCtx, previous, name, deactivate[name], admin.LaunchPlanState_INACTIVE, dryRun); err != nil {
+			results = append(results, Result{Name: name, Status: "Failed", Info: fmt.Sprintf("Error deactivating version %v due to %v", deactivate[name], err)})
+			return results, err
+		}
+		results = append(results, Result{Name: name, Status: "Success", Info: fmt.Sprintf("Deactivated version %v", deactivate[name])})
+	}
+	return results, nil
+}
+
+func updateLaunchPlanState(ctx context.Context, cmdCtx cmdCore.CommandContext, manifest *Manifest, name, version string,
+	state admin.LaunchPlanState, dryRun bool) error {
+	if dryRun {
+		logger.Debugf(ctx, "skipping UpdateLaunchPlan request (DryRun)")
+		return nil
+	}
+	_, err := cmdCtx.AdminClient().UpdateLaunchPlan(ctx, &admin.LaunchPlanUpdateRequest{
+		Id: &core.Identifier{
+			ResourceType: core.ResourceType_LAUNCH_PLAN,
+			Project:      manifest.Project,
+			Domain:       manifest.Domain,
+			Name:         name,
+			Version:      version,
+		},
+		State: state,
+	})
+	return err
+}
+
+func sortedNames(versions map[string]string) []string {
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,159 @@
+package register
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	"github.com/flyteorg/flyteidl/clients/go/admin/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func writeManifest(t *testing.T, launchPlans map[string]string) string {
+	manifest := newManifest("flytesnacks", "development", nil, "")
+	for name, version := range launchPlans {
+		manifest.Entities = append(manifest.Entities, ManifestEntity{ResourceType: core.ResourceType_LAUNCH_PLAN.String(),
+			Name: name, Version: version, Activated: true})
+	}
+	manifest.Entities = append(manifest.Entities, ManifestEntity{ResourceType: core.ResourceType_LAUNCH_PLAN.String(),
+		Name: "core.basic.unscheduled", Version: "v1"})
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	assert.Nil(t, manifest.write(path))
+	return path
+}
+
+func launchPlanUpdate(name, version string, state admin.LaunchPlanState) *admin.LaunchPlanUpdateRequest {
+	return &admin.LaunchPlanUpdateRequest{
+		Id: &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Project: "flytesnacks", Domain: "development",
+			Name: name, Version: version},
+		State: state,
+	}
+}
+
+func TestRollback(t *testing.T) {
+	defer func() { *rconfig.DefaultRollbackConfig = rconfig.RollbackConfig{} }()
+	activeLaunchPlan := func(name string) *admin.ActiveLaunchPlanRequest {
+		return &admin.ActiveLaunchPlanRequest{Id: &admin.NamedEntityIdentifier{Project: "flytesnacks", Domain: "development", Name: name}}
+	}
+
+	t.Run("Reactivate previous launch plans", func(t *testing.T) {
+		s := setup()
+		rconfig.DefaultRollbackConfig.Manifest = writeManifest(t, map[string]string{"core.basic.daily": "v1", "core.basic.hourly": "v1"})
+		rconfig.DefaultRollbackConfig.CurrentManifest = writeManifest(t, map[string]string{"core.basic.daily": "v2", "core.basic.weekly": "v2"})
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, activeLaunchPlan("core.basic.daily")).
+			Return(&admin.LaunchPlan{Id: &core.Identifier{Name: "core.basic.daily", Version: "v2"}}, nil)
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, activeLaunchPlan("core.basic.hourly")).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanUpdateResponse{}, nil)
+
+		err := rollbackFunc(s.Ctx, nil, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertCalled(t, "UpdateLaunchPlan", s.Ctx, launchPlanUpdate("core.basic.daily", "v1", admin.LaunchPlanState_ACTIVE))
+		s.MockAdminClient.AssertCalled(t, "UpdateLaunchPlan", s.Ctx, launchPlanUpdate("core.basic.hourly", "v1", admin.LaunchPlanState_ACTIVE))
+		s.MockAdminClient.AssertCalled(t, "UpdateLaunchPlan", s.Ctx, launchPlanUpdate("core.basic.daily", "v2", admin.LaunchPlanState_INACTIVE))
+		s.MockAdminClient.AssertCalled(t, "UpdateLaunchPlan", s.Ctx, launchPlanUpdate("core.basic.weekly", "v2", admin.LaunchPlanState_INACTIVE))
+		s.MockAdminClient.AssertNumberOfCalls(t, "UpdateLaunchPlan", 4)
+	})
+	t.Run("Already active", func(t *testing.T) {
+		s := setup()
+		rconfig.DefaultRollbackConfig.Manifest = writeManifest(t, map[string]string{"core.basic.daily": "v1"})
+		rconfig.DefaultRollbackConfig.CurrentManifest = ""
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, activeLaunchPlan("core.basic.daily")).
+			Return(&admin.LaunchPlan{Id: &core.Identifier{Name: "core.basic.daily", Version: "v1"}}, nil)
+
+		err := rollbackFunc(s.Ctx, nil, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+	})
+	t.Run("Dry run", func(t *testing.T) {
+		s := setup()
+		rconfig.DefaultRollbackConfig.Manifest = writeManifest(t, map[string]string{"core.basic.daily": "v1"})
+		rconfig.DefaultRollbackConfig.DryRun = true
+		defer func() { rconfig.DefaultRollbackConfig.DryRun = false }()
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, activeLaunchPlan("core.basic.daily")).
+			Return(&admin.LaunchPlan{Id: &core.Identifier{Name: "core.basic.daily", Version: "v2"}}, nil)
+
+		err := rollbackFunc(s.Ctx, nil, s.CmdCtx)
+		assert.Nil(t, err)
+		s.MockAdminClient.AssertNotCalled(t, "UpdateLaunchPlan", mock.Anything, mock.Anything)
+	})
+	t.Run("Failed activation", func(t *testing.T) {
+		s := setup()
+		rconfig.DefaultRollbackConfig.Manifest = writeManifest(t, map[string]string{"core.basic.daily": "v1"})
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, activeLaunchPlan("core.basic.daily")).
+			Return(&admin.LaunchPlan{Id: &core.Identifier{Name: "core.basic.daily", Version: "v2"}}, nil)
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(s.Ctx, mock.Anything).Return(nil, errors.New("failed"))
+
+		err := rollbackFunc(s.Ctx, nil, s.CmdCtx)
+		assert.NotNil(t, err)
+		s.MockAdminClient.AssertNumberOfCalls(t, "UpdateLaunchPlan", 1)
+	})
+	t.Run("Launch plans which already existed", func(t *testing.T) {
+		s := setup()
+		registerFilesSetup()
+		Client = memoryStore(t)
+		rconfig.DefaultFilesConfig.Archive = true
+		rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath = s3Output
+		rconfig.DefaultFilesConfig.Version = "v1"
+		rconfig.DefaultFilesConfig.Manifest = filepath.Join(t.TempDir(), "manifest.json")
+		defer func() {
+			rconfig.DefaultFilesConfig.Archive = false
+			rconfig.DefaultFilesConfig.DeprecatedSourceUploadPath = ""
+			rconfig.DefaultFilesConfig.EnableSchedule = false
+			rconfig.DefaultFilesConfig.Version = ""
+			rconfig.DefaultFilesConfig.Manifest = ""
+		}()
+		s.MockAdminClient.OnCreateTaskMatch(mock.Anything, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "AlreadyExists"))
+		s.MockAdminClient.OnCreateWorkflowMatch(mock.Anything, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "AlreadyExists"))
+		s.MockAdminClient.OnCreateLaunchPlanMatch(mock.Anything, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "AlreadyExists"))
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(mock.Anything, mock.Anything).Return(&admin.LaunchPlanUpdateResponse{}, nil)
+		s.MockClient.DataProxyClient().(*mocks.DataProxyServiceClient).OnCreateUploadLocationMatch(mock.Anything, mock.Anything).
+			Return(&service.CreateUploadLocationResponse{}, nil)
+		assert.Nil(t, registerFromFilesFunc(s.Ctx, []string{"testdata/flytesnacks-core.tgz"}, s.CmdCtx))
+
+		// The existing launch plans are activated by the registration, and recorded as such.
+		manifest, err := readManifest(rconfig.DefaultFilesConfig.Manifest)
+		assert.Nil(t, err)
+		activated := manifest.activatedLaunchPlans()
+		assert.NotEmpty(t, activated)
+		s.MockAdminClient.AssertNumberOfCalls(t, "UpdateLaunchPlan", len(activated))
+
+		// Rolling back to the registration reactivates them.
+		s = setup()
+		rconfig.DefaultRollbackConfig.Manifest = rconfig.DefaultFilesConfig.Manifest
+		rconfig.DefaultRollbackConfig.CurrentManifest = ""
+		s.MockAdminClient.OnGetActiveLaunchPlanMatch(s.Ctx, mock.Anything).
+			Return(&admin.LaunchPlan{Id: &core.Identifier{Version: "v2"}}, nil)
+		s.MockAdminClient.OnUpdateLaunchPlanMatch(s.Ctx, mock.Anything).Return(&admin.LaunchPlanUpdateResponse{}, nil)
+		assert.Nil(t, rollbackFunc(s.Ctx, nil, s.CmdCtx))
+		for name := range activated {
+			for version, state := range map[string]admin.LaunchPlanState{"v1": admin.LaunchPlanState_ACTIVE, "v2": admin.LaunchPlanState_INACTIVE} {
+				s.MockAdminClient.AssertCalled(t, "UpdateLaunchPlan", s.Ctx, &admin.LaunchPlanUpdateRequest{
+					Id: &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Project: manifest.Project, Domain: manifest.Domain,
+						Name: name, Version: version},
+					State: state,
+				})
+			}
+		}
+	})
+	t.Run("Invalid manifests", func(t *testing.T) {
+		s := setup()
+		rconfig.DefaultRollbackConfig.Manifest = ""
+		assert.NotNil(t, rollbackFunc(s.Ctx, nil, s.CmdCtx))
+		rconfig.DefaultRollbackConfig.Manifest = filepath.Join(t.TempDir(), "missing.json")
+		assert.NotNil(t, rollbackFunc(s.Ctx, nil, s.CmdCtx))
+
+		other := newManifest("flytesnacks", "production", nil, "")
+		rconfig.DefaultRollbackConfig.CurrentManifest = filepath.Join(t.TempDir(), "other.json")
+		assert.Nil(t, other.write(rconfig.DefaultRollbackConfig.CurrentManifest))
+		rconfig.DefaultRollbackConfig.Manifest = writeManifest(t, map[string]string{"core.basic.daily": "v1"})
+		assert.NotNil(t, rollbackFunc(s.Ctx, nil, s.CmdCtx))
+	})
+}
@@ -0,0 +1,319 @@
+package register
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/md5" //#nosec
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+const (
+	sourceArchivePrefix = "fast"
+	largestFilesReport  = 10
+	megabyte            = 1 << 20
+)
+
+// ignoreFiles are the files of the source dir, and of its sub dirs, listing the paths left out of the archive
+var ignoreFiles = []string{".gitignore", ".flyteignore"}
+
+// standardIgnores are always left out of the archive, like pyflyte does
+var standardIgnores = []string{".git/", "*.pyc", "__pycache__/", ".cache/"}
+
+// ignoreRule is a pattern of an ignore file, matching the paths relative to the dir of the file
+type ignoreRule struct {
+	dir     string
+	regexp  *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// newIgnoreRule parses a line of an ignore file in the gitignore format. It returns nil for blank lines and comments.
+func newIgnoreRule(dir, line string) *ignoreRule {
+	line = strings.TrimRight(line, " \r")
+	if len(line) == 0 || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	rule := &ignoreRule{dir: dir}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if len(line) == 0 {
+		return nil
+	}
+	// A pattern without a slash matches at any depth, one with a slash is relative to the dir of the ignore file.
+	if !strings.Contains(line, "/") {
+		line = "**/" + line
+	}
+	rule.regexp = globRegexp(strings.TrimPrefix(line, "/"))
+	return rule
+}
+
+// globRegexp converts a gitignore glob, where * and ? don't match slashes and ** matches any dirs, to a regexp
+func globRegexp(glob string) *regexp.Regexp {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			re.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			re.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		case c == '[':
+			if end := strings.Index(glob[i:], "]"); end > 0 {
+				class := glob[i+1 : i+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				re.WriteString("[" + class + "]")
+				i += end
+			} else {
+				re.WriteString(`\[`)
+			}
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("(/.*)?$")
+	return regexp.MustCompile(re.String())
+}
+
+func (r *ignoreRule) match(relPath string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if len(r.dir) > 0 {
+		if !strings.HasPrefix(relPath, r.dir+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, r.dir+"/")
+	}
+	return r.regexp.MatchString(relPath)
+}
+
+// ignored tells whether the last rule matching the path ignores it
+func ignored(rules []*ignoreRule, relPath string, isDir bool) bool {
+	ignore := false
+	for _, rule := range rules {
+		if rule.match(relPath, isDir) {
+			ignore = !rule.negate
+		}
+	}
+	return ignore
+}
+
+func readIgnoreRules(root, dir string) ([]*ignoreRule, error) {
+	var rules []*ignoreRule
+	for _, name := range ignoreFiles {
+		file, err := os.Open(filepath.Join(root, filepath.FromSlash(dir), name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if rule := newIgnoreRule(dir, scanner.Text()); rule != nil {
+				rules = append(rules, rule)
+			}
+		}
+		_ = file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %v of %v. Error: %w", name, dir, err)
+		}
+	}
+	return rules, nil
+}
+
+// sourceFile is a file of the source dir going into the archive, by its slash separated path relative to the dir
+type sourceFile struct {
+	relPath string
+	info    fs.FileInfo
+}
+
+// listSourceFiles lists the files of the source dir, in lexical order, leaving out the ignored ones
+func listSourceFiles(root string) ([]sourceFile, error) {
+	var rules []*ignoreRule
+	for _, pattern := range standardIgnores {
+		rules = append(rules, newIgnoreRule("", pattern))
+	}
+	var files []sourceFile
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		relPath := filepath.ToSlash(rel)
+		if relPath == "." {
+			dirRules, err := readIgnoreRules(root, "")
+			rules = append(rules, dirRules...)
+			return err
+		}
+		if ignored(rules, relPath, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			// The rules of the sub dir only match under it, so they can be appended for the rest of the walk.
+			dirRules, err := readIgnoreRules(root, relPath)
+			rules = append(rules, dirRules...)
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && info.Mode()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		files = append(files, sourceFile{relPath: relPath, info: info})
+		return nil
+	})
+	return files, err
+}
+
+// checkSourceSize fails for the source dirs above the limit, reporting their largest files
+func checkSourceSize(root string, files []sourceFile, limitMB int) error {
+	var total int64
+	for _, file := range files {
+		total += file.info.Size()
+	}
+	if limitMB <= 0 || total <= int64(limitMB)*megabyte {
+		return nil
+	}
+	largest := append([]sourceFile{}, files...)
+	sort.SliceStable(largest, func(i, j int) bool {
+		return largest[i].info.Size() > largest[j].info.Size()
+	})
+	if len(largest) > largestFilesReport {
+		largest = largest[:largestFilesReport]
+	}
+	var report strings.Builder
+	for _, file := range largest {
+		report.WriteString(fmt.Sprintf("\n  %.2f MB  %v", float64(file.info.Size())/megabyte, file.relPath))
+	}
+	return fmt.Errorf("source dir %v holds %.2f MB of files, above the limit of %v MB. Ignore the files not needed by the tasks in %v, or raise maxSourceSizeMB. The largest files are:%v",
+		root, float64(total)/megabyte, limitMB, strings.Join(ignoreFiles, " or "), report.String())
+}
+
+// buildSourceArchive archives the source dir into a fast<md5>.tar.gz of the dest dir, for a fast registration like
+// pyflyte packages them. The archive only depends on the paths, the contents and the executable bits of the files, as
+// their times, owners and permissions are normalized, so an unchanged source dir gets the same archive.
+func buildSourceArchive(ctx context.Context, root, destDir string, limitMB int) (string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("source %v isn't a dir", root)
+	}
+	files, err := listSourceFiles(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the files of source dir %v. Error: %w", root, err)
+	}
+	if err := checkSourceSize(root, files, limitMB); err != nil {
+		return "", err
+	}
+
+	tmp, err := ioutil.TempFile(destDir, sourceArchivePrefix)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	/* #nosec */
+	hash := md5.New()
+	gzipWriter := gzip.NewWriter(tmp)
+	tarWriter := tar.NewWriter(io.MultiWriter(gzipWriter, hash))
+	for _, file := range files {
+		if err := addSourceFile(tarWriter, root, file); err != nil {
+			_ = tmp.Close()
+			return "", fmt.Errorf("failed to archive %v of source dir %v. Error: %w", file.relPath, root, err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	archive := filepath.Join(destDir, sourceArchivePrefix+hex.EncodeToString(hash.Sum(nil))+sourceCodeExtension)
+	if err := os.Rename(tmp.Name(), archive); err != nil {
+		return "", err
+	}
+	logger.Infof(ctx, "Archived %v files of source dir %v to %v", len(files), root, archive)
+	return archive, nil
+}
+
+func addSourceFile(tarWriter *tar.Writer, root string, file sourceFile) error {
+	header := &tar.Header{
+		Name:    path.Clean(file.relPath),
+		Mode:    0644,
+		ModTime: time.Unix(0, 0),
+		Format:  tar.FormatPAX,
+	}
+	if file.info.Mode()&0111 != 0 {
+		header.Mode = 0755
+	}
+	if file.info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(filepath.Join(root, filepath.FromSlash(file.relPath)))
+		if err != nil {
+			return err
+		}
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = target
+		header.Mode = 0777
+		return tarWriter.WriteHeader(header)
+	}
+	header.Typeflag = tar.TypeReg
+	header.Size = file.info.Size()
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	src, err := os.Open(filepath.Join(root, filepath.FromSlash(file.relPath)))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	_, err = io.CopyN(tarWriter, src, file.info.Size())
+	return err
+}
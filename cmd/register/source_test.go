@@ -0,0 +1,141 @@
+package register
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSourceFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.Nil(t, os.WriteFile(p, []byte(content), 0600))
+	}
+}
+
+func archivedFiles(t *testing.T, archive string) map[string]string {
+	file, err := os.Open(archive)
+	assert.Nil(t, err)
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	assert.Nil(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files
+		}
+		assert.Nil(t, err)
+		content, err := io.ReadAll(tarReader)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Unix(0, 0), header.ModTime)
+		files[header.Name] = string(content)
+	}
+}
+
+func TestIgnoreRule(t *testing.T) {
+	tests := []struct {
+		dir     string
+		pattern string
+		path    string
+		isDir   bool
+		matches bool
+	}{
+		{"", "*.log", "debug.log", false, true},
+		{"", "*.log", "logs/debug.log", false, true},
+		{"", "/*.log", "logs/debug.log", false, false},
+		{"", "build/", "build", true, true},
+		{"", "build/", "build", false, false},
+		{"", "docs/*.md", "docs/index.md", false, true},
+		{"", "docs/*.md", "docs/api/index.md", false, false},
+		{"", "docs/**/*.md", "docs/api/index.md", false, true},
+		{"", "**/data", "src/data", true, true},
+		{"", "data/**", "data/raw/file.csv", false, true},
+		{"", "file?.txt", "file1.txt", false, true},
+		{"", "file[0-9].txt", "filea.txt", false, false},
+		{"sub", "*.csv", "sub/data/file.csv", false, true},
+		{"sub", "*.csv", "file.csv", false, false},
+	}
+	for _, test := range tests {
+		rule := newIgnoreRule(test.dir, test.pattern)
+		assert.Equal(t, test.matches, rule.match(test.path, test.isDir), "%v of %v on %v", test.pattern, test.dir, test.path)
+	}
+	assert.Nil(t, newIgnoreRule("", "# comment"))
+	assert.Nil(t, newIgnoreRule("", "  "))
+
+	rules := []*ignoreRule{newIgnoreRule("", "*.txt"), newIgnoreRule("", "!keep.txt")}
+	assert.True(t, ignored(rules, "notes.txt", false))
+	assert.False(t, ignored(rules, "keep.txt", false))
+}
+
+func TestBuildSourceArchive(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeSourceFiles(t, root, map[string]string{
+		"workflows/basic.py":           "basic",
+		"workflows/notes.txt":          "notes",
+		"workflows/keep.txt":           "keep",
+		"workflows/__pycache__/a.pyc":  "compiled",
+		"workflows/.gitignore":         "*.txt\n!keep.txt\n",
+		"data/raw.csv":                 "raw",
+		".flyteignore":                 "# data isn't needed by the tasks\ndata/\n",
+		".git/HEAD":                    "ref: refs/heads/master",
+		"requirements.txt":             "flytekit",
+		"workflows/utils/__init__.py":  "",
+		"workflows/utils/debug.log":    "log",
+		"workflows/utils/.flyteignore": "*.log",
+	})
+
+	archive, err := buildSourceArchive(ctx, root, t.TempDir(), 100)
+	assert.Nil(t, err)
+	assert.True(t, isFastRegister(archive), archive)
+	assert.Equal(t, map[string]string{
+		".flyteignore":                 "# data isn't needed by the tasks\ndata/\n",
+		"requirements.txt":             "flytekit",
+		"workflows/.gitignore":         "*.txt\n!keep.txt\n",
+		"workflows/basic.py":           "basic",
+		"workflows/keep.txt":           "keep",
+		"workflows/utils/.flyteignore": "*.log",
+		"workflows/utils/__init__.py":  "",
+	}, archivedFiles(t, archive))
+
+	// The times of the files don't change the archive.
+	future := time.Now().Add(time.Hour)
+	assert.Nil(t, os.Chtimes(filepath.Join(root, "workflows", "basic.py"), future, future))
+	again, err := buildSourceArchive(ctx, root, t.TempDir(), 100)
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Base(archive), filepath.Base(again))
+
+	writeSourceFiles(t, root, map[string]string{"workflows/basic.py": "changed"})
+	changed, err := buildSourceArchive(ctx, root, t.TempDir(), 100)
+	assert.Nil(t, err)
+	assert.NotEqual(t, filepath.Base(archive), filepath.Base(changed))
+
+	_, err = buildSourceArchive(ctx, filepath.Join(root, "requirements.txt"), t.TempDir(), 100)
+	assert.NotNil(t, err)
+}
+
+func TestBuildSourceArchiveSizeLimit(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFiles(t, root, map[string]string{
+		"model.bin":   strings.Repeat("x", 2*megabyte),
+		"workflow.py": "workflow",
+	})
+	_, err := buildSourceArchive(context.Background(), root, t.TempDir(), 1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "above the limit of 1 MB")
+	assert.Contains(t, err.Error(), "2.00 MB  model.bin")
+
+	_, err = buildSourceArchive(context.Background(), root, t.TempDir(), 3)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,284 @@
+package register
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/md5" //#nosec
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errDataProxyUnavailable is returned by the data proxy uploader when admin doesn't issue signed URLs
+var errDataProxyUnavailable = errors.New("the data proxy of flyte admin is unavailable")
+
+// SourceArchive is the source code archive of a fast registration. The tar it compresses is uploaded, under a location
+// derived from its MD5, so an unchanged archive keeps its location.
+type SourceArchive struct {
+	Path string
+	Name string
+	MD5  []byte
+	Size int64
+}
+
+// NewSourceArchive computes the MD5 and the size of the tar compressed by the archive
+func NewSourceArchive(path string) (*SourceArchive, error) {
+	reader, err := openArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	/* #nosec */
+	hash := md5.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return nil, err
+	}
+	return &SourceArchive{Path: path, Name: filepath.Base(path), MD5: hash.Sum(nil), Size: size}, nil
+}
+
+// Open returns the tar compressed by the archive
+func (a *SourceArchive) Open() (io.ReadCloser, error) {
+	return openArchive(a.Path)
+}
+
+// key returns the name of the archive prefixed with its MD5
+func (a *SourceArchive) key() string {
+	return fmt.Sprintf("%v-%v", hex.EncodeToString(a.MD5), a.Name)
+}
+
+type archiveReader struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (r archiveReader) Close() error {
+	if err := r.Reader.Close(); err != nil {
+		_ = r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+func openArchive(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return archiveReader{Reader: reader, file: file}, nil
+}
+
+// Uploader uploads the source code archives of fast registrations to where the tasks download them from
+type Uploader interface {
+	// Upload uploads the archive, unless its location already holds it, and returns its location
+	Upload(ctx context.Context, archive *SourceArchive) (storage.DataReference, error)
+}
+
+// NewUploader returns the uploader of the config. By default, it uploads through the data proxy of admin, falling back
+// to the storage config for the admins without one.
+func NewUploader(ctx context.Context, project, domain string, dataProxyClient service.DataProxyServiceClient,
+	config rconfig.FilesConfig) (Uploader, error) {
+	dataProxy := dataProxyUploader{client: dataProxyClient, project: project, domain: domain, store: getStorageClient}
+	switch config.Uploader {
+	case "":
+		return fallbackUploader{dataProxy: dataProxy, storage: func() (Uploader, error) {
+			return newStorageUploader(ctx, config.DeprecatedSourceUploadPath)
+		}}, nil
+	case rconfig.UploaderDataProxy:
+		return dataProxy, nil
+	case rconfig.UploaderStorage:
+		return newStorageUploader(ctx, config.DeprecatedSourceUploadPath)
+	case rconfig.UploaderLocal:
+		dir := config.LocalUploadPath
+		if len(dir) == 0 {
+			dir = f.FilePathJoin(f.UserHomeDir(), ".flyte", "fast")
+		}
+		return localUploader{dir: dir}, nil
+	}
+	return nil, fmt.Errorf("unsupported uploader %v. It must be one of %v, %v, %v", config.Uploader,
+		rconfig.UploaderDataProxy, rconfig.UploaderStorage, rconfig.UploaderLocal)
+}
+
+// dataProxyUploader uploads to the signed URLs of the data proxy of admin. Admin derives the location from the MD5 of
+// the archive, but a signed URL can't tell whether the location already holds it, so the location is looked up with the
+// storage config instead. The archive is uploaded again when the storage config can't reach the location.
+type dataProxyUploader struct {
+	client  service.DataProxyServiceClient
+	project string
+	domain  string
+	store   func(ctx context.Context) (*storage.DataStore, error)
+}
+
+func (u dataProxyUploader) Upload(ctx context.Context, archive *SourceArchive) (storage.DataReference, error) {
+	if u.client == nil {
+		return "", errDataProxyUnavailable
+	}
+	resp, err := u.client.CreateUploadLocation(ctx, &service.CreateUploadLocationRequest{
+		Project:    u.project,
+		Domain:     u.domain,
+		Filename:   archive.Name,
+		ContentMd5: archive.MD5,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return "", errDataProxyUnavailable
+		}
+		return "", fmt.Errorf("failed to create an upload location. Error: %w", err)
+	}
+	if len(resp.GetSignedUrl()) == 0 {
+		return "", errDataProxyUnavailable
+	}
+	location := storage.DataReference(resp.NativeUrl)
+	if u.uploaded(ctx, location, archive) {
+		logger.Infof(ctx, "Source code archive %v is already uploaded to %v", archive.Name, location)
+		return location, nil
+	}
+	reader, err := archive.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	return location, DirectUpload(resp.SignedUrl, archive.MD5, archive.Size, reader)
+}
+
+// uploaded tells whether the location already holds the archive, as far as the storage config can tell
+func (u dataProxyUploader) uploaded(ctx context.Context, location storage.DataReference, archive *SourceArchive) bool {
+	if u.store == nil || len(location) == 0 {
+		return false
+	}
+	store, err := u.store(ctx)
+	if err != nil {
+		logger.Debugf(ctx, "no storage client to look up %v: %v", location, err)
+		return false
+	}
+	metadata, err := store.Head(ctx, location)
+	if err != nil {
+		logger.Debugf(ctx, "failed to look up %v: %v", location, err)
+		return false
+	}
+	return metadata.Exists() && metadata.Size() == archive.Size
+}
+
+// fallbackUploader uploads through the data proxy, or to the storage config when admin has no data proxy
+type fallbackUploader struct {
+	dataProxy Uploader
+	storage   func() (Uploader, error)
+}
+
+func (u fallbackUploader) Upload(ctx context.Context, archive *SourceArchive) (storage.DataReference, error) {
+	location, err := u.dataProxy.Upload(ctx, archive)
+	if !errors.Is(err, errDataProxyUnavailable) {
+		return location, err
+	}
+	logger.Infof(ctx, "Using an older version of FlyteAdmin. Falling back to the configured storage client.")
+	uploader, err := u.storage()
+	if err != nil {
+		return "", err
+	}
+	return uploader.Upload(ctx, archive)
+}
+
+// storageUploader writes to the storage config, s3, gcs, azure or minio, under the fast dir of its container unless
+// another dir is given
+type storageUploader struct {
+	store *storage.DataStore
+	dir   storage.DataReference
+}
+
+func newStorageUploader(ctx context.Context, dir string) (Uploader, error) {
+	store, err := getStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remoteDir := storage.DataReference(dir)
+	if len(remoteDir) == 0 {
+		if remoteDir, err = store.ConstructReference(ctx, store.GetBaseContainerFQN(ctx), "fast"); err != nil {
+			return nil, err
+		}
+	}
+	return storageUploader{store: store, dir: remoteDir}, nil
+}
+
+func (u storageUploader) Upload(ctx context.Context, archive *SourceArchive) (storage.DataReference, error) {
+	remotePath, err := getRemoteStoragePath(ctx, u.store, u.dir.String(), archive.Name, hex.EncodeToString(archive.MD5))
+	if err != nil {
+		return "", err
+	}
+	metadata, err := u.store.Head(ctx, remotePath)
+	if err != nil {
+		return "", err
+	}
+	if metadata.Exists() && metadata.Size() == archive.Size {
+		logger.Infof(ctx, "Source code archive %v is already uploaded to %v", archive.Name, remotePath)
+		return remotePath, nil
+	}
+	reader, err := archive.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	if err := u.store.ComposedProtobufStore.WriteRaw(ctx, remotePath, archive.Size, storage.Options{}, reader); err != nil {
+		return "", err
+	}
+	return remotePath, nil
+}
+
+// localUploader copies to a dir of the local filesystem, for a sandbox mounting the dir at the same path
+type localUploader struct {
+	dir string
+}
+
+func (u localUploader) Upload(ctx context.Context, archive *SourceArchive) (storage.DataReference, error) {
+	dir, err := filepath.Abs(u.dir)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, archive.key())
+	location := storage.DataReference("file://" + filepath.ToSlash(target))
+	if info, err := os.Stat(target); err == nil && info.Size() == archive.Size {
+		logger.Infof(ctx, "Source code archive %v is already uploaded to %v", archive.Name, location)
+		return location, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	reader, err := archive.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	// The archive is written aside first, so an interrupted copy doesn't pass for an uploaded archive.
+	tmp, err := ioutil.TempFile(dir, archive.Name)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, reader); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", err
+	}
+	return location, nil
+}
@@ -0,0 +1,174 @@
+package register
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	"github.com/flyteorg/flyteidl/clients/go/admin/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const sourceArchive = "testdata/flytesnacks-core.tgz"
+
+func memoryStore(t *testing.T) *storage.DataStore {
+	labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope().NewSubScope("flytectl"))
+	assert.Nil(t, err)
+	return store
+}
+
+func TestNewSourceArchive(t *testing.T) {
+	archive, err := NewSourceArchive(sourceArchive)
+	assert.Nil(t, err)
+	assert.Equal(t, "flytesnacks-core.tgz", archive.Name)
+	assert.Equal(t, []uint8{0x19, 0x72, 0x39, 0xcd, 0x85, 0x2d, 0xf1, 0x79, 0x8f, 0x6b, 0x3, 0xb3, 0xa9, 0x6c, 0xec, 0xa0}, archive.MD5)
+	assert.Equal(t, "197239cd852df1798f6b03b3a96ceca0-flytesnacks-core.tgz", archive.key())
+
+	_, err = NewSourceArchive("testdata/invalid.tar")
+	assert.NotNil(t, err)
+}
+
+func TestNewUploader(t *testing.T) {
+	ctx := context.Background()
+	Client = memoryStore(t)
+	for uploaderType, expected := range map[string]interface{}{
+		"":                        fallbackUploader{},
+		rconfig.UploaderDataProxy: dataProxyUploader{},
+		rconfig.UploaderStorage:   storageUploader{},
+		rconfig.UploaderLocal:     localUploader{},
+	} {
+		uploader, err := NewUploader(ctx, "flytesnacks", "development", nil, rconfig.FilesConfig{Uploader: uploaderType})
+		assert.Nil(t, err)
+		assert.IsType(t, expected, uploader)
+	}
+	_, err := NewUploader(ctx, "flytesnacks", "development", nil, rconfig.FilesConfig{Uploader: "ftp"})
+	assert.NotNil(t, err)
+}
+
+func TestStorageUploader(t *testing.T) {
+	ctx := context.Background()
+	Client = memoryStore(t)
+	archive, err := NewSourceArchive(sourceArchive)
+	assert.Nil(t, err)
+	uploader, err := newStorageUploader(ctx, "s3://dummy/fast")
+	assert.Nil(t, err)
+
+	location, err := uploader.Upload(ctx, archive)
+	assert.Nil(t, err)
+	assert.Equal(t, storage.DataReference("s3://dummy/fast/197239cd852df1798f6b03b3a96ceca0-flytesnacks-core.tgz"), location)
+
+	// An archive already uploaded isn't written again.
+	marker := bytes.Repeat([]byte("x"), int(archive.Size))
+	assert.Nil(t, Client.WriteRaw(ctx, location, archive.Size, storage.Options{}, bytes.NewReader(marker)))
+	again, err := uploader.Upload(ctx, archive)
+	assert.Nil(t, err)
+	assert.Equal(t, location, again)
+	reader, err := Client.ReadRaw(ctx, location)
+	assert.Nil(t, err)
+	content, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, marker, content)
+}
+
+func TestLocalUploader(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "fast")
+	archive, err := NewSourceArchive(sourceArchive)
+	assert.Nil(t, err)
+	uploader := localUploader{dir: dir}
+
+	location, err := uploader.Upload(ctx, archive)
+	assert.Nil(t, err)
+	target := filepath.Join(dir, archive.key())
+	assert.Equal(t, storage.DataReference("file://"+filepath.ToSlash(target)), location)
+	info, err := os.Stat(target)
+	assert.Nil(t, err)
+	assert.Equal(t, archive.Size, info.Size())
+
+	marker := bytes.Repeat([]byte("x"), int(archive.Size))
+	assert.Nil(t, os.WriteFile(target, marker, 0600))
+	_, err = uploader.Upload(ctx, archive)
+	assert.Nil(t, err)
+	content, err := os.ReadFile(target)
+	assert.Nil(t, err)
+	assert.Equal(t, marker, content)
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+}
+
+func TestDataProxyUploader(t *testing.T) {
+	ctx := context.Background()
+	archive, err := NewSourceArchive(sourceArchive)
+	assert.Nil(t, err)
+
+	t.Run("Signed URL", func(t *testing.T) {
+		Client = memoryStore(t)
+		var uploaded int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, http.MethodPut, req.Method)
+			content, err := ioutil.ReadAll(req.Body)
+			assert.Nil(t, err)
+			uploaded = len(content)
+		}))
+		defer server.Close()
+		client := &mocks.DataProxyServiceClient{}
+		client.OnCreateUploadLocationMatch(ctx, &service.CreateUploadLocationRequest{
+			Project: "flytesnacks", Domain: "development", Filename: archive.Name, ContentMd5: archive.MD5,
+		}).Return(&service.CreateUploadLocationResponse{SignedUrl: server.URL, NativeUrl: "s3://bucket/fast/source.tgz"}, nil)
+		uploader, err := NewUploader(ctx, "flytesnacks", "development", client, rconfig.FilesConfig{Uploader: rconfig.UploaderDataProxy})
+		assert.Nil(t, err)
+		location, err := uploader.Upload(ctx, archive)
+		assert.Nil(t, err)
+		assert.Equal(t, storage.DataReference("s3://bucket/fast/source.tgz"), location)
+		assert.Equal(t, int(archive.Size), uploaded)
+	})
+	t.Run("Already uploaded", func(t *testing.T) {
+		Client = memoryStore(t)
+		location := storage.DataReference("s3://bucket/fast/source.tgz")
+		assert.Nil(t, Client.WriteRaw(ctx, location, archive.Size, storage.Options{}, bytes.NewReader(bytes.Repeat([]byte("x"), int(archive.Size)))))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			assert.Fail(t, "the archive is uploaded again")
+		}))
+		defer server.Close()
+		client := &mocks.DataProxyServiceClient{}
+		client.OnCreateUploadLocationMatch(ctx, mock.Anything).Return(
+			&service.CreateUploadLocationResponse{SignedUrl: server.URL, NativeUrl: location.String()}, nil)
+		uploader, err := NewUploader(ctx, "flytesnacks", "development", client, rconfig.FilesConfig{Uploader: rconfig.UploaderDataProxy})
+		assert.Nil(t, err)
+		uploaded, err := uploader.Upload(ctx, archive)
+		assert.Nil(t, err)
+		assert.Equal(t, location, uploaded)
+	})
+	t.Run("Fallback to storage", func(t *testing.T) {
+		Client = memoryStore(t)
+		client := &mocks.DataProxyServiceClient{}
+		client.OnCreateUploadLocationMatch(ctx, mock.Anything).Return(nil, status.Error(codes.Unimplemented, "unimplemented"))
+		uploader, err := NewUploader(ctx, "flytesnacks", "development", client, rconfig.FilesConfig{})
+		assert.Nil(t, err)
+		location, err := uploader.Upload(ctx, archive)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasSuffix(location.String(), archive.key()), location)
+
+		uploader, err = NewUploader(ctx, "flytesnacks", "development", client, rconfig.FilesConfig{Uploader: rconfig.UploaderDataProxy})
+		assert.Nil(t, err)
+		_, err = uploader.Upload(ctx, archive)
+		assert.ErrorIs(t, err, errDataProxyUnavailable)
+	})
+}
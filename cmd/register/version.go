@@ -0,0 +1,235 @@
+package register
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+const (
+	gitVersionTemplate  = "{{.GitSHA}}"
+	hashVersionTemplate = "{{.Hash}}"
+	hashVersionLength   = 16
+	dirtyVersionSuffix  = "-dirty"
+	dateVersionLayout   = "20060102"
+)
+
+// gitCommand runs git in the current directory, replaced by the tests
+var gitCommand = func(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %v failed: %v: %w", strings.Join(args, " "), strings.TrimSpace(string(out)), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// versionValues are the fields of the version templates
+type versionValues struct {
+	// Version is the version passed with --version
+	Version string
+	// Date is the date of the registration as YYYYMMDD
+	Date string
+	// Hash is the content hash of the spec of the entity
+	Hash string
+	git  *gitVersion
+}
+
+// GitSHA returns the short SHA of the commit of the current directory, with a dirty suffix when it has uncommitted
+// changes. git only runs for the templates using it.
+func (v versionValues) GitSHA() (string, error) {
+	return v.git.get()
+}
+
+type gitVersion struct {
+	version string
+	err     error
+	done    bool
+}
+
+func (g *gitVersion) get() (string, error) {
+	if g.done {
+		return g.version, g.err
+	}
+	g.done = true
+	sha, err := gitCommand("rev-parse", "--short", "HEAD")
+	if err != nil {
+		g.err = fmt.Errorf("failed to derive the version from git. Error: %w", err)
+		return "", g.err
+	}
+	changes, err := gitCommand("status", "--porcelain")
+	if err != nil {
+		g.err = fmt.Errorf("failed to derive the version from git. Error: %w", err)
+		return "", g.err
+	}
+	g.version = sha
+	if len(changes) > 0 {
+		g.version += dirtyVersionSuffix
+	}
+	return g.version, nil
+}
+
+// versioner derives the versions of the entities the package leaves un-versioned, from either git, the content hash of
+// the entities or a version template.
+type versioner struct {
+	template *template.Template
+	values   versionValues
+	// perEntity is set when the version depends on the hash of each entity, so the entities of the package reference
+	// each other with the versions they got instead of a single version.
+	perEntity bool
+	force     bool
+	// versions are the versions of the entities hydrated so far, by resource type and name
+	versions map[string]string
+}
+
+// newVersioner returns the versioner of the config, nil when the entities get the version of the config as is
+func newVersioner(config rconfig.FilesConfig) (*versioner, error) {
+	text := config.VersionTemplate
+	switch config.VersionFrom {
+	case "":
+		if len(text) == 0 {
+			return nil, nil
+		}
+	case rconfig.VersionFromGit, rconfig.VersionFromHash:
+		if len(text) > 0 {
+			return nil, fmt.Errorf("versionFrom and versionTemplate can't be used together")
+		}
+		if len(config.Version) > 0 {
+			return nil, fmt.Errorf("version and versionFrom can't be used together")
+		}
+		text = gitVersionTemplate
+		if config.VersionFrom == rconfig.VersionFromHash {
+			text = hashVersionTemplate
+		}
+	default:
+		return nil, fmt.Errorf("unsupported versionFrom %v. It must be one of %v, %v", config.VersionFrom,
+			rconfig.VersionFromGit, rconfig.VersionFromHash)
+	}
+	tmpl, err := template.New("version").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid versionTemplate %v. Error: %w", text, err)
+	}
+	perEntity := strings.Contains(text, ".Hash")
+	if perEntity && config.Force {
+		return nil, fmt.Errorf("force can't be used with versions derived from the hash of the entities")
+	}
+	return &versioner{
+		template: tmpl,
+		values: versionValues{
+			Version: config.Version,
+			Date:    time.Now().UTC().Format(dateVersionLayout),
+			git:     &gitVersion{},
+		},
+		perEntity: perEntity,
+		force:     config.Force,
+		versions:  map[string]string{},
+	}, nil
+}
+
+func (v *versioner) render(hash string) (string, error) {
+	values := v.values
+	values.Hash = hash
+	var version bytes.Buffer
+	if err := v.template.Execute(&version, values); err != nil {
+		return "", err
+	}
+	if len(strings.TrimSpace(version.String())) == 0 {
+		return "", fmt.Errorf("version template %v renders an empty version", v.template.Root.String())
+	}
+	return version.String(), nil
+}
+
+// hydrateSpec hydrates the spec like hydrateSpec does, with the version derived for the entity. The references to the
+// entities hydrated before get their versions, so the packages are expected to list the entities after the entities
+// they reference, like pyflyte serializes them.
+func (v *versioner) hydrateSpec(message proto.Message, uploadLocation storage.DataReference, config rconfig.FilesConfig) (string, error) {
+	if err := walkIdentifiers(message, func(identifier *core.Identifier) {
+		if version, ok := v.versions[versionKey(identifier)]; ok && isUnversioned(identifier) {
+			identifier.Version = version
+		}
+	}); err != nil {
+		return "", err
+	}
+	config.Version = ""
+	config.Force = false
+	if err := hydrateSpec(message, uploadLocation, config); err != nil {
+		return "", err
+	}
+
+	id, err := entityIdentifier(message)
+	if err != nil {
+		return "", err
+	}
+	var hash string
+	if v.perEntity {
+		var unversioned *core.Identifier
+		if err := walkIdentifiers(message, func(identifier *core.Identifier) {
+			if unversioned == nil && identifier != id && isUnversioned(identifier) {
+				unversioned = identifier
+			}
+		}); err != nil {
+			return "", err
+		}
+		if unversioned != nil {
+			return "", fmt.Errorf("%v references %v %v, which has no version and isn't registered before it in the package",
+				id.Name, unversioned.ResourceType, unversioned.Name)
+		}
+		// The hash of the entity covers the versions of the entities it references, so the entity gets a new version
+		// whenever one of them does.
+		digest, err := specDigest(message)
+		if err != nil {
+			return "", err
+		}
+		hash = digest[:hashVersionLength]
+	}
+	version, err := v.render(hash)
+	if err != nil {
+		return "", err
+	}
+	if err := hydrateIdentifiers(message, version, v.force); err != nil {
+		return "", err
+	}
+	v.versions[versionKey(id)] = id.Version
+	return id.Version, nil
+}
+
+// specDigest returns the hex sha256 of the deterministic serialization of the spec
+func specDigest(message proto.Message) (string, error) {
+	raw, err := protoV2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(message))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func isUnversioned(identifier *core.Identifier) bool {
+	return identifier.Version == "" || identifier.Version == registrationVersionPattern
+}
+
+func versionKey(identifier *core.Identifier) string {
+	return fmt.Sprintf("%v/%v", identifier.ResourceType, identifier.Name)
+}
+
+func entityIdentifier(message proto.Message) (*core.Identifier, error) {
+	switch v := message.(type) {
+	case *admin.LaunchPlan:
+		return v.Id, nil
+	case *admin.WorkflowSpec:
+		return v.Template.Id, nil
+	case *admin.TaskSpec:
+		return v.Template.Id, nil
+	}
+	return nil, fmt.Errorf("unknown type %T", message)
+}
@@ -0,0 +1,140 @@
+package register
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	rconfig "github.com/flyteorg/flytectl/cmd/config/subcommand/register"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// hydrateFiles hydrates the files of the package with the versioner, in order
+func hydrateFiles(t *testing.T, v *versioner, files []string) ([]proto.Message, []string) {
+	var specs []proto.Message
+	var versions []string
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		spec, err := UnMarshalContents(context.Background(), raw, file)
+		assert.Nil(t, err)
+		version, err := v.hydrateSpec(spec, "", *rconfig.DefaultFilesConfig)
+		assert.Nil(t, err)
+		specs = append(specs, spec)
+		versions = append(versions, version)
+	}
+	return specs, versions
+}
+
+func TestNewVersioner(t *testing.T) {
+	v, err := newVersioner(rconfig.FilesConfig{Version: "v1"})
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	for _, config := range []rconfig.FilesConfig{
+		{VersionFrom: "svn"},
+		{VersionFrom: rconfig.VersionFromGit, Version: "v1"},
+		{VersionFrom: rconfig.VersionFromGit, VersionTemplate: "{{.GitSHA}}"},
+		{VersionFrom: rconfig.VersionFromHash, Force: true},
+		{VersionTemplate: "{{.GitSHA"},
+	} {
+		_, err = newVersioner(config)
+		assert.NotNil(t, err, config)
+	}
+}
+
+func TestVersionFromGit(t *testing.T) {
+	defer func(command func(args ...string) (string, error)) { gitCommand = command }(gitCommand)
+	status := ""
+	gitCommand = func(args ...string) (string, error) {
+		if args[0] == "rev-parse" {
+			return "1a2b3c4", nil
+		}
+		return status, nil
+	}
+
+	v, err := newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromGit})
+	assert.Nil(t, err)
+	version, err := v.render("")
+	assert.Nil(t, err)
+	assert.Equal(t, "1a2b3c4", version)
+
+	status = " M workflows/basic.py"
+	v, err = newVersioner(rconfig.FilesConfig{VersionTemplate: "{{.Version}}-{{.GitSHA}}-{{.Date}}", Version: "v1"})
+	assert.Nil(t, err)
+	version, err = v.render("")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(version, "v1-1a2b3c4-dirty-"), version)
+
+	gitCommand = func(args ...string) (string, error) {
+		return "", errors.New("not a git repository")
+	}
+	v, err = newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromGit})
+	assert.Nil(t, err)
+	_, err = v.render("")
+	assert.NotNil(t, err)
+}
+
+func TestVersionFromHash(t *testing.T) {
+	s := setup()
+	rconfig.DefaultFilesConfig.Archive = true
+	defer func() { rconfig.DefaultFilesConfig.Archive = false }()
+	files, tmpDir, err := GetSerializeOutputFiles(s.Ctx, []string{"testdata/valid-register.tgz"}, true)
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	v, err := newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromHash})
+	assert.Nil(t, err)
+	specs, versions := hydrateFiles(t, v, files)
+	assert.Equal(t, 4, len(versions))
+	assert.Equal(t, hashVersionLength, len(versions[0]))
+	assert.NotEqual(t, versions[0], versions[1])
+	assert.Equal(t, versions[0], specs[0].(*admin.TaskSpec).Template.Id.Version)
+
+	// The workflow references the versions the tasks got.
+	referenced := map[string]string{}
+	for _, node := range specs[2].(*admin.WorkflowSpec).Template.Nodes {
+		if taskNode := node.GetTaskNode(); taskNode != nil {
+			referenced[taskNode.GetReferenceId().Name] = taskNode.GetReferenceId().Version
+		}
+	}
+	assert.Equal(t, map[string]string{
+		specs[0].(*admin.TaskSpec).Template.Id.Name: versions[0],
+		specs[1].(*admin.TaskSpec).Template.Id.Name: versions[1],
+	}, referenced)
+
+	// Unchanged entities keep their versions, and the entities referencing a changed one get new versions.
+	v, err = newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromHash})
+	assert.Nil(t, err)
+	_, again := hydrateFiles(t, v, files)
+	assert.Equal(t, versions, again)
+
+	task := specs[0].(*admin.TaskSpec)
+	task.Template.Id.Version = ""
+	task.Template.Metadata.Retries = &core.RetryStrategy{Retries: 3}
+	raw, err := proto.Marshal(task)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(files[0], raw, 0600))
+	v, err = newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromHash})
+	assert.Nil(t, err)
+	_, changed := hydrateFiles(t, v, files)
+	assert.NotEqual(t, versions[0], changed[0])
+	assert.Equal(t, versions[1], changed[1])
+	assert.NotEqual(t, versions[2], changed[2])
+
+	// A reference to an entity missing from the package can't be versioned.
+	v, err = newVersioner(rconfig.FilesConfig{VersionFrom: rconfig.VersionFromHash})
+	assert.Nil(t, err)
+	raw, err = ioutil.ReadFile(files[2])
+	assert.Nil(t, err)
+	spec, err := UnMarshalContents(s.Ctx, raw, files[2])
+	assert.Nil(t, err)
+	_, err = v.hydrateSpec(spec, "", *rconfig.DefaultFilesConfig)
+	assert.NotNil(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/flyteorg/flytectl/cmd/auth"
 	"github.com/flyteorg/flytectl/cmd/compile"
 	"github.com/flyteorg/flytectl/cmd/config"
 	configuration "github.com/flyteorg/flytectl/cmd/configuration"
@@ -13,12 +14,15 @@ import (
 	"github.com/flyteorg/flytectl/cmd/delete"
 	"github.com/flyteorg/flytectl/cmd/demo"
 	"github.com/flyteorg/flytectl/cmd/get"
+	"github.com/flyteorg/flytectl/cmd/promote"
+	"github.com/flyteorg/flytectl/cmd/prune"
 	"github.com/flyteorg/flytectl/cmd/register"
 	"github.com/flyteorg/flytectl/cmd/sandbox"
+	"github.com/flyteorg/flytectl/cmd/ui"
 	"github.com/flyteorg/flytectl/cmd/update"
 	"github.com/flyteorg/flytectl/cmd/upgrade"
 	"github.com/flyteorg/flytectl/cmd/version"
-	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
 	"github.com/flyteorg/flytectl/pkg/printer"
 	stdConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
@@ -30,12 +34,12 @@ import (
 
 var (
 	cfgFile        string
+	cfgContext     string
 	configAccessor = viper.NewAccessor(stdConfig.Options{StrictMode: true})
 )
 
 const (
-	configFileDir  = ".flyte"
-	configFileName = "config.yaml"
+	contextEnvName = "FLYTECTL_CONTEXT"
 )
 
 func newRootCmd() *cobra.Command {
@@ -48,6 +52,7 @@ func newRootCmd() *cobra.Command {
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.flyte/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgContext, "context", "", "context of the config file to use (default is the current-context of the config file)")
 
 	configAccessor.InitializePflags(rootCmd.PersistentFlags())
 
@@ -64,10 +69,14 @@ func newRootCmd() *cobra.Command {
 	rootCmd.AddCommand(update.CreateUpdateCommand())
 	rootCmd.AddCommand(register.RemoteRegisterCommand())
 	rootCmd.AddCommand(delete.RemoteDeleteCommand())
+	rootCmd.AddCommand(promote.CreatePromoteCommand())
+	rootCmd.AddCommand(prune.CreatePruneCommand())
 	rootCmd.AddCommand(sandbox.CreateSandboxCommand())
 	rootCmd.AddCommand(demo.CreateDemoCommand())
 	rootCmd.AddCommand(configuration.CreateConfigCommand())
+	rootCmd.AddCommand(auth.CreateAuthCommand())
 	rootCmd.AddCommand(completionCmd)
+	cmdCore.AddCommands(rootCmd, ui.CreateUICommand())
 	// Added version command
 	versionCmd := version.GetVersionCommand(rootCmd)
 	cmdCore.AddCommands(rootCmd, versionCmd)
@@ -82,20 +91,8 @@ func newRootCmd() *cobra.Command {
 }
 
 func initConfig(cmd *cobra.Command, _ []string) error {
-	configFile := f.FilePathJoin(f.UserHomeDir(), configFileDir, configFileName)
-	// TODO: Move flyteconfig env variable logic in flytestdlib
-	if len(os.Getenv("FLYTECTL_CONFIG")) > 0 {
-		configFile = os.Getenv("FLYTECTL_CONFIG")
-	}
-
-	if len(cfgFile) > 0 {
-		configFile = cfgFile
-	}
-
-	configAccessor = viper.NewAccessor(stdConfig.Options{
-		StrictMode:  true,
-		SearchPaths: []string{configFile},
-	})
+	configFile := configutil.ResolveConfigFile(cfgFile)
+	configutil.ConfigFileUsed = configFile
 
 	// persistent flags were initially bound to the root command so we must bind to the same command to avoid
 	// overriding those initial ones. We need to traverse up to the root command and initialize pflags for that.
@@ -103,10 +100,24 @@ func initConfig(cmd *cobra.Command, _ []string) error {
 	for rootCmd.Parent() != nil {
 		rootCmd = rootCmd.Parent()
 	}
+	configutil.ConfigFlags = rootCmd.PersistentFlags()
+
+	// The config of the selected context is merged over the top-level config of the file, and written to a temporary
+	// file for the flags and env vars to take precedence over it the same way they do over the config file.
+	effectiveConfigFile, cleanup, err := contextConfigFile(rootCmd, configFile)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	configAccessor = viper.NewAccessor(stdConfig.Options{
+		StrictMode:  true,
+		SearchPaths: []string{effectiveConfigFile},
+	})
 
 	configAccessor.InitializePflags(rootCmd.PersistentFlags())
 
-	err := configAccessor.UpdateConfig(context.TODO())
+	err = configAccessor.UpdateConfig(context.TODO())
 	if err != nil {
 		return err
 	}
@@ -114,6 +125,50 @@ func initConfig(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// contextConfigFile returns the config file holding the config of the context selected by the context flag, the
+// FLYTECTL_CONTEXT env var or the current-context of the config file, in that order. The top-level config is used when
+// no context is selected, and the config file is returned as is when it holds no contexts.
+func contextConfigFile(rootCmd *cobra.Command, configFile string) (string, func(), error) {
+	noop := func() {}
+	configutil.CurrentContext = ""
+	contextsFile, err := configutil.LoadContextsFile(configFile)
+	if err != nil {
+		return "", noop, err
+	}
+	name := cfgContext
+	if len(name) == 0 {
+		name = os.Getenv(contextEnvName)
+	}
+	if len(name) == 0 {
+		name = contextsFile.CurrentContext
+		if _, ok := contextsFile.GetContext(name); !ok && len(name) > 0 {
+			// Falls back to the top-level config for flytectl config use-context to be able to fix the file.
+			logrus.Warnf("current-context %q not found in %v", name, configFile)
+			name = ""
+		}
+	}
+	if len(name) == 0 && len(contextsFile.Contexts) == 0 && len(contextsFile.CurrentContext) == 0 {
+		return configFile, noop, nil
+	}
+	if _, ok := contextsFile.GetContext(name); !ok && len(name) > 0 {
+		return "", noop, fmt.Errorf("context %q not found in %v", name, configFile)
+	}
+
+	dir, err := os.MkdirTemp("", "flytectl-context")
+	if err != nil {
+		return "", noop, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	flags := rootCmd.PersistentFlags()
+	effectiveConfigFile, err := contextsFile.WriteEffectiveConfig(name, flags.Changed("project") || flags.Changed("domain"), dir)
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	configutil.CurrentContext = name
+	return effectiveConfigFile, cleanup, nil
+}
+
 func GenerateDocs() error {
 	rootCmd := newRootCmd()
 	err := GenReSTTree(rootCmd, "gen")
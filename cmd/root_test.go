@@ -1,8 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/flyteorg/flytectl/cmd/config"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,3 +19,75 @@ func TestRootCmdIntegration(t *testing.T) {
 	rootCmd := newRootCmd()
 	assert.NotNil(t, rootCmd)
 }
+
+func TestInitConfigWithContexts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(`
+current-context: sandbox
+contexts:
+  - name: sandbox
+    admin:
+      endpoint: dns:///localhost:30081
+      insecure: true
+    project: flytesnacks
+    domain: development
+  - name: production
+    admin:
+      endpoint: dns:///flyte.myexample.com
+    project: flyteexamples
+admin:
+  authType: Pkce
+`), 0600))
+	defer func() {
+		cfgFile, cfgContext = "", ""
+		configutil.CurrentContext = ""
+		config.GetConfig().Project, config.GetConfig().Domain = "", ""
+	}()
+
+	t.Run("Current context", func(t *testing.T) {
+		rootCmd := newRootCmd()
+		cfgFile = path
+		assert.Nil(t, initConfig(rootCmd, nil))
+		assert.Equal(t, "sandbox", configutil.CurrentContext)
+		assert.Equal(t, "dns:///localhost:30081", admin.GetConfig(context.Background()).Endpoint.String())
+		assert.True(t, admin.GetConfig(context.Background()).UseInsecureConnection)
+		assert.Equal(t, "flytesnacks", config.GetConfig().Project)
+		assert.Equal(t, "development", config.GetConfig().Domain)
+	})
+	t.Run("Context from the env var", func(t *testing.T) {
+		t.Setenv(contextEnvName, "production")
+		rootCmd := newRootCmd()
+		cfgFile = path
+		assert.Nil(t, initConfig(rootCmd, nil))
+		assert.Equal(t, "production", configutil.CurrentContext)
+		assert.Equal(t, "dns:///flyte.myexample.com", admin.GetConfig(context.Background()).Endpoint.String())
+		assert.Equal(t, "flyteexamples", config.GetConfig().Project)
+	})
+	t.Run("Context from the flag", func(t *testing.T) {
+		t.Setenv(contextEnvName, "sandbox")
+		rootCmd := newRootCmd()
+		assert.Nil(t, rootCmd.ParseFlags([]string{"--config", path, "--context", "production", "--project", "flytesnacks"}))
+		assert.Nil(t, initConfig(rootCmd, nil))
+		assert.Equal(t, "production", configutil.CurrentContext)
+		assert.Equal(t, "dns:///flyte.myexample.com", admin.GetConfig(context.Background()).Endpoint.String())
+		assert.Equal(t, "flytesnacks", config.GetConfig().Project)
+	})
+	t.Run("Missing context", func(t *testing.T) {
+		rootCmd := newRootCmd()
+		cfgFile, cfgContext = path, "staging"
+		err := initConfig(rootCmd, nil)
+		assert.EqualError(t, err, fmt.Sprintf("context \"staging\" not found in %v", path))
+		cfgContext = ""
+	})
+	t.Run("No current context", func(t *testing.T) {
+		noCurrentPath := filepath.Join(t.TempDir(), "config.yaml")
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Nil(t, os.WriteFile(noCurrentPath, bytes.Replace(data, []byte("current-context: sandbox"), nil, 1), 0600))
+		rootCmd := newRootCmd()
+		cfgFile = noCurrentPath
+		assert.Nil(t, initConfig(rootCmd, nil))
+		assert.Equal(t, "", configutil.CurrentContext)
+		assert.Equal(t, admin.AuthTypePkce, admin.GetConfig(context.Background()).AuthType)
+	})
+}
@@ -0,0 +1,67 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/printer"
+	"github.com/flyteorg/flytectl/pkg/sandbox"
+)
+
+const (
+	doctorShort = "Diagnoses the sandbox and the environment it runs in."
+	doctorLong  = `
+Runs a checklist of the sandbox health and prints a hint to fix every failed check. It checks the docker daemon, the
+memory available to docker, the free disk space, the conflicts on the host ports of the sandbox and of the demo, the
+kube context in $HOME/.flyte/k3s, the Kubernetes API, the health of Flyteadmin and the reachability of Minio.
+The cluster checks are skipped when the sandbox isn't running.
+::
+
+ flytectl sandbox doctor
+
+Diagnoses a named sandbox:
+::
+
+ flytectl sandbox doctor --name feature-x
+
+Prints the checks in JSON, e.g. to attach them to a bug report:
+::
+
+ flytectl sandbox doctor -o json
+
+The command fails when any of the checks fails.
+
+Usage
+`
+)
+
+var checkColumns = []printer.Column{
+	{Header: "Status", JSONPath: "$.status"},
+	{Header: "Check", JSONPath: "$.name"},
+	{Header: "Message", JSONPath: "$.message"},
+	{Header: "Hint", JSONPath: "$.hint"},
+}
+
+func sandboxDoctor(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	return runDoctor(ctx, sandbox.NewDoctor(cli), sandboxCmdConfig.DefaultInstanceConfig.Name)
+}
+
+func runDoctor(ctx context.Context, doctor *sandbox.Doctor, name string) error {
+	checks := doctor.Run(ctx, name)
+	p := printer.Printer{}
+	if err := p.PrintInterface(config.GetConfig().MustOutputFormat(), checkColumns, checks); err != nil {
+		return err
+	}
+	if failed := sandbox.FailedChecks(checks); failed > 0 {
+		return fmt.Errorf("%v of %v checks failed", failed, len(checks))
+	}
+	return nil
+}
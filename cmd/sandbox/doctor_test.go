@@ -0,0 +1,23 @@
+package sandbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxDoctor(t *testing.T) {
+	t.Run("Docker unreachable", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnServerVersion(s.Ctx).Return(types.Version{}, fmt.Errorf("cannot connect"))
+		docker.Client = mockDocker
+		err := sandboxDoctor(s.Ctx, []string{}, s.CmdCtx)
+		assert.EqualError(t, err, "1 of 1 checks failed")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
 	"github.com/flyteorg/flytectl/pkg/docker"
 )
@@ -18,22 +19,27 @@ By default, "flytectl exec" is present in the /root directory inside the sandbox
 
  flytectl sandbox exec -- ls -al 
 
+Runs the command inside the container of a named sandbox:
+::
+
+ flytectl sandbox exec --name feature-x -- ls -al
+
 Usage`
 )
 
 func sandboxClusterExec(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
 	if err != nil {
 		return err
 	}
 	if len(args) > 0 {
-		return execute(ctx, cli, args)
+		return execute(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name, args)
 	}
 	return fmt.Errorf("missing argument. Please check usage examples by running flytectl sandbox exec --help")
 }
 
-func execute(ctx context.Context, cli docker.Docker, args []string) error {
-	c, err := docker.GetSandbox(ctx, cli)
+func execute(ctx context.Context, cli docker.Docker, name string, args []string) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,93 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/printer"
+)
+
+const (
+	listShort = "Lists the sandboxes."
+	listLong  = `
+Lists the default sandbox and the named ones, along with the state of their container, the URL of their Flyte console
+and their config file.
+::
+
+ flytectl sandbox list
+
+Lists the sandboxes run by podman instead of the detected container runtime:
+::
+
+ flytectl sandbox list --runtime podman
+
+Usage
+`
+	defaultSandboxName = "(default)"
+)
+
+var sandboxColumns = []printer.Column{
+	{Header: "Name", JSONPath: "$.Name"},
+	{Header: "Container", JSONPath: "$.Container"},
+	{Header: "Image", JSONPath: "$.Image"},
+	{Header: "State", JSONPath: "$.State"},
+	{Header: "Status", JSONPath: "$.Status"},
+	{Header: "Console", JSONPath: "$.Console"},
+	{Header: "Config", JSONPath: "$.Config"},
+}
+
+// Summary describes a sandbox listed by the list command
+type Summary struct {
+	Name      string
+	Container string
+	Image     string
+	State     string
+	Status    string
+	Console   string
+	Config    string
+}
+
+func listSandboxes(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	summaries, err := sandboxSummaries(ctx, cli)
+	if err != nil {
+		return err
+	}
+	p := printer.Printer{}
+	return p.PrintInterface(config.GetConfig().MustOutputFormat(), sandboxColumns, summaries)
+}
+
+func sandboxSummaries(ctx context.Context, cli docker.Docker) ([]Summary, error) {
+	containers, err := docker.ListSandboxes(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]Summary, 0, len(containers))
+	for _, c := range containers {
+		name := docker.SandboxName(c)
+		summary := Summary{
+			Name:      name,
+			Container: docker.SandboxContainerName(name),
+			Image:     c.Image,
+			State:     c.State,
+			Status:    c.Status,
+			Config:    configutil.SandboxConfigFile(name),
+		}
+		if len(name) == 0 {
+			summary.Name = defaultSandboxName
+		}
+		if port, ok := c.Labels[docker.SandboxConsolePortLabel]; ok {
+			summary.Console = fmt.Sprintf("http://localhost:%v/console", port)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
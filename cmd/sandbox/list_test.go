@@ -0,0 +1,50 @@
+package sandbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListSandboxes(t *testing.T) {
+	t.Run("Sandbox summaries", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return([]types.Container{
+			{ID: "2", Names: []string{"/flyte-sandbox-feature-x"}, Image: "sandbox:v1", State: "running", Status: "Up 2 minutes",
+				Labels: map[string]string{docker.SandboxNameLabel: "feature-x", docker.SandboxConsolePortLabel: "30181"}},
+			{ID: "1", Names: []string{"/flyte-sandbox"}, Image: "sandbox:v1", State: "exited", Status: "Exited (0)"},
+			{ID: "3", Names: []string{"/nginx"}},
+		}, nil)
+		summaries, err := sandboxSummaries(s.Ctx, mockDocker)
+		assert.Nil(t, err)
+		assert.Equal(t, []Summary{
+			{Name: defaultSandboxName, Container: "flyte-sandbox", Image: "sandbox:v1", State: "exited", Status: "Exited (0)",
+				Config: configutil.FlytectlConfig},
+			{Name: "feature-x", Container: "flyte-sandbox-feature-x", Image: "sandbox:v1", State: "running", Status: "Up 2 minutes",
+				Console: "http://localhost:30181/console", Config: configutil.SandboxConfigFile("feature-x")},
+		}, summaries)
+	})
+	t.Run("List sandboxes", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		docker.Client = mockDocker
+		err := listSandboxes(s.Ctx, []string{}, s.CmdCtx)
+		assert.Nil(t, err)
+	})
+	t.Run("List sandboxes error", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return(nil, fmt.Errorf("failed"))
+		docker.Client = mockDocker
+		err := listSandboxes(s.Ctx, []string{}, s.CmdCtx)
+		assert.EqualError(t, err, "failed")
+	})
+}
@@ -0,0 +1,44 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/sandbox"
+)
+
+const (
+	loadImageShort = "Loads locally built images into the sandbox cluster."
+	loadImageLong  = `
+Exports images from the local container runtime and imports them into the containerd of the sandbox cluster, the way
+kind load docker-image does. The pods of the sandbox can then run the image without pushing it to a registry.
+::
+
+ docker build -t my-image:dev .
+ flytectl sandbox load-image my-image:dev
+
+Loads several images into a named sandbox:
+::
+
+ flytectl sandbox load-image my-image:dev my-other-image:dev --name feature-x
+
+The tasks registered with a local tag then run with it, e.g. with flytectl register files. Avoid the latest tag, which
+Kubernetes always pulls from the registry.
+
+Usage
+`
+)
+
+func sandboxLoadImage(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing argument. Please check usage examples by running flytectl sandbox load-image --help")
+	}
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	return sandbox.LoadImages(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name, args)
+}
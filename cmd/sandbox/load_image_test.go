@@ -0,0 +1,30 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxLoadImage(t *testing.T) {
+	t.Run("Missing image", func(t *testing.T) {
+		s := testutils.Setup()
+		err := sandboxLoadImage(s.Ctx, []string{}, s.CmdCtx)
+		assert.EqualError(t, err, "missing argument. Please check usage examples by running flytectl sandbox load-image --help")
+	})
+	t.Run("Named sandbox not found", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		docker.Client = mockDocker
+		sandboxCmdConfig.DefaultInstanceConfig.Name = "feature-x"
+		defer func() { sandboxCmdConfig.DefaultInstanceConfig.Name = "" }()
+		err := sandboxLoadImage(s.Ctx, []string{"my-image:dev"}, s.CmdCtx)
+		assert.EqualError(t, err, "sandbox container flyte-sandbox-feature-x not found")
+	})
+}
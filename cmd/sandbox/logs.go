@@ -0,0 +1,80 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/sandbox"
+)
+
+const (
+	logsShort = "Prints the logs of the sandbox container, of a Flyte component or of the task pods of an execution."
+	logsLong  = `
+Prints the logs of the sandbox container:
+::
+
+ flytectl sandbox logs
+
+Prints the logs of the pods of a Flyte component running in the flyte namespace of the sandbox, e.g. flyteadmin,
+flytepropeller or flyteconsole. Every line is prefixed with the pod and container it comes from:
+::
+
+ flytectl sandbox logs flytepropeller
+
+Streams the logs until interrupted, starting from the logs of the last 10 minutes:
+::
+
+ flytectl sandbox logs flyteadmin --follow --since 10m
+
+Prints the logs of every task pod of an execution, which run in the <project>-<domain> namespace:
+::
+
+ flytectl sandbox logs --execution f2b3c8d6e1a0a4b5c6d7 -p flytesnacks -d development
+
+Prints the logs of a named sandbox:
+::
+
+ flytectl sandbox logs flyteadmin --name feature-x
+
+Usage
+`
+)
+
+func sandboxLogs(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultLogsConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	logsConfig := sandboxCmdConfig.DefaultLogsConfig
+	options := sandbox.LogOptions{
+		Follow: logsConfig.Follow,
+		Since:  logsConfig.Since.Duration,
+	}
+	if len(logsConfig.Execution) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("component can't be combined with execution")
+		}
+		project, domain := config.GetConfig().Project, config.GetConfig().Domain
+		if len(project) == 0 || len(domain) == 0 {
+			return fmt.Errorf("project and domain are required with execution")
+		}
+		k8sClient, err := sandbox.GetK8sClient(ctx, cli, logsConfig.Name)
+		if err != nil {
+			return err
+		}
+		return sandbox.ExecutionLogs(ctx, k8sClient.CoreV1(), fmt.Sprintf("%v-%v", project, domain), logsConfig.Execution, options, os.Stdout)
+	}
+	if len(args) == 0 {
+		return sandbox.ContainerLogs(ctx, cli, logsConfig.Name, options, os.Stdout)
+	}
+	k8sClient, err := sandbox.GetK8sClient(ctx, cli, logsConfig.Name)
+	if err != nil {
+		return err
+	}
+	return sandbox.ComponentLogs(ctx, k8sClient.CoreV1(), args[0], options, os.Stdout)
+}
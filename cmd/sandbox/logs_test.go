@@ -0,0 +1,50 @@
+package sandbox
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/flyteorg/flytectl/cmd/config"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSandboxLogs(t *testing.T) {
+	t.Run("Sandbox container logs", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return([]types.Container{
+			{ID: docker.FlyteSandboxClusterName, Names: []string{"/" + docker.FlyteSandboxClusterName}},
+		}, nil)
+		mockDocker.OnContainerLogsMatch(s.Ctx, docker.FlyteSandboxClusterName, mock.Anything).Return(
+			io.NopCloser(strings.NewReader("")), nil)
+		docker.Client = mockDocker
+		err := sandboxLogs(s.Ctx, []string{}, s.CmdCtx)
+		assert.Nil(t, err)
+	})
+	t.Run("Sandbox not found", func(t *testing.T) {
+		s := testutils.Setup()
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(s.Ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		docker.Client = mockDocker
+		err := sandboxLogs(s.Ctx, []string{}, s.CmdCtx)
+		assert.EqualError(t, err, "sandbox container flyte-sandbox not found")
+	})
+	t.Run("Execution without project", func(t *testing.T) {
+		s := testutils.Setup()
+		docker.Client = &mocks.Docker{}
+		config.GetConfig().Project = ""
+		sandboxCmdConfig.DefaultLogsConfig.Execution = "f1234"
+		defer func() {
+			sandboxCmdConfig.DefaultLogsConfig.Execution = ""
+		}()
+		err := sandboxLogs(s.Ctx, []string{}, s.CmdCtx)
+		assert.EqualError(t, err, "project and domain are required with execution")
+	})
+}
@@ -8,7 +8,7 @@ import (
 
 // Long descriptions are whitespace sensitive when generating docs using sphinx.
 const (
-	sandboxShort = `Helps with sandbox interactions like start, teardown, status, and exec.`
+	sandboxShort = `Helps with sandbox interactions like start, teardown, status, list, snapshot, logs, doctor, load-image, and exec.`
 	sandboxLong  = `
 Flyte Sandbox is a fully standalone minimal environment for running Flyte.
 It provides a simplified way of running Flyte sandbox as a single Docker container locally.
@@ -32,6 +32,40 @@ To execute commands inside the sandbox container, use exec:
 ::
 
  flytectl sandbox exec -- pwd 	
+
+To load a locally built image into the sandbox cluster, run:
+::
+
+ flytectl sandbox load-image my-image:dev
+
+To print the logs of a Flyte component running in the sandbox, run:
+::
+
+ flytectl sandbox logs flyteadmin
+
+To diagnose a sandbox which fails to start or to serve Flyte, run:
+::
+
+ flytectl sandbox doctor
+
+Several sandboxes can run side by side by giving them a name. Every named sandbox gets its own host ports,
+kube context and config file:
+::
+
+ flytectl sandbox start --name feature-x
+ flytectl sandbox status --name feature-x
+ flytectl sandbox teardown --name feature-x
+
+To list all the sandboxes, run:
+::
+
+ flytectl sandbox list
+
+To save the state of the sandbox and restore it later, run:
+::
+
+ flytectl sandbox snapshot save my-demo
+ flytectl sandbox snapshot restore my-demo
 `
 )
 
@@ -49,16 +83,29 @@ func CreateSandboxCommand() *cobra.Command {
 			Long:  startLong, PFlagProvider: sandboxCmdConfig.DefaultConfig, DisableFlyteClient: true},
 		"teardown": {CmdFunc: teardownSandboxCluster, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: teardownShort,
-			Long:  teardownLong, DisableFlyteClient: true},
+			Long:  teardownLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
 		"status": {CmdFunc: sandboxClusterStatus, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: statusShort,
-			Long:  statusLong},
+			Long:  statusLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig},
+		"doctor": {CmdFunc: sandboxDoctor, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: doctorShort,
+			Long:  doctorLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
+		"logs": {CmdFunc: sandboxLogs, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: logsShort,
+			Long:  logsLong, PFlagProvider: sandboxCmdConfig.DefaultLogsConfig, DisableFlyteClient: true},
+		"load-image": {CmdFunc: sandboxLoadImage, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: loadImageShort,
+			Long:  loadImageLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
+		"list": {CmdFunc: listSandboxes, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: listShort,
+			Long:  listLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
 		"exec": {CmdFunc: sandboxClusterExec, Aliases: []string{}, ProjectDomainNotRequired: true,
 			Short: execShort,
-			Long:  execLong, DisableFlyteClient: true},
+			Long:  execLong, PFlagProvider: sandboxCmdConfig.DefaultInstanceConfig, DisableFlyteClient: true},
 	}
 
 	cmdcore.AddCommands(sandbox, sandboxResourcesFuncs)
+	sandbox.AddCommand(createSnapshotCommand())
 
 	return sandbox
 }
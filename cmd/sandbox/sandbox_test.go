@@ -11,29 +11,49 @@ import (
 func TestCreateSandboxCommand(t *testing.T) {
 	sandboxCommand := CreateSandboxCommand()
 	assert.Equal(t, sandboxCommand.Use, "sandbox")
-	assert.Equal(t, sandboxCommand.Short, "Helps with sandbox interactions like start, teardown, status, and exec.")
+	assert.Equal(t, sandboxCommand.Short, "Helps with sandbox interactions like start, teardown, status, list, snapshot, logs, doctor, load-image, and exec.")
 	fmt.Println(sandboxCommand.Commands())
-	assert.Equal(t, len(sandboxCommand.Commands()), 4)
+	assert.Equal(t, len(sandboxCommand.Commands()), 9)
 	cmdNouns := sandboxCommand.Commands()
 	// Sort by Use value.
 	sort.Slice(cmdNouns, func(i, j int) bool {
 		return cmdNouns[i].Use < cmdNouns[j].Use
 	})
 
-	assert.Equal(t, cmdNouns[0].Use, "exec")
-	assert.Equal(t, cmdNouns[0].Short, execShort)
-	assert.Equal(t, cmdNouns[0].Long, execLong)
+	assert.Equal(t, cmdNouns[0].Use, "doctor")
+	assert.Equal(t, cmdNouns[0].Short, doctorShort)
+	assert.Equal(t, cmdNouns[0].Long, doctorLong)
 
-	assert.Equal(t, cmdNouns[1].Use, "start")
-	assert.Equal(t, cmdNouns[1].Short, startShort)
-	assert.Equal(t, cmdNouns[1].Long, startLong)
+	assert.Equal(t, cmdNouns[1].Use, "exec")
+	assert.Equal(t, cmdNouns[1].Short, execShort)
+	assert.Equal(t, cmdNouns[1].Long, execLong)
 
-	assert.Equal(t, cmdNouns[2].Use, "status")
-	assert.Equal(t, cmdNouns[2].Short, statusShort)
-	assert.Equal(t, cmdNouns[2].Long, statusLong)
+	assert.Equal(t, cmdNouns[2].Use, "list")
+	assert.Equal(t, cmdNouns[2].Short, listShort)
+	assert.Equal(t, cmdNouns[2].Long, listLong)
 
-	assert.Equal(t, cmdNouns[3].Use, "teardown")
-	assert.Equal(t, cmdNouns[3].Short, teardownShort)
-	assert.Equal(t, cmdNouns[3].Long, teardownLong)
+	assert.Equal(t, cmdNouns[3].Use, "load-image")
+	assert.Equal(t, cmdNouns[3].Short, loadImageShort)
+	assert.Equal(t, cmdNouns[3].Long, loadImageLong)
+
+	assert.Equal(t, cmdNouns[4].Use, "logs")
+	assert.Equal(t, cmdNouns[4].Short, logsShort)
+	assert.Equal(t, cmdNouns[4].Long, logsLong)
+
+	assert.Equal(t, cmdNouns[5].Use, "snapshot")
+	assert.Equal(t, cmdNouns[5].Short, snapshotShort)
+	assert.Equal(t, len(cmdNouns[5].Commands()), 2)
+
+	assert.Equal(t, cmdNouns[6].Use, "start")
+	assert.Equal(t, cmdNouns[6].Short, startShort)
+	assert.Equal(t, cmdNouns[6].Long, startLong)
+
+	assert.Equal(t, cmdNouns[7].Use, "status")
+	assert.Equal(t, cmdNouns[7].Short, statusShort)
+	assert.Equal(t, cmdNouns[7].Long, statusLong)
+
+	assert.Equal(t, cmdNouns[8].Use, "teardown")
+	assert.Equal(t, cmdNouns[8].Short, teardownShort)
+	assert.Equal(t, cmdNouns[8].Long, teardownLong)
 
 }
@@ -0,0 +1,105 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	cmdcore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/sandbox"
+	"github.com/spf13/cobra"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using sphinx.
+const (
+	snapshotShort = "Saves and restores the state of a sandbox."
+	snapshotLong  = `
+Snapshots hold the state of a sandbox cluster, including the registered entities, the executions and the object
+store data. They are kept in $HOME/.flyte/snapshots and can be restored any number of times, which is useful to reset
+demos or CI fixtures to a known state.
+::
+
+ flytectl sandbox snapshot save my-demo
+ flytectl sandbox snapshot restore my-demo
+`
+	snapshotSaveShort = "Saves the state of a sandbox to a snapshot."
+	snapshotSaveLong  = `
+Stops the sandbox container, archives its state to the named snapshot and starts the container again if it was running:
+::
+
+ flytectl sandbox snapshot save my-demo
+
+Snapshots a named sandbox:
+::
+
+ flytectl sandbox snapshot save my-demo --sandbox feature-x
+
+Overwrites an existing snapshot:
+::
+
+ flytectl sandbox snapshot save my-demo --force
+
+Usage
+`
+	snapshotRestoreShort = "Restores a sandbox from a snapshot."
+	snapshotRestoreLong  = `
+Recreates the container of the snapshotted sandbox from the archived state. The existing container of that sandbox is
+removed once confirmed:
+::
+
+ flytectl sandbox snapshot restore my-demo
+
+Restores a snapshot saved with another container runtime than the detected one:
+::
+
+ flytectl sandbox snapshot restore my-demo --runtime podman
+
+Usage
+`
+)
+
+func createSnapshotCommand() *cobra.Command {
+	snapshot := &cobra.Command{
+		Use:   "snapshot",
+		Short: snapshotShort,
+		Long:  snapshotLong,
+	}
+
+	snapshotResourcesFuncs := map[string]cmdcore.CommandEntry{
+		"save": {CmdFunc: saveSnapshot, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: snapshotSaveShort,
+			Long:  snapshotSaveLong, PFlagProvider: sandboxCmdConfig.DefaultSnapshotConfig, DisableFlyteClient: true},
+		"restore": {CmdFunc: restoreSnapshot, Aliases: []string{}, ProjectDomainNotRequired: true,
+			Short: snapshotRestoreShort,
+			Long:  snapshotRestoreLong, PFlagProvider: sandboxCmdConfig.DefaultSnapshotConfig, DisableFlyteClient: true},
+	}
+
+	cmdcore.AddCommands(snapshot, snapshotResourcesFuncs)
+
+	return snapshot
+}
+
+func saveSnapshot(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	if len(args) != 1 {
+		return fmt.Errorf("snapshot name is required. Please check usage examples by running flytectl sandbox snapshot save --help")
+	}
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultSnapshotConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	return sandbox.SaveSnapshot(ctx, cli, sandboxCmdConfig.DefaultSnapshotConfig.Sandbox, args[0],
+		sandboxCmdConfig.DefaultSnapshotConfig.Force)
+}
+
+func restoreSnapshot(ctx context.Context, args []string, cmdCtx cmdcore.CommandContext) error {
+	if len(args) != 1 {
+		return fmt.Errorf("snapshot name is required. Please check usage examples by running flytectl sandbox snapshot restore --help")
+	}
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultSnapshotConfig.Runtime)
+	if err != nil {
+		return err
+	}
+	return sandbox.RestoreSnapshot(ctx, cli, args[0], os.Stdin)
+}
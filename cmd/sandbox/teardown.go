@@ -3,6 +3,7 @@ package sandbox
 import (
 	"context"
 
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
 	"github.com/flyteorg/flytectl/pkg/docker"
 	"github.com/flyteorg/flytectl/pkg/sandbox"
 
@@ -17,15 +18,20 @@ Removes the Sandbox cluster and all the Flyte config created by 'sandbox start':
 
  flytectl sandbox teardown 
 	
+Removes a named sandbox along with its config and kube context:
+::
+
+ flytectl sandbox teardown --name feature-x
+
 
 Usage
 `
 )
 
 func teardownSandboxCluster(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxCmdConfig.DefaultInstanceConfig.Runtime)
 	if err != nil {
 		return err
 	}
-	return sandbox.Teardown(ctx, cli)
+	return sandbox.Teardown(ctx, cli, sandboxCmdConfig.DefaultInstanceConfig.Name)
 }
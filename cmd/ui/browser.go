@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+	"github.com/flyteorg/flytectl/cmd/get"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/ghodss/yaml"
+)
+
+// browser fetches the resources displayed by the terminal UI and runs the actions on executions.
+type browser struct {
+	ctx    context.Context
+	cmdCtx cmdCore.CommandContext
+}
+
+func (b *browser) projects() ([]*admin.Project, error) {
+	projects, err := b.cmdCtx.AdminFetcherExt().ListProjects(b.ctx, filters.DefaultFilter)
+	if err != nil {
+		return nil, err
+	}
+	return projects.Projects, nil
+}
+
+func (b *browser) workflows(project, domain string) ([]string, error) {
+	entities, err := b.cmdCtx.AdminFetcherExt().FetchAllWorkflows(b.ctx, project, domain, filters.DefaultFilter)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		names = append(names, entity.Id.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// executions returns the latest executions of the workflow, newest first.
+func (b *browser) executions(project, domain, workflow string) ([]*admin.Execution, error) {
+	filter := filters.DefaultFilter
+	filter.FieldSelector = fmt.Sprintf("workflow.name=%v", workflow)
+	executions, err := b.cmdCtx.AdminFetcherExt().ListExecution(b.ctx, project, domain, filter)
+	if err != nil {
+		return nil, err
+	}
+	return executions.Executions, nil
+}
+
+func (b *browser) nodes(id *core.WorkflowExecutionIdentifier) ([]*get.NodeExecutionClosure, error) {
+	return get.FetchExecutionDetails(b.ctx, id.Project, id.Domain, id.Name, b.cmdCtx)
+}
+
+func (b *browser) relaunch(id *core.WorkflowExecutionIdentifier) (*core.WorkflowExecutionIdentifier, error) {
+	resp, err := b.cmdCtx.AdminClient().RelaunchExecution(b.ctx, &admin.ExecutionRelaunchRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Id, nil
+}
+
+func (b *browser) recover(id *core.WorkflowExecutionIdentifier) (*core.WorkflowExecutionIdentifier, error) {
+	resp, err := b.cmdCtx.AdminClient().RecoverExecution(b.ctx, &admin.ExecutionRecoverRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Id, nil
+}
+
+func (b *browser) terminate(id *core.WorkflowExecutionIdentifier) error {
+	_, err := b.cmdCtx.AdminClient().TerminateExecution(b.ctx, &admin.ExecutionTerminateRequest{
+		Id:    id,
+		Cause: "terminated from flytectl ui",
+	})
+	return err
+}
+
+// executionDetails describes the execution shown at the root of the node tree.
+func executionDetails(execution *admin.Execution) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Execution: %v\n", execution.Id.Name)
+	fmt.Fprintf(&sb, "Launch plan: %v:%v\n", execution.Spec.GetLaunchPlan().GetName(), execution.Spec.GetLaunchPlan().GetVersion())
+	fmt.Fprintf(&sb, "Phase: %v\n", execution.Closure.GetPhase())
+	if execution.Closure.GetStartedAt() != nil {
+		fmt.Fprintf(&sb, "Started at: %v\n", execution.Closure.StartedAt.AsTime())
+	}
+	if execution.Closure.GetDuration() != nil {
+		fmt.Fprintf(&sb, "Duration: %v\n", execution.Closure.Duration.AsDuration())
+	}
+	if execution.Closure.GetError() != nil {
+		fmt.Fprintf(&sb, "Error: %v\n", execution.Closure.GetError().Message)
+	}
+	return sb.String()
+}
+
+// nodeDetails describes a node execution with its inputs, outputs and the logs of its task attempts.
+func nodeDetails(node *get.NodeExecutionClosure) string {
+	var sb strings.Builder
+	closure := node.NodeExec.Closure
+	fmt.Fprintf(&sb, "Node: %v\n", node.NodeExec.Id.NodeId)
+	fmt.Fprintf(&sb, "Phase: %v\n", closure.GetPhase())
+	if closure.GetStartedAt() != nil {
+		fmt.Fprintf(&sb, "Started at: %v\n", closure.StartedAt.AsTime())
+	}
+	if closure.GetDuration() != nil {
+		fmt.Fprintf(&sb, "Duration: %v\n", closure.Duration.AsDuration())
+	}
+	if closure.GetError() != nil {
+		fmt.Fprintf(&sb, "Error: %v\n", closure.GetError().Message)
+	}
+	writeLiterals(&sb, "Inputs", node.Inputs)
+	writeLiterals(&sb, "Outputs", node.Outputs)
+	for _, taskExec := range node.TaskExecutions {
+		fmt.Fprintf(&sb, "\nAttempt %v: %v\n", taskExec.Id.RetryAttempt, taskExec.Closure.GetPhase())
+		for _, log := range taskExec.Closure.GetLogs() {
+			fmt.Fprintf(&sb, "  %v: %v\n", log.Name, log.Uri)
+		}
+	}
+	return sb.String()
+}
+
+func writeLiterals(sb *strings.Builder, title string, literals map[string]interface{}) {
+	if len(literals) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n%v:\n", title)
+	content, err := yaml.Marshal(literals)
+	if err != nil {
+		fmt.Fprintf(sb, "  %v\n", err)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+		fmt.Fprintf(sb, "  %v\n", line)
+	}
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/get"
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var executionID = &core.WorkflowExecutionIdentifier{Project: "dummyProject", Domain: "dummyDomain", Name: "exec1"}
+
+func TestBrowserWorkflows(t *testing.T) {
+	s := setup()
+	b := &browser{ctx: s.Ctx, cmdCtx: s.CmdCtx}
+	s.FetcherExt.OnFetchAllWorkflowsMatch(s.Ctx, "dummyProject", "dummyDomain", filters.DefaultFilter).Return([]*admin.NamedEntity{
+		{Id: &admin.NamedEntityIdentifier{Name: "wf_b"}},
+		{Id: &admin.NamedEntityIdentifier{Name: "wf_a"}},
+	}, nil)
+	workflows, err := b.workflows("dummyProject", "dummyDomain")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"wf_a", "wf_b"}, workflows)
+}
+
+func TestBrowserExecutions(t *testing.T) {
+	s := setup()
+	b := &browser{ctx: s.Ctx, cmdCtx: s.CmdCtx}
+	s.FetcherExt.OnListExecutionMatch(s.Ctx, "dummyProject", "dummyDomain", mock.MatchedBy(func(filter filters.Filters) bool {
+		return filter.FieldSelector == "workflow.name=wf_a"
+	})).Return(&admin.ExecutionList{Executions: []*admin.Execution{{Id: executionID}}}, nil)
+	executions, err := b.executions("dummyProject", "dummyDomain", "wf_a")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(executions))
+}
+
+func TestBrowserActions(t *testing.T) {
+	relaunchedID := &core.WorkflowExecutionIdentifier{Project: "dummyProject", Domain: "dummyDomain", Name: "exec2"}
+	t.Run("relaunch", func(t *testing.T) {
+		s := setup()
+		b := &browser{ctx: s.Ctx, cmdCtx: s.CmdCtx}
+		s.MockAdminClient.OnRelaunchExecutionMatch(s.Ctx, &admin.ExecutionRelaunchRequest{Id: executionID}).Return(
+			&admin.ExecutionCreateResponse{Id: relaunchedID}, nil)
+		id, err := b.relaunch(executionID)
+		assert.Nil(t, err)
+		assert.Equal(t, relaunchedID, id)
+	})
+	t.Run("recover", func(t *testing.T) {
+		s := setup()
+		b := &browser{ctx: s.Ctx, cmdCtx: s.CmdCtx}
+		s.MockAdminClient.OnRecoverExecutionMatch(s.Ctx, &admin.ExecutionRecoverRequest{Id: executionID}).Return(nil, fmt.Errorf("failed"))
+		_, err := b.recover(executionID)
+		assert.EqualError(t, err, "failed")
+	})
+	t.Run("terminate", func(t *testing.T) {
+		s := setup()
+		b := &browser{ctx: s.Ctx, cmdCtx: s.CmdCtx}
+		s.MockAdminClient.OnTerminateExecutionMatch(s.Ctx, mock.MatchedBy(func(request *admin.ExecutionTerminateRequest) bool {
+			return request.Id == executionID
+		})).Return(&admin.ExecutionTerminateResponse{}, nil)
+		assert.Nil(t, b.terminate(executionID))
+	})
+}
+
+func TestNodeDetails(t *testing.T) {
+	node := &get.NodeExecutionClosure{
+		NodeExec: &get.NodeExecution{NodeExecution: &admin.NodeExecution{
+			Id: &core.NodeExecutionIdentifier{NodeId: "n0"},
+			Closure: &admin.NodeExecutionClosure{
+				Phase:    core.NodeExecution_SUCCEEDED,
+				Duration: durationpb.New(90e9),
+			},
+		}},
+		Inputs:  map[string]interface{}{"x": 1},
+		Outputs: map[string]interface{}{"o0": "done"},
+		TaskExecutions: []*get.TaskExecutionClosure{{TaskExecution: &get.TaskExecution{TaskExecution: &admin.TaskExecution{
+			Id: &core.TaskExecutionIdentifier{RetryAttempt: 0},
+			Closure: &admin.TaskExecutionClosure{
+				Phase: core.TaskExecution_SUCCEEDED,
+				Logs:  []*core.TaskLog{{Name: "Kubernetes Logs", Uri: "http://logs/n0"}},
+			},
+		}}}},
+	}
+	assert.Equal(t, `Node: n0
+Phase: SUCCEEDED
+Duration: 1m30s
+
+Inputs:
+  x: 1
+
+Outputs:
+  o0: done
+
+Attempt 0: SUCCEEDED
+  Kubernetes Logs: http://logs/n0
+`, nodeDetails(node))
+}
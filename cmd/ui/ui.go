@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytectl/cmd/config"
+	cmdCore "github.com/flyteorg/flytectl/cmd/core"
+)
+
+// Long descriptions are whitespace sensitive when generating docs using sphinx.
+const (
+	uiShort = "Browse projects, workflows and executions in a terminal UI."
+	uiLong  = `
+Open an interactive terminal UI to drill down from projects to domains, workflows, executions and the node tree of an
+execution, along with the inputs, outputs and task logs of every node.
+::
+
+ flytectl ui
+
+Start directly from the workflows of a project and domain:
+::
+
+ flytectl ui -p flytesnacks -d development
+
+Keyboard actions:
+
+- enter: open the selected item
+- esc: go back to the previous view
+- r: relaunch the selected execution
+- R: recover the selected execution from its last known failure point
+- t: terminate the selected execution
+- q: quit
+
+Relaunching, recovering and terminating an execution ask for a confirmation.
+
+Usage
+`
+)
+
+// CreateUICommand will return the ui command
+func CreateUICommand() map[string]cmdCore.CommandEntry {
+	uiResourcesFuncs := map[string]cmdCore.CommandEntry{
+		"ui": {
+			CmdFunc:                  launchUI,
+			Short:                    uiShort,
+			Long:                     uiLong,
+			ProjectDomainNotRequired: true,
+		},
+	}
+	return uiResourcesFuncs
+}
+
+func launchUI(ctx context.Context, args []string, cmdCtx cmdCore.CommandContext) error {
+	v := newView(&browser{ctx: ctx, cmdCtx: cmdCtx})
+	if err := v.start(config.GetConfig().Project, config.GetConfig().Domain); err != nil {
+		return err
+	}
+	return v.app.Run()
+}
@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flytectl/cmd/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var setup = testutils.Setup
+
+func TestCreateUICommand(t *testing.T) {
+	uiCommand := CreateUICommand()
+	assert.Equal(t, 1, len(uiCommand))
+	entry := uiCommand["ui"]
+	assert.Equal(t, uiShort, entry.Short)
+	assert.Equal(t, uiLong, entry.Long)
+	assert.True(t, entry.ProjectDomainNotRequired)
+	assert.NotNil(t, entry.CmdFunc)
+}
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/flyteorg/flytectl/cmd/get"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	projectsPage   = "projects"
+	domainsPage    = "domains"
+	workflowsPage  = "workflows"
+	executionsPage = "executions"
+	executionPage  = "execution"
+	confirmPage    = "confirm"
+
+	keyHints = "enter: open  esc: back  r: relaunch  R: recover  t: terminate  q: quit"
+)
+
+// view holds the pages of the terminal UI. Every page drills down into the item selected in the previous one.
+type view struct {
+	browser *browser
+	app     *tview.Application
+	pages   *tview.Pages
+	status  *tview.TextView
+	// history holds the names of the opened pages, the last one being displayed.
+	history []string
+	// selections holds the execution which the keyboard actions apply to, for the pages showing executions.
+	selections map[string]*admin.Execution
+}
+
+func newView(b *browser) *view {
+	v := &view{
+		browser:    b,
+		app:        tview.NewApplication(),
+		pages:      tview.NewPages(),
+		status:     tview.NewTextView().SetDynamicColors(true),
+		selections: map[string]*admin.Execution{},
+	}
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.pages, 0, 1, true).
+		AddItem(v.status, 1, 0, false)
+	v.app.SetRoot(layout, true).SetInputCapture(v.handleKey)
+	v.setStatus("")
+	return v
+}
+
+// start opens the projects page, and drills down to the given project and domain if any.
+func (v *view) start(project, domain string) error {
+	projects, err := v.browser.projects()
+	if err != nil {
+		return err
+	}
+	v.showProjects(projects)
+	if len(project) == 0 {
+		return nil
+	}
+	for _, p := range projects {
+		if p.Id == project {
+			v.showDomains(p)
+			if len(domain) == 0 {
+				return nil
+			}
+			return v.showWorkflows(project, domain)
+		}
+	}
+	return fmt.Errorf("project %v not found", project)
+}
+
+func (v *view) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if name, _ := v.pages.GetFrontPage(); name == confirmPage {
+		return event
+	}
+	switch event.Key() {
+	case tcell.KeyEscape:
+		v.back()
+		return nil
+	case tcell.KeyRune:
+		execution := v.selections[v.currentPage()]
+		switch event.Rune() {
+		case 'q':
+			v.app.Stop()
+			return nil
+		case 'r':
+			if execution != nil {
+				v.confirm(fmt.Sprintf("Relaunch execution %v?", execution.Id.Name), func() error {
+					id, err := v.browser.relaunch(execution.Id)
+					if err == nil {
+						v.setStatus(fmt.Sprintf("relaunched %v as %v", execution.Id.Name, id.Name))
+					}
+					return err
+				})
+			}
+			return nil
+		case 'R':
+			if execution != nil {
+				v.confirm(fmt.Sprintf("Recover execution %v?", execution.Id.Name), func() error {
+					id, err := v.browser.recover(execution.Id)
+					if err == nil {
+						v.setStatus(fmt.Sprintf("recovered %v as %v", execution.Id.Name, id.Name))
+					}
+					return err
+				})
+			}
+			return nil
+		case 't':
+			if execution != nil {
+				v.confirm(fmt.Sprintf("Terminate execution %v?", execution.Id.Name), func() error {
+					err := v.browser.terminate(execution.Id)
+					if err == nil {
+						v.setStatus(fmt.Sprintf("terminated %v", execution.Id.Name))
+					}
+					return err
+				})
+			}
+			return nil
+		}
+	}
+	return event
+}
+
+func (v *view) showProjects(projects []*admin.Project) {
+	list := newList("Projects")
+	for _, project := range projects {
+		project := project
+		list.AddItem(project.Name, project.Id, 0, func() {
+			v.showDomains(project)
+		})
+	}
+	v.push(projectsPage, list)
+}
+
+func (v *view) showDomains(project *admin.Project) {
+	list := newList(fmt.Sprintf("Domains of %v", project.Id))
+	for _, domain := range project.Domains {
+		domain := domain
+		list.AddItem(domain.Name, domain.Id, 0, func() {
+			if err := v.showWorkflows(project.Id, domain.Id); err != nil {
+				v.setError(err)
+			}
+		})
+	}
+	v.push(domainsPage, list)
+}
+
+func (v *view) showWorkflows(project, domain string) error {
+	workflows, err := v.browser.workflows(project, domain)
+	if err != nil {
+		return err
+	}
+	list := newList(fmt.Sprintf("Workflows in %v/%v", project, domain))
+	for _, workflow := range workflows {
+		workflow := workflow
+		list.AddItem(workflow, "", 0, func() {
+			if err := v.showExecutions(project, domain, workflow); err != nil {
+				v.setError(err)
+			}
+		})
+	}
+	v.push(workflowsPage, list)
+	return nil
+}
+
+func (v *view) showExecutions(project, domain, workflow string) error {
+	executions, err := v.browser.executions(project, domain, workflow)
+	if err != nil {
+		return err
+	}
+	list := newList(fmt.Sprintf("Executions of %v", workflow))
+	for _, execution := range executions {
+		execution := execution
+		list.AddItem(execution.Id.Name, fmt.Sprintf("%v - %v", execution.Closure.GetPhase(), execution.Closure.GetStartedAt().AsTime()),
+			0, func() {
+				if err := v.showExecution(execution); err != nil {
+					v.setError(err)
+				}
+			})
+	}
+	list.SetChangedFunc(func(index int, _, _ string, _ rune) {
+		v.selections[executionsPage] = executions[index]
+	})
+	v.selections[executionsPage] = nil
+	if len(executions) > 0 {
+		v.selections[executionsPage] = executions[0]
+	}
+	v.push(executionsPage, list)
+	return nil
+}
+
+func (v *view) showExecution(execution *admin.Execution) error {
+	nodes, err := v.browser.nodes(execution.Id)
+	if err != nil {
+		return err
+	}
+	root := tview.NewTreeNode(fmt.Sprintf("%v - %v", execution.Id.Name, execution.Closure.GetPhase())).SetReference(execution)
+	addNodes(root, nodes)
+	details := tview.NewTextView().SetWordWrap(true).SetText(executionDetails(execution))
+	details.SetBorder(true).SetTitle(" Details ")
+	tree := tview.NewTreeView().SetRoot(root).SetCurrentNode(root)
+	tree.SetBorder(true).SetTitle(" Nodes ")
+	tree.SetChangedFunc(func(node *tview.TreeNode) {
+		switch reference := node.GetReference().(type) {
+		case *admin.Execution:
+			details.SetText(executionDetails(reference))
+		case *get.NodeExecutionClosure:
+			details.SetText(nodeDetails(reference))
+		}
+		details.ScrollToBeginning()
+	})
+	tree.SetSelectedFunc(func(node *tview.TreeNode) {
+		node.SetExpanded(!node.IsExpanded())
+	})
+	v.selections[executionPage] = execution
+	v.push(executionPage, tview.NewFlex().
+		AddItem(tree, 0, 1, true).
+		AddItem(details, 0, 2, false))
+	return nil
+}
+
+func addNodes(parent *tview.TreeNode, nodes []*get.NodeExecutionClosure) {
+	for _, node := range nodes {
+		child := tview.NewTreeNode(fmt.Sprintf("%v - %v", node.NodeExec.Id.NodeId, node.NodeExec.Closure.GetPhase())).
+			SetReference(node)
+		addNodes(child, node.ChildNodes)
+		parent.AddChild(child)
+	}
+}
+
+func newList(title string) *tview.List {
+	list := tview.NewList()
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" %v ", title))
+	return list
+}
+
+func (v *view) push(name string, page tview.Primitive) {
+	v.pages.AddAndSwitchToPage(name, page, true)
+	v.history = append(v.history, name)
+	v.app.SetFocus(page)
+	v.setStatus("")
+}
+
+// back closes the displayed page and returns to the previous one.
+func (v *view) back() {
+	if len(v.history) <= 1 {
+		return
+	}
+	v.pages.RemovePage(v.currentPage())
+	delete(v.selections, v.currentPage())
+	v.history = v.history[:len(v.history)-1]
+	v.pages.SwitchToPage(v.currentPage())
+	v.focusFront()
+	v.setStatus("")
+}
+
+func (v *view) currentPage() string {
+	if len(v.history) == 0 {
+		return ""
+	}
+	return v.history[len(v.history)-1]
+}
+
+// confirm asks for a confirmation before running the action on the selected execution.
+func (v *view) confirm(text string, action func() error) {
+	modal := tview.NewModal().SetText(text).AddButtons([]string{"Yes", "No"})
+	modal.SetDoneFunc(func(_ int, label string) {
+		v.pages.RemovePage(confirmPage)
+		v.focusFront()
+		if label != "Yes" {
+			return
+		}
+		if err := action(); err != nil {
+			v.setError(err)
+		}
+	})
+	v.pages.AddPage(confirmPage, modal, false, true)
+	v.app.SetFocus(modal)
+}
+
+func (v *view) focusFront() {
+	if _, page := v.pages.GetFrontPage(); page != nil {
+		v.app.SetFocus(page)
+	}
+}
+
+func (v *view) setStatus(message string) {
+	if len(message) == 0 {
+		v.status.SetText(keyHints)
+		return
+	}
+	v.status.SetText(fmt.Sprintf("%v  |  %v", tview.Escape(message), keyHints))
+}
+
+func (v *view) setError(err error) {
+	v.status.SetText(fmt.Sprintf("[red]%v[-]  |  %v", tview.Escape(err.Error()), keyHints))
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flytectl/pkg/filters"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestViewNavigation(t *testing.T) {
+	s := setup()
+	v := newView(&browser{ctx: s.Ctx, cmdCtx: s.CmdCtx})
+	s.FetcherExt.OnListProjectsMatch(s.Ctx, mock.Anything).Return(&admin.Projects{Projects: []*admin.Project{{
+		Id:      "dummyProject",
+		Name:    "Dummy Project",
+		Domains: []*admin.Domain{{Id: "dummyDomain", Name: "Dummy Domain"}},
+	}}}, nil)
+	s.FetcherExt.OnFetchAllWorkflowsMatch(s.Ctx, "dummyProject", "dummyDomain", filters.DefaultFilter).Return([]*admin.NamedEntity{
+		{Id: &admin.NamedEntityIdentifier{Name: "wf_a"}},
+	}, nil)
+	s.FetcherExt.OnListExecutionMatch(s.Ctx, "dummyProject", "dummyDomain", mock.Anything).Return(&admin.ExecutionList{
+		Executions: []*admin.Execution{{Id: executionID}},
+	}, nil)
+
+	assert.Nil(t, v.start("dummyProject", "dummyDomain"))
+	assert.Equal(t, []string{projectsPage, domainsPage, workflowsPage}, v.history)
+
+	assert.Nil(t, v.showExecutions("dummyProject", "dummyDomain", "wf_a"))
+	assert.Equal(t, executionID, v.selections[executionsPage].Id)
+
+	v.back()
+	v.back()
+	assert.Equal(t, []string{projectsPage, domainsPage}, v.history)
+	assert.Nil(t, v.selections[executionsPage])
+	name, _ := v.pages.GetFrontPage()
+	assert.Equal(t, domainsPage, name)
+
+	v.back()
+	v.back()
+	assert.Equal(t, []string{projectsPage}, v.history)
+}
+
+func TestViewStartUnknownProject(t *testing.T) {
+	s := setup()
+	v := newView(&browser{ctx: s.Ctx, cmdCtx: s.CmdCtx})
+	s.FetcherExt.OnListProjectsMatch(s.Ctx, mock.Anything).Return(&admin.Projects{}, nil)
+	assert.EqualError(t, v.start("unknown", ""), "project unknown not found")
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"runtime"
-	"strings"
 
 	"github.com/flyteorg/flytectl/pkg/util"
 
@@ -17,6 +16,7 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/mouuff/go-rocket-update/pkg/updater"
 
+	upgradeConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/upgrade"
 	cmdCore "github.com/flyteorg/flytectl/cmd/core"
 	"github.com/flyteorg/flytectl/pkg/platformutil"
 	"github.com/spf13/cobra"
@@ -36,6 +36,25 @@ For Flytectl, it is:
 .. note::
 	Please upgrade with sudo. Failing to do so may result in a permission issues.
 	
+Upgrade or downgrade to a specific version:
+::
+
+ flytectl upgrade --version v0.5.0
+
+Upgrade or downgrade to the latest patch of a minor version, x or * matching any version segment. Prereleases are
+only considered with the prerelease channel:
+::
+
+ flytectl upgrade --version v0.5.x
+
+Upgrade to the latest prerelease:
+::
+
+ flytectl upgrade --channel prerelease
+
+The SHA256 checksum of the downloaded archive is verified against the checksums file of the release before the binary
+is replaced, and the upgrade fails on a mismatch.
+
 Rollback Flytectl binary:
 ::
 
@@ -61,6 +80,7 @@ func SelfUpgrade(rootCmd *cobra.Command) map[string]cmdCore.CommandEntry {
 			Short:                    upgradeCmdShort,
 			Long:                     upgradeCmdLong,
 			DisableFlyteClient:       true,
+			PFlagProvider:            upgradeConfig.DefaultConfig,
 		},
 	}
 	return getResourcesFuncs
@@ -83,13 +103,18 @@ func selfUpgrade(ctx context.Context, args []string, cmdCtx cmdCore.CommandConte
 		return github.FlytectlReleaseConfig.Rollback()
 	}
 
-	if isSupported, err := isUpgradeSupported(goos); err != nil {
+	g := github.GetGHRepoService()
+	release, err := github.GetFlytectlRelease(upgradeConfig.DefaultConfig.Version, upgradeConfig.DefaultConfig.Channel, g)
+	if err != nil {
+		return err
+	}
+	if isSupported, err := isUpgradeSupported(goos, release.GetTagName(), len(upgradeConfig.DefaultConfig.Version) > 0); err != nil {
 		return err
 	} else if !isSupported {
 		return nil
 	}
 
-	if message, err := upgrade(github.FlytectlReleaseConfig); err != nil {
+	if message, err := upgrade(github.NewFlytectlUpdater(release, g)); err != nil {
 		return err
 	} else if len(message) > 0 {
 		logger.Info(ctx, message)
@@ -113,24 +138,30 @@ func upgrade(u *updater.Updater) (string, error) {
 	return "", nil
 }
 
-func isUpgradeSupported(goos platformutil.Platform) (bool, error) {
-	latest, err := github.FlytectlReleaseConfig.GetLatestVersion()
-	if err != nil {
-		return false, err
-	}
-
-	if isGreater, err := util.IsVersionGreaterThan(latest, stdlibversion.Version); err != nil {
+// isUpgradeSupported checks that the binary can be replaced with the one of the version. A pinned version can be older
+// than the current one, whereas the latest version of a channel must be newer.
+func isUpgradeSupported(goos platformutil.Platform, version string, pinned bool) (bool, error) {
+	if pinned {
+		if version == stdlibversion.Version {
+			fmt.Printf("You already have the version %s of Flytectl\n", version)
+			return false, nil
+		}
+	} else if isGreater, err := util.IsVersionGreaterThan(version, stdlibversion.Version); err != nil {
 		return false, err
 	} else if !isGreater {
 		fmt.Println("You already have the latest version of Flytectl")
 		return false, nil
 	}
 
-	message, err := github.GetUpgradeMessage(latest, goos)
+	message, err := github.GetUpgradeMessage(version, goos)
+	if err != nil {
+		return false, err
+	}
+	symlink, err := github.CheckBrewInstall(goos)
 	if err != nil {
 		return false, err
 	}
-	if goos.String() == platformutil.Windows.String() || strings.Contains(message, "brew") {
+	if goos.String() == platformutil.Windows.String() || len(symlink) > 0 {
 		if len(message) > 0 {
 			fmt.Println(message)
 		}
@@ -1,9 +1,23 @@
 package upgrade
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"sort"
+	"strings"
 	"testing"
 
+	upgradeConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/upgrade"
+	"github.com/flyteorg/flytectl/pkg/github/mocks"
+	gh "github.com/google/go-github/v42/github"
+	"github.com/stretchr/testify/mock"
+
 	"github.com/flyteorg/flytectl/cmd/testutils"
 
 	"github.com/flyteorg/flytectl/pkg/github"
@@ -44,15 +58,68 @@ func TestUpgradeCommand(t *testing.T) {
 	assert.Equal(t, cmdNouns[0].Long, upgradeCmdLong)
 }
 
-//
+// mockFlytectlRelease mocks the flytectl release of the tag, whose archive holds a binary printing the tag and is
+// listed in the checksums file of the release with the checksum.
+func mockFlytectlRelease(t *testing.T, tag string, checksum func(archive []byte) string) *mocks.GHRepoService {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	binary := "flytectl " + tag
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "flytectl", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(binary))}))
+	_, err := tw.Write([]byte(binary))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gz.Close())
+	archive := buf.Bytes()
+
+	archiveID, checksumsID := int64(1), int64(2)
+	archiveName, checksumsName := github.FlytectlAssetName, "checksums.txt"
+	release := &gh.RepositoryRelease{TagName: &tag, Assets: []*gh.ReleaseAsset{
+		{ID: &archiveID, Name: &archiveName},
+		{ID: &checksumsID, Name: &checksumsName},
+	}}
+	mockGh := &mocks.GHRepoService{}
+	mockGh.OnGetLatestReleaseMatch(mock.Anything, "flyteorg", "flytectl").Return(release, nil, nil)
+	mockGh.OnGetReleaseByTagMatch(mock.Anything, "flyteorg", "flytectl", tag).Return(release, nil, nil)
+	mockGh.OnListReleasesMatch(mock.Anything, "flyteorg", "flytectl", mock.Anything).Return([]*gh.RepositoryRelease{release}, nil, nil)
+	mockGh.OnDownloadReleaseAssetMatch(mock.Anything, "flyteorg", "flytectl", archiveID, mock.Anything).Return(
+		ioutil.NopCloser(bytes.NewReader(archive)), "", nil)
+	mockGh.OnDownloadReleaseAssetMatch(mock.Anything, "flyteorg", "flytectl", checksumsID, mock.Anything).Return(
+		ioutil.NopCloser(strings.NewReader(fmt.Sprintf("%v  %v\n", checksum(archive), archiveName))), "", nil)
+	return mockGh
+}
+
+func validChecksum(archive []byte) string {
+	sum := sha256.Sum256(archive)
+	return hex.EncodeToString(sum[:])
+}
+
 func TestUpgrade(t *testing.T) {
-	_ = util.WriteIntoFile([]byte("data"), tempExt)
 	stdlibversion.Version = version
 	github.FlytectlReleaseConfig.OverrideExecutable = tempExt
 	t.Run("Successful upgrade", func(t *testing.T) {
-		message, err := upgrade(github.FlytectlReleaseConfig)
+		_ = util.WriteIntoFile([]byte("data"), tempExt)
+		mockGh := mockFlytectlRelease(t, "v0.3.0", validChecksum)
+		release, err := github.GetFlytectlRelease("", github.ChannelStable, mockGh)
+		assert.Nil(t, err)
+		message, err := upgrade(github.NewFlytectlUpdater(release, mockGh))
 		assert.Nil(t, err)
-		assert.Contains(t, message, "Successfully updated to version")
+		assert.Equal(t, "Successfully updated to version v0.3.0", message)
+		content, err := os.ReadFile(tempExt)
+		assert.Nil(t, err)
+		assert.Equal(t, "flytectl v0.3.0", string(content))
+	})
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		_ = util.WriteIntoFile([]byte("data"), tempExt)
+		mockGh := mockFlytectlRelease(t, "v0.3.0", func([]byte) string { return validChecksum([]byte("another archive")) })
+		release, err := github.GetFlytectlRelease("", github.ChannelStable, mockGh)
+		assert.Nil(t, err)
+		_, err = upgrade(github.NewFlytectlUpdater(release, mockGh))
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+		content, err := os.ReadFile(tempExt)
+		assert.Nil(t, err)
+		assert.Equal(t, "data", string(content))
 	})
 }
 
@@ -75,30 +142,41 @@ func TestIsUpgradeable(t *testing.T) {
 	linux := platformutil.Linux
 	windows := platformutil.Windows
 	darwin := platformutil.Darwin
+	latest := "v0.3.0"
 	t.Run("IsUpgradeable on linux", func(t *testing.T) {
-		check, err := isUpgradeSupported(linux)
+		check, err := isUpgradeSupported(linux, latest, false)
 		assert.Nil(t, err)
 		assert.Equal(t, true, check)
 	})
 	t.Run("IsUpgradeable on darwin", func(t *testing.T) {
-		check, err := isUpgradeSupported(darwin)
+		check, err := isUpgradeSupported(darwin, latest, false)
 		assert.Nil(t, err)
 		assert.Equal(t, true, check)
 	})
-	t.Run("IsUpgradeable on darwin using brew", func(t *testing.T) {
-		check, err := isUpgradeSupported(darwin)
+	t.Run("Already the latest version", func(t *testing.T) {
+		check, err := isUpgradeSupported(linux, version, false)
+		assert.Nil(t, err)
+		assert.Equal(t, false, check)
+	})
+	t.Run("Downgrade to a pinned version", func(t *testing.T) {
+		check, err := isUpgradeSupported(linux, "v0.1.0", true)
 		assert.Nil(t, err)
 		assert.Equal(t, true, check)
 	})
+	t.Run("Already the pinned version", func(t *testing.T) {
+		check, err := isUpgradeSupported(linux, version, true)
+		assert.Nil(t, err)
+		assert.Equal(t, false, check)
+	})
 	t.Run("isUpgradeSupported failed", func(t *testing.T) {
 		stdlibversion.Version = "v"
-		check, err := isUpgradeSupported(linux)
+		check, err := isUpgradeSupported(linux, latest, false)
 		assert.NotNil(t, err)
 		assert.Equal(t, false, check)
 		stdlibversion.Version = version
 	})
 	t.Run("isUpgradeSupported windows", func(t *testing.T) {
-		check, err := isUpgradeSupported(windows)
+		check, err := isUpgradeSupported(windows, latest, false)
 		assert.Nil(t, err)
 		assert.Equal(t, false, check)
 	})
@@ -108,13 +186,40 @@ func TestSelfUpgrade(t *testing.T) {
 	stdlibversion.Version = version
 	github.FlytectlReleaseConfig.OverrideExecutable = tempExt
 	goos = platformutil.Linux
+	defer func() {
+		github.Client = nil
+		upgradeConfig.DefaultConfig.Version = ""
+		upgradeConfig.DefaultConfig.Channel = github.ChannelStable
+	}()
 	t.Run("Successful upgrade", func(t *testing.T) {
 		s := testutils.Setup()
 		stdlibversion.Build = ""
 		stdlibversion.BuildTime = ""
 		stdlibversion.Version = version
+		github.Client = mockFlytectlRelease(t, "v0.3.0", validChecksum)
+
+		assert.Nil(t, selfUpgrade(s.Ctx, []string{}, s.CmdCtx))
+	})
+	t.Run("Successful upgrade from the prerelease channel", func(t *testing.T) {
+		s := testutils.Setup()
+		stdlibversion.Version = version
+		upgradeConfig.DefaultConfig.Channel = github.ChannelPrerelease
+		github.Client = mockFlytectlRelease(t, "v0.3.0-b0", validChecksum)
+
+		assert.Nil(t, selfUpgrade(s.Ctx, []string{}, s.CmdCtx))
+		upgradeConfig.DefaultConfig.Channel = github.ChannelStable
+	})
+	t.Run("Successful downgrade to a pinned version", func(t *testing.T) {
+		s := testutils.Setup()
+		stdlibversion.Version = version
+		upgradeConfig.DefaultConfig.Version = "v0.1.0"
+		github.Client = mockFlytectlRelease(t, "v0.1.0", validChecksum)
 
 		assert.Nil(t, selfUpgrade(s.Ctx, []string{}, s.CmdCtx))
+		content, err := os.ReadFile(tempExt)
+		assert.Nil(t, err)
+		assert.Equal(t, "flytectl v0.1.0", string(content))
+		upgradeConfig.DefaultConfig.Version = ""
 	})
 }
 
@@ -122,15 +227,25 @@ func TestSelfUpgradeError(t *testing.T) {
 	stdlibversion.Version = version
 	github.FlytectlReleaseConfig.OverrideExecutable = tempExt
 	goos = platformutil.Linux
+	defer func() { github.Client = nil }()
 	t.Run("Successful upgrade", func(t *testing.T) {
 		s := testutils.Setup()
 		stdlibversion.Build = ""
 		stdlibversion.BuildTime = ""
 		stdlibversion.Version = "v"
+		github.Client = mockFlytectlRelease(t, "v0.3.0", validChecksum)
 
 		assert.NotNil(t, selfUpgrade(s.Ctx, []string{}, s.CmdCtx))
 	})
+	t.Run("Unsupported channel", func(t *testing.T) {
+		s := testutils.Setup()
+		stdlibversion.Version = version
+		upgradeConfig.DefaultConfig.Channel = "nightly"
+		defer func() { upgradeConfig.DefaultConfig.Channel = github.ChannelStable }()
+		github.Client = mockFlytectlRelease(t, "v0.3.0", validChecksum)
 
+		assert.NotNil(t, selfUpgrade(s.Ctx, []string{}, s.CmdCtx))
+	})
 }
 
 func TestSelfUpgradeRollback(t *testing.T) {
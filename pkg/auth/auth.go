@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytectl/pkg/pkce"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flyteidl/clients/go/admin/cache"
+	"github.com/flyteorg/flyteidl/clients/go/admin/deviceflow"
+	adminPkce "github.com/flyteorg/flyteidl/clients/go/admin/pkce"
+	"github.com/flyteorg/flyteidl/clients/go/admin/tokenorchestrator"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+// TokenCache is a cache of the tokens of an admin endpoint which can be cleared
+type TokenCache interface {
+	cache.TokenCache
+	DeleteToken() error
+}
+
+// ErrNoToken is returned when no token of the admin endpoint is cached
+var ErrNoToken = pkce.ErrTokenNotFound
+
+// newAuthMetadataClient is replaced by the tests to fake the auth metadata service of admin
+var newAuthMetadataClient = admin.InitializeAuthMetadataClient
+
+// NewTokenCache returns the cache of the tokens of the admin endpoint for the config context. The tokenCache.type
+// setting selects the keyring of the OS or the encrypted file, the auto type falling back to the file when the keyring
+// is unavailable.
+func NewTokenCache(cfg *admin.Config, contextName string) (TokenCache, error) {
+	serviceUser := pkce.KeyRingServiceUserFor(cfg.Endpoint.String(), contextName)
+	keyringCache := pkce.TokenCacheKeyringProvider{
+		ServiceUser: serviceUser,
+		ServiceName: pkce.KeyRingServiceName,
+	}
+	cacheConfig := GetConfig()
+	fileCache := pkce.TokenCacheFileProvider{
+		Path:        cacheConfig.Path,
+		ServiceUser: serviceUser,
+	}
+	if len(fileCache.Path) == 0 {
+		fileCache.Path = pkce.DefaultTokenCacheFile
+	}
+	switch strings.ToLower(cacheConfig.Type) {
+	case TokenCacheTypeKeyring:
+		return keyringCache, nil
+	case TokenCacheTypeFile:
+		return fileCache, nil
+	case "", TokenCacheTypeAuto:
+	default:
+		return nil, fmt.Errorf("unsupported tokenCache.type %q. Supported types are %v, %v and %v", cacheConfig.Type,
+			TokenCacheTypeAuto, TokenCacheTypeKeyring, TokenCacheTypeFile)
+	}
+	// The keyring is unavailable if it fails other than for a missing token, e.g. without a D-Bus secret service.
+	if _, err := keyring.Get(keyringCache.ServiceName, keyringCache.ServiceUser); err != nil &&
+		!errors.Is(err, keyring.ErrNotFound) {
+		logger.Debugf(context.Background(), "keyring unavailable, caching the tokens in %v: %v", fileCache.Path, err)
+		return fileCache, nil
+	}
+	return keyringCache, nil
+}
+
+// Session manages the tokens the admin endpoint is authenticated with. Only the Pkce and DeviceFlow auth types cache
+// their tokens, the ClientSecret and ExternalCommand ones getting a new token for every command.
+type Session struct {
+	Config *admin.Config
+	Cache  TokenCache
+}
+
+// NewSession returns the session of the admin endpoint of the config context
+func NewSession(cfg *admin.Config, contextName string) (*Session, error) {
+	cache, err := NewTokenCache(cfg, contextName)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Config: cfg, Cache: cache}, nil
+}
+
+// CachesTokens tells whether the auth type of the session caches its tokens
+func (s *Session) CachesTokens() bool {
+	return s.Config.AuthType == admin.AuthTypePkce || s.Config.AuthType == admin.AuthTypeDeviceFlow
+}
+
+// Login runs the auth flow even if a valid token is cached, and caches the new token
+func (s *Session) Login(ctx context.Context) (*oauth2.Token, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if !s.CachesTokens() {
+		return s.newToken(ctx)
+	}
+	// The cached token is only replaced once the flow succeeds, for a failed login to leave the session as is.
+	orchestrator, err := s.baseTokenOrchestrator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.Config.AuthType == admin.AuthTypePkce {
+		pkceOrchestrator, err := adminPkce.NewTokenOrchestrator(orchestrator, s.Config.PkceConfig)
+		if err != nil {
+			return nil, err
+		}
+		return pkceOrchestrator.FetchTokenFromAuthFlow(ctx)
+	}
+	deviceFlowOrchestrator, err := deviceflow.NewDeviceFlowTokenOrchestrator(orchestrator, s.Config.DeviceFlowConfig)
+	if err != nil {
+		return nil, err
+	}
+	return deviceFlowOrchestrator.FetchTokenFromAuthFlow(ctx)
+}
+
+// Logout deletes the cached token
+func (s *Session) Logout() error {
+	err := s.Cache.DeleteToken()
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNoToken
+	}
+	return err
+}
+
+// CachedToken returns the cached token, which may be expired. ErrNoToken is only returned when no token is cached, the
+// errors reading the cache being returned as is.
+func (s *Session) CachedToken() (*oauth2.Token, error) {
+	token, err := s.Cache.GetToken()
+	if errors.Is(err, ErrNoToken) || errors.Is(err, keyring.ErrNotFound) || (err == nil && token == nil) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Token returns a valid access token. The cached token is refreshed with its refresh token when it expires within the
+// refresh grace period, even if it is already expired.
+func (s *Session) Token(ctx context.Context) (*oauth2.Token, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if !s.CachesTokens() {
+		return s.newToken(ctx)
+	}
+	token, err := s.CachedToken()
+	if err != nil {
+		return nil, fmt.Errorf("%w for %v. Please run flytectl auth login", err, s.Config.Endpoint.String())
+	}
+	gracePeriod := s.refreshGracePeriod()
+	if token.Valid() && time.Now().Before(token.Expiry.Add(-gracePeriod.Duration)) {
+		return token, nil
+	}
+	if len(token.RefreshToken) == 0 {
+		return nil, fmt.Errorf("the cached token of %v expires and can't be refreshed. Please run flytectl auth login",
+			s.Config.Endpoint.String())
+	}
+	orchestrator, err := s.baseTokenOrchestrator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The token is expired for the refresh to happen within the grace period, the way the admin client does
+	expired := *token
+	expired.Expiry = token.Expiry.Add(-gracePeriod.Duration)
+	token, err = orchestrator.RefreshToken(ctx, &expired)
+	if err == nil && !token.Valid() {
+		err = errors.New("the refreshed token is invalid")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("the cached token of %v can't be refreshed: %w. Please run flytectl auth login",
+			s.Config.Endpoint.String(), err)
+	}
+	return token, nil
+}
+
+func (s *Session) validate() error {
+	if len(s.Config.Endpoint.String()) == 0 {
+		return errors.New("the admin endpoint isn't configured. Please run flytectl config init")
+	}
+	return nil
+}
+
+func (s *Session) refreshGracePeriod() config.Duration {
+	if s.Config.AuthType == admin.AuthTypeDeviceFlow {
+		return s.Config.DeviceFlowConfig.TokenRefreshGracePeriod
+	}
+	return s.Config.PkceConfig.TokenRefreshGracePeriod
+}
+
+func (s *Session) baseTokenOrchestrator(ctx context.Context) (tokenorchestrator.BaseTokenOrchestrator, error) {
+	authClient, err := newAuthMetadataClient(ctx, s.Config)
+	if err != nil {
+		return tokenorchestrator.BaseTokenOrchestrator{}, err
+	}
+	return tokenorchestrator.NewBaseTokenOrchestrator(ctx, s.Cache, authClient)
+}
+
+// newToken gets a token with the ClientSecret or ExternalCommand auth type, which aren't cached
+func (s *Session) newToken(ctx context.Context) (*oauth2.Token, error) {
+	authClient, err := newAuthMetadataClient(ctx, s.Config)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := admin.NewTokenSourceProvider(ctx, s.Config, s.Cache, authClient)
+	if err != nil {
+		return nil, err
+	}
+	tokenSource, err := provider.GetTokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tokenSource.Token()
+}
+
+// TokenInfo is the identity, expiry and scopes of a token
+type TokenInfo struct {
+	Subject     string    `json:"subject,omitempty"`
+	Identity    string    `json:"identity,omitempty"`
+	Issuer      string    `json:"issuer,omitempty"`
+	Audience    []string  `json:"audience,omitempty"`
+	Scopes      []string  `json:"scopes,omitempty"`
+	Expiry      time.Time `json:"expiry"`
+	Refreshable bool      `json:"refreshable"`
+}
+
+// Expired tells whether the token is expired at the given time
+func (i TokenInfo) Expired(now time.Time) bool {
+	return !i.Expiry.IsZero() && !now.Before(i.Expiry)
+}
+
+// DecodeToken returns the info of the token. The claims of a JWT access token are decoded without verifying its
+// signature, and an opaque access token only has the expiry of the token response.
+func DecodeToken(token *oauth2.Token) TokenInfo {
+	info := TokenInfo{Expiry: token.Expiry, Refreshable: len(token.RefreshToken) > 0}
+	parts := strings.Split(token.AccessToken, ".")
+	if len(parts) != 3 {
+		return info
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return info
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return info
+	}
+	info.Subject = stringClaim(claims, "sub")
+	info.Identity = stringClaim(claims, "email", "preferred_username", "user_info.preferred_name", "name", "client_id")
+	info.Issuer = stringClaim(claims, "iss")
+	info.Audience = listClaim(claims, "aud")
+	info.Scopes = listClaim(claims, "scp", "scope")
+	if exp, ok := claims["exp"].(float64); ok {
+		info.Expiry = time.Unix(int64(exp), 0).UTC()
+	}
+	return info
+}
+
+// stringClaim returns the first claim set among the keys, a dotted key being a nested claim
+func stringClaim(claims map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		var value interface{} = claims
+		for _, part := range strings.Split(key, ".") {
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = nested[part]
+		}
+		if s, ok := value.(string); ok && len(s) > 0 {
+			return s
+		}
+	}
+	return ""
+}
+
+// listClaim returns the first claim set among the keys, which is either a list or a space separated string
+func listClaim(claims map[string]interface{}, keys ...string) []string {
+	for _, key := range keys {
+		switch value := claims[key].(type) {
+		case string:
+			if fields := strings.Fields(value); len(fields) > 0 {
+				return fields
+			}
+		case []interface{}:
+			var list []string
+			for _, item := range value {
+				list = append(list, fmt.Sprintf("%v", item))
+			}
+			if len(list) > 0 {
+				return list
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytectl/pkg/pkce"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flyteidl/clients/go/admin/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func jwt(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	assert.Nil(t, err)
+	return fmt.Sprintf("e30.%s.c2lnbmF0dXJl", base64.RawURLEncoding.EncodeToString(payload))
+}
+
+func testSession(t *testing.T, authType admin.AuthType) *Session {
+	keyring.MockInit()
+	endpoint, err := url.Parse("dns:///flyte.example.com")
+	assert.Nil(t, err)
+	cfg := &admin.Config{
+		Endpoint:   config.URL{URL: *endpoint},
+		AuthType:   authType,
+		PkceConfig: admin.GetConfig(context.Background()).PkceConfig,
+	}
+	session, err := NewSession(cfg, "production")
+	assert.Nil(t, err)
+	return session
+}
+
+// fakeAuthMetadataClient fakes the auth metadata service of admin, the token endpoint being the server
+func fakeAuthMetadataClient(t *testing.T, tokenEndpoint string) {
+	newAuthMetadataClient = func(ctx context.Context, cfg *admin.Config) (service.AuthMetadataServiceClient, error) {
+		client := &mocks.AuthMetadataServiceClient{}
+		client.OnGetPublicClientConfigMatch(mock.Anything, mock.Anything).Return(&service.PublicClientAuthConfigResponse{
+			ClientId: "flytectl", RedirectUri: "http://localhost:53593/callback"}, nil)
+		client.OnGetOAuth2MetadataMatch(mock.Anything, mock.Anything).Return(&service.OAuth2MetadataResponse{
+			TokenEndpoint: tokenEndpoint}, nil)
+		return client, nil
+	}
+	t.Cleanup(func() { newAuthMetadataClient = admin.InitializeAuthMetadataClient })
+}
+
+func noAuthMetadataClient(t *testing.T) {
+	newAuthMetadataClient = func(ctx context.Context, cfg *admin.Config) (service.AuthMetadataServiceClient, error) {
+		return nil, errors.New("admin is unreachable")
+	}
+	t.Cleanup(func() { newAuthMetadataClient = admin.InitializeAuthMetadataClient })
+}
+
+func TestSessionToken(t *testing.T) {
+	t.Run("Cached token", func(t *testing.T) {
+		noAuthMetadataClient(t)
+		session := testSession(t, admin.AuthTypePkce)
+		cached := &oauth2.Token{AccessToken: "cached", Expiry: time.Now().Add(time.Hour)}
+		assert.Nil(t, session.Cache.SaveToken(cached))
+		token, err := session.Token(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "cached", token.AccessToken)
+	})
+	t.Run("Refreshed token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Nil(t, r.ParseForm())
+			assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+			assert.Equal(t, "refresh", r.Form.Get("refresh_token"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"refreshed","token_type":"bearer","expires_in":3600,"refresh_token":"refresh"}`))
+		}))
+		defer server.Close()
+		fakeAuthMetadataClient(t, server.URL)
+		session := testSession(t, admin.AuthTypePkce)
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "expired", RefreshToken: "refresh",
+			Expiry: time.Now().Add(-time.Minute)}))
+		token, err := session.Token(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "refreshed", token.AccessToken)
+		cached, err := session.CachedToken()
+		assert.Nil(t, err)
+		assert.Equal(t, "refreshed", cached.AccessToken)
+	})
+	t.Run("Expired token", func(t *testing.T) {
+		fakeAuthMetadataClient(t, "http://localhost:1/token")
+		session := testSession(t, admin.AuthTypePkce)
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "expired", Expiry: time.Now().Add(-time.Minute)}))
+		_, err := session.Token(context.Background())
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "Please run flytectl auth login")
+	})
+	t.Run("No token", func(t *testing.T) {
+		noAuthMetadataClient(t)
+		session := testSession(t, admin.AuthTypeDeviceFlow)
+		_ = session.Logout()
+		_, err := session.Token(context.Background())
+		assert.True(t, errors.Is(err, ErrNoToken))
+		assert.EqualError(t, err, "no token found in the cache for dns:///flyte.example.com. Please run flytectl auth login")
+	})
+	t.Run("External command", func(t *testing.T) {
+		fakeAuthMetadataClient(t, "")
+		session := testSession(t, admin.AuthTypeExternalCommand)
+		session.Config.Command = []string{"echo", "external"}
+		token, err := session.Token(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "external", token.AccessToken)
+		token, err = session.Login(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "external", token.AccessToken)
+	})
+	t.Run("No endpoint", func(t *testing.T) {
+		session := testSession(t, admin.AuthTypePkce)
+		session.Config.Endpoint = config.URL{}
+		_, err := session.Token(context.Background())
+		assert.EqualError(t, err, "the admin endpoint isn't configured. Please run flytectl config init")
+		_, err = session.Login(context.Background())
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNewTokenCache(t *testing.T) {
+	keyring.MockInit()
+	defer func() { *DefaultConfig = Config{Type: TokenCacheTypeAuto} }()
+	endpoint, err := url.Parse("dns:///flyte.example.com")
+	assert.Nil(t, err)
+	cfg := &admin.Config{Endpoint: config.URL{URL: *endpoint}}
+
+	t.Run("Auto", func(t *testing.T) {
+		for _, cacheType := range []string{TokenCacheTypeAuto, ""} {
+			DefaultConfig.Type = cacheType
+			cache, err := NewTokenCache(cfg, "production")
+			assert.Nil(t, err)
+			assert.Equal(t, pkce.TokenCacheKeyringProvider{
+				ServiceName: pkce.KeyRingServiceName,
+				ServiceUser: "production:dns:///flyte.example.com:flytectl-user",
+			}, cache)
+		}
+	})
+	t.Run("File", func(t *testing.T) {
+		DefaultConfig.Type = TokenCacheTypeFile
+		cache, err := NewTokenCache(cfg, "")
+		assert.Nil(t, err)
+		assert.Equal(t, pkce.TokenCacheFileProvider{
+			Path:        pkce.DefaultTokenCacheFile,
+			ServiceUser: "dns:///flyte.example.com:flytectl-user",
+		}, cache)
+		DefaultConfig.Path = "/tmp/tokens.enc"
+		cache, err = NewTokenCache(cfg, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "/tmp/tokens.enc", cache.(pkce.TokenCacheFileProvider).Path)
+	})
+	t.Run("Keyring", func(t *testing.T) {
+		DefaultConfig.Type = "Keyring"
+		cache, err := NewTokenCache(cfg, "")
+		assert.Nil(t, err)
+		assert.IsType(t, pkce.TokenCacheKeyringProvider{}, cache)
+	})
+	t.Run("Unsupported type", func(t *testing.T) {
+		DefaultConfig.Type = "flie"
+		_, err := NewTokenCache(cfg, "")
+		assert.EqualError(t, err, `unsupported tokenCache.type "flie". Supported types are auto, keyring and file`)
+		_, err = NewSession(cfg, "")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSessionLogout(t *testing.T) {
+	session := testSession(t, admin.AuthTypePkce)
+	assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "cached"}))
+	assert.Nil(t, session.Logout())
+	_, err := session.CachedToken()
+	assert.Equal(t, ErrNoToken, err)
+	assert.Equal(t, ErrNoToken, session.Logout())
+}
+
+func TestSessionLogin(t *testing.T) {
+	t.Run("Failed flow keeps the cached token", func(t *testing.T) {
+		fakeAuthMetadataClient(t, "http://localhost:1/token")
+		session := testSession(t, admin.AuthTypeDeviceFlow)
+		assert.Nil(t, session.Cache.SaveToken(&oauth2.Token{AccessToken: "cached"}))
+		_, err := session.Login(context.Background())
+		assert.NotNil(t, err)
+		cached, err := session.CachedToken()
+		assert.Nil(t, err)
+		assert.Equal(t, "cached", cached.AccessToken)
+	})
+}
+
+func TestSessionCachedToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	session := &Session{Cache: pkce.TokenCacheFileProvider{Path: path, ServiceUser: "flytectl-user", Key: make([]byte, 32)}}
+	_, err := session.CachedToken()
+	assert.Equal(t, ErrNoToken, err)
+
+	// A cache which can't be read isn't mistaken for a missing token.
+	assert.Nil(t, os.WriteFile(path, []byte("corrupted token cache"), 0600))
+	_, err = session.CachedToken()
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrNoToken))
+}
+
+func TestSessionCachesTokens(t *testing.T) {
+	assert.True(t, testSession(t, admin.AuthTypePkce).CachesTokens())
+	assert.True(t, testSession(t, admin.AuthTypeDeviceFlow).CachesTokens())
+	assert.False(t, testSession(t, admin.AuthTypeClientSecret).CachesTokens())
+	assert.False(t, testSession(t, admin.AuthTypeExternalCommand).CachesTokens())
+}
+
+func TestDecodeToken(t *testing.T) {
+	t.Run("JWT", func(t *testing.T) {
+		info := DecodeToken(&oauth2.Token{
+			AccessToken: jwt(t, map[string]interface{}{
+				"sub":       "00u1",
+				"iss":       "https://flyte.example.com",
+				"aud":       "https://flyte.example.com",
+				"exp":       1700000000,
+				"scp":       []string{"all", "offline"},
+				"user_info": map[string]interface{}{"preferred_name": "jane@example.com"},
+			}),
+			RefreshToken: "refresh",
+		})
+		assert.Equal(t, TokenInfo{
+			Subject:     "00u1",
+			Identity:    "jane@example.com",
+			Issuer:      "https://flyte.example.com",
+			Audience:    []string{"https://flyte.example.com"},
+			Scopes:      []string{"all", "offline"},
+			Expiry:      time.Unix(1700000000, 0).UTC(),
+			Refreshable: true,
+		}, info)
+		assert.True(t, info.Expired(time.Unix(1700000000, 0)))
+		assert.False(t, info.Expired(time.Unix(1699999999, 0)))
+	})
+	t.Run("Scope claim", func(t *testing.T) {
+		info := DecodeToken(&oauth2.Token{AccessToken: jwt(t, map[string]interface{}{
+			"email": "jane@example.com", "scope": "openid offline"})})
+		assert.Equal(t, "jane@example.com", info.Identity)
+		assert.Equal(t, []string{"openid", "offline"}, info.Scopes)
+	})
+	t.Run("Opaque token", func(t *testing.T) {
+		expiry := time.Now().Add(time.Hour)
+		info := DecodeToken(&oauth2.Token{AccessToken: "opaque", Expiry: expiry})
+		assert.Equal(t, TokenInfo{Expiry: expiry}, info)
+		assert.False(t, TokenInfo{}.Expired(time.Now()))
+	})
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "github.com/flyteorg/flytestdlib/config"
+
+//go:generate pflags Config --default-var DefaultConfig --bind-default-var
+
+const (
+	// TokenCacheTypeAuto caches the tokens in the keyring of the OS, else in the encrypted file
+	TokenCacheTypeAuto = "auto"
+	// TokenCacheTypeKeyring caches the tokens in the keyring of the OS
+	TokenCacheTypeKeyring = "keyring"
+	// TokenCacheTypeFile caches the tokens in the encrypted file
+	TokenCacheTypeFile = "file"
+)
+
+var (
+	DefaultConfig = &Config{
+		Type: TokenCacheTypeAuto,
+	}
+	section = config.MustRegisterSection("tokenCache", DefaultConfig)
+)
+
+// Config selects where the tokens of the Pkce and DeviceFlow auth types are cached
+type Config struct {
+	Type string `json:"type" pflag:",Cache of the tokens: auto keyring or file. auto uses the keyring of the OS and falls back to the encrypted file when it's unavailable."`
+	Path string `json:"path" pflag:",Path of the encrypted token cache file. Defaults to ~/.flyte/tokens.enc."`
+}
+
+func GetConfig() *Config {
+	return section.GetConfig().(*Config)
+}
@@ -1,34 +1,161 @@
 package configutil
 
 import (
-	"html/template"
+	"encoding/json"
 	"os"
+	"text/template"
 
 	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/spf13/pflag"
 )
 
 const (
 	AdminConfigTemplate = `admin:
   # For GRPC endpoints you might want to use dns:///flyte.myexample.com
   endpoint: {{.Host}}
-  authType: Pkce
+  authType: {{or .AuthType "Pkce"}}
   insecure: {{.Insecure}}
+{{- with .ClientID}}
+  clientId: {{json .}}
+{{- end}}
+{{- with .ClientSecretLocation}}
+  clientSecretLocation: {{json .}}
+{{- end}}
+{{- with .ClientSecretEnvVar}}
+  clientSecretEnvVar: {{json .}}
+{{- end}}
+{{- with .Scopes}}
+  scopes: {{json .}}
+{{- end}}
+{{- with .CACertFilePath}}
+  caCertFilePath: {{json .}}
+{{- end}}
+{{- with .Command}}
+  command: {{json .}}
+{{- end}}
+{{- with .Storage}}
+storage:
+  type: {{.Type}}
+  container: {{json .Container}}
+{{- if .StowKind}}
+  stow:
+    kind: {{.StowKind}}
+    config:
+{{- range $key, $value := .StowConfig}}
+      {{$key}}: {{json $value}}
+{{- end}}
+{{- else}}
+  connection:
+{{- with .Endpoint}}
+    endpoint: {{json .}}
+{{- end}}
+    auth-type: {{.AuthType}}
+{{- with .Region}}
+    region: {{json .}}
+{{- end}}
+{{- with .AccessKey}}
+    access-key: {{json .}}
+{{- end}}
+{{- with .SecretKey}}
+    secret-key: {{json .}}
+{{- end}}
+{{- if .DisableSSL}}
+    disable-ssl: true
+{{- end}}
+{{- end}}
+{{- end}}
 logger:
   show-source: true
   level: 0`
 )
 
+// ConfigTemplateSpec holds the values of the flytectl config. The auth type defaults to Pkce and the optional settings
+// are left out when empty.
 type ConfigTemplateSpec struct {
-	Host     string
-	Insecure bool
+	Host                 string
+	Insecure             bool
+	AuthType             string
+	ClientID             string
+	ClientSecretLocation string
+	ClientSecretEnvVar   string
+	Scopes               []string
+	CACertFilePath       string
+	Command              []string
+	Storage              *StorageTemplateSpec
+}
+
+// StorageTemplateSpec holds the storage section of the flytectl config, as read by the flytestdlib storage. The s3 and
+// minio stores are configured with the connection settings, and the other ones with the stow kind and config.
+type StorageTemplateSpec struct {
+	Type       string
+	Container  string
+	Endpoint   string
+	AuthType   string
+	Region     string
+	AccessKey  string
+	SecretKey  string
+	DisableSSL bool
+	StowKind   string
+	StowConfig map[string]string
 }
 
 var (
 	FlytectlConfig = f.FilePathJoin(f.UserHomeDir(), ".flyte", "config-sandbox.yaml")
 	ConfigFile     = f.FilePathJoin(f.UserHomeDir(), ".flyte", "config.yaml")
 	Kubeconfig     = f.FilePathJoin(f.UserHomeDir(), ".flyte", "k3s", "k3s.yaml")
+
+	// ConfigFileUsed is the config file loaded by flytectl, and CurrentContext the context of the file in use if any.
+	ConfigFileUsed = ConfigFile
+	CurrentContext string
+	// ConfigFlags are the flags overriding the settings of the config file
+	ConfigFlags *pflag.FlagSet
 )
 
+const configEnvName = "FLYTECTL_CONFIG"
+
+// ResolveConfigFile returns the config file passed with the config flag, else the one of the FLYTECTL_CONFIG env var,
+// else the default one.
+func ResolveConfigFile(flagValue string) string {
+	if len(flagValue) > 0 {
+		return flagValue
+	}
+	// TODO: Move flyteconfig env variable logic in flytestdlib
+	if len(os.Getenv(configEnvName)) > 0 {
+		return os.Getenv(configEnvName)
+	}
+	return ConfigFile
+}
+
+// SandboxDir returns the directory holding the config and the kubeconfig of the named sandbox. The default sandbox,
+// which has an empty name, keeps them in the Flyte dir.
+func SandboxDir(name string) string {
+	if len(name) == 0 {
+		return f.FilePathJoin(f.UserHomeDir(), ".flyte")
+	}
+	return f.FilePathJoin(f.UserHomeDir(), ".flyte", "sandboxes", name)
+}
+
+// SandboxConfigFile returns the flytectl config of the named sandbox
+func SandboxConfigFile(name string) string {
+	if len(name) == 0 {
+		return FlytectlConfig
+	}
+	return f.FilePathJoin(SandboxDir(name), "config-sandbox.yaml")
+}
+
+// SandboxKubeconfig returns the kubeconfig of the named sandbox
+func SandboxKubeconfig(name string) string {
+	if len(name) == 0 {
+		return Kubeconfig
+	}
+	return f.FilePathJoin(SandboxDir(name), "k3s", "k3s.yaml")
+}
+
+// SnapshotDir returns the directory holding the named snapshot of a sandbox
+func SnapshotDir(name string) string {
+	return f.FilePathJoin(f.UserHomeDir(), ".flyte", "snapshots", name)
+}
+
 // GetTemplate returns cluster config
 func GetTemplate() string {
 	return AdminConfigTemplate
@@ -36,7 +163,7 @@ func GetTemplate() string {
 
 // SetupConfig download the Flyte sandbox config
 func SetupConfig(filename, templateStr string, templateSpec ConfigTemplateSpec) error {
-	tmpl := template.New("config")
+	tmpl := template.New("config").Funcs(template.FuncMap{"json": toJSON})
 	tmpl, err := tmpl.Parse(templateStr)
 	if err != nil {
 		return err
@@ -61,3 +188,17 @@ func ConfigCleanup() error {
 	}
 	return nil
 }
+
+// SandboxConfigCleanup will remove the config of the named sandbox
+func SandboxConfigCleanup(name string) error {
+	if len(name) == 0 {
+		return ConfigCleanup()
+	}
+	return os.RemoveAll(SandboxDir(name))
+}
+
+// toJSON renders a value of the config template in JSON, which is valid YAML and escapes the strings
+func toJSON(v interface{}) (string, error) {
+	raw, err := json.Marshal(v)
+	return string(raw), err
+}
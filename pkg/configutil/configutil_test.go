@@ -30,6 +30,21 @@ func TestConfigCleanup(t *testing.T) {
 	_ = ConfigCleanup()
 }
 
+func TestSandboxConfigPaths(t *testing.T) {
+	assert.Equal(t, FlytectlConfig, SandboxConfigFile(""))
+	assert.Equal(t, Kubeconfig, SandboxKubeconfig(""))
+	dir := f.FilePathJoin(f.UserHomeDir(), ".flyte", "sandboxes", "feature-x")
+	assert.Equal(t, dir, SandboxDir("feature-x"))
+	assert.Equal(t, f.FilePathJoin(dir, "config-sandbox.yaml"), SandboxConfigFile("feature-x"))
+	assert.Equal(t, f.FilePathJoin(dir, "k3s", "k3s.yaml"), SandboxKubeconfig("feature-x"))
+
+	assert.Nil(t, os.MkdirAll(f.FilePathJoin(dir, "k3s"), 0755))
+	assert.Nil(t, ioutil.WriteFile(SandboxConfigFile("feature-x"), []byte("string"), 0600))
+	assert.Nil(t, SandboxConfigCleanup("feature-x"))
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestSetupFlytectlConfig(t *testing.T) {
 	templateValue := ConfigTemplateSpec{
 		Host:     "dns:///localhost:30081",
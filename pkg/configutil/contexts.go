@@ -0,0 +1,202 @@
+package configutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	contextsKey       = "contexts"
+	currentContextKey = "current-context"
+	adminKey          = "admin"
+	storageKey        = "storage"
+	rootKey           = "root"
+)
+
+// Context is a named set of settings of the config file, kubeconfig style. The admin and storage settings of the
+// context are merged over the top-level ones of the file, which hold the settings shared by all the contexts.
+type Context struct {
+	Name    string                 `json:"name"`
+	Admin   map[string]interface{} `json:"admin,omitempty"`
+	Storage map[string]interface{} `json:"storage,omitempty"`
+	Project string                 `json:"project,omitempty"`
+	Domain  string                 `json:"domain,omitempty"`
+}
+
+// Endpoint returns the admin endpoint of the context
+func (c Context) Endpoint() string {
+	endpoint, _ := c.Admin["endpoint"].(string)
+	return endpoint
+}
+
+// ContextsFile is a flytectl config file holding contexts. The other sections of the file are kept as they are.
+type ContextsFile struct {
+	CurrentContext string
+	Contexts       []Context
+	sections       map[string]interface{}
+}
+
+// LoadContextsFile reads the config file. A missing file is loaded as an empty one.
+func LoadContextsFile(path string) (*ContextsFile, error) {
+	file := &ContextsFile{sections: map[string]interface{}{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &file.sections); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %v: %w", path, err)
+	}
+	if file.sections == nil {
+		file.sections = map[string]interface{}{}
+	}
+	if current, ok := file.sections[currentContextKey]; ok {
+		file.CurrentContext = fmt.Sprintf("%v", current)
+	}
+	if contexts, ok := file.sections[contextsKey]; ok {
+		raw, err := yaml.Marshal(contexts)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(raw, &file.Contexts); err != nil {
+			return nil, fmt.Errorf("invalid contexts in config file %v: %w", path, err)
+		}
+	}
+	delete(file.sections, currentContextKey)
+	delete(file.sections, contextsKey)
+	return file, nil
+}
+
+// Write writes the config file
+func (c *ContextsFile) Write(path string) error {
+	data, err := yaml.Marshal(c.document())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (c *ContextsFile) document() map[string]interface{} {
+	doc := make(map[string]interface{}, len(c.sections)+2)
+	for k, v := range c.sections {
+		doc[k] = v
+	}
+	if len(c.Contexts) > 0 {
+		doc[contextsKey] = c.Contexts
+	}
+	if len(c.CurrentContext) > 0 {
+		doc[currentContextKey] = c.CurrentContext
+	}
+	return doc
+}
+
+// GetContext returns the named context
+func (c *ContextsFile) GetContext(name string) (*Context, bool) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], true
+		}
+	}
+	return nil, false
+}
+
+// SetContext adds the context, or replaces the one with the same name
+func (c *ContextsFile) SetContext(context Context) {
+	if existing, ok := c.GetContext(context.Name); ok {
+		*existing = context
+		return
+	}
+	c.Contexts = append(c.Contexts, context)
+	sort.Slice(c.Contexts, func(i, j int) bool { return c.Contexts[i].Name < c.Contexts[j].Name })
+}
+
+// UseContext makes the named context the current one
+func (c *ContextsFile) UseContext(name string) error {
+	if _, ok := c.GetContext(name); !ok {
+		return fmt.Errorf("context %q not found", name)
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// EffectiveConfig returns the config of the named context, its admin and storage settings being merged over the
+// top-level ones and its project and domain set in the root section unless keepProjectDomain is set, e.g. because they
+// are passed as flags. An empty name returns the top-level config.
+func (c *ContextsFile) EffectiveConfig(name string, keepProjectDomain bool) (map[string]interface{}, error) {
+	config := make(map[string]interface{}, len(c.sections))
+	for k, v := range c.sections {
+		config[k] = v
+	}
+	if len(name) == 0 {
+		return config, nil
+	}
+	context, ok := c.GetContext(name)
+	if !ok {
+		return nil, fmt.Errorf("context %q not found", name)
+	}
+	config[adminKey] = mergeSection(config[adminKey], context.Admin)
+	config[storageKey] = mergeSection(config[storageKey], context.Storage)
+	if !keepProjectDomain {
+		root := map[string]interface{}{}
+		if len(context.Project) > 0 {
+			root["project"] = context.Project
+		}
+		if len(context.Domain) > 0 {
+			root["domain"] = context.Domain
+		}
+		config[rootKey] = mergeSection(config[rootKey], root)
+	}
+	for k, v := range config {
+		if v == nil {
+			delete(config, k)
+		}
+	}
+	return config, nil
+}
+
+// WriteEffectiveConfig writes the config of the named context to a file of the dir
+func (c *ContextsFile) WriteEffectiveConfig(name string, keepProjectDomain bool, dir string) (string, error) {
+	config, err := c.EffectiveConfig(name, keepProjectDomain)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "config.yaml")
+	return path, os.WriteFile(path, data, 0600)
+}
+
+// mergeSection returns the settings of the section overridden by the ones of the context, the nested settings being
+// merged recursively.
+func mergeSection(section interface{}, override map[string]interface{}) interface{} {
+	if len(override) == 0 {
+		return section
+	}
+	base, ok := section.(map[string]interface{})
+	if !ok {
+		return override
+	}
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		if nested, ok := v.(map[string]interface{}); ok {
+			merged[k] = mergeSection(merged[k], nested)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
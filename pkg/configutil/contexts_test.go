@@ -0,0 +1,124 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const contextsConfig = `
+current-context: sandbox
+contexts:
+  - name: sandbox
+    admin:
+      endpoint: dns:///localhost:30081
+      insecure: true
+    project: flytesnacks
+    domain: development
+  - name: production
+    admin:
+      endpoint: dns:///flyte.myexample.com
+    storage:
+      type: s3
+      stow:
+        config:
+          region: us-east-2
+admin:
+  authType: Pkce
+  insecure: false
+storage:
+  type: minio
+  stow:
+    kind: s3
+    config:
+      auth_type: iam
+logger:
+  level: 0
+`
+
+func writeContextsConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestContextsFile(t *testing.T) {
+	path := writeContextsConfig(t, contextsConfig)
+	file, err := LoadContextsFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "sandbox", file.CurrentContext)
+	assert.Len(t, file.Contexts, 2)
+
+	t.Run("Effective config of a context", func(t *testing.T) {
+		config, err := file.EffectiveConfig("production", false)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"authType": "Pkce", "insecure": false, "endpoint": "dns:///flyte.myexample.com"}, config["admin"])
+		assert.Equal(t, map[string]interface{}{"type": "s3", "stow": map[string]interface{}{
+			"kind": "s3", "config": map[string]interface{}{"auth_type": "iam", "region": "us-east-2"}}}, config["storage"])
+		assert.Equal(t, map[string]interface{}{"level": float64(0)}, config["logger"])
+		assert.Nil(t, config["contexts"])
+		assert.Nil(t, config["root"])
+	})
+	t.Run("Effective config with the project and domain of the context", func(t *testing.T) {
+		config, err := file.EffectiveConfig("sandbox", false)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"project": "flytesnacks", "domain": "development"}, config["root"])
+		assert.Equal(t, true, config["admin"].(map[string]interface{})["insecure"])
+
+		config, err = file.EffectiveConfig("sandbox", true)
+		assert.Nil(t, err)
+		assert.Nil(t, config["root"])
+	})
+	t.Run("Effective config of a missing context", func(t *testing.T) {
+		_, err := file.EffectiveConfig("staging", false)
+		assert.EqualError(t, err, `context "staging" not found`)
+	})
+	t.Run("Write effective config", func(t *testing.T) {
+		effective, err := file.WriteEffectiveConfig("production", false, t.TempDir())
+		assert.Nil(t, err)
+		data, err := os.ReadFile(effective)
+		assert.Nil(t, err)
+		var config map[string]interface{}
+		assert.Nil(t, yaml.Unmarshal(data, &config))
+		assert.Equal(t, "dns:///flyte.myexample.com", config["admin"].(map[string]interface{})["endpoint"])
+	})
+	t.Run("Set and use a context", func(t *testing.T) {
+		file.SetContext(Context{Name: "staging", Admin: map[string]interface{}{"endpoint": "dns:///staging.myexample.com"}})
+		assert.EqualError(t, file.UseContext("dev"), `context "dev" not found`)
+		assert.Nil(t, file.UseContext("staging"))
+		assert.Nil(t, file.Write(path))
+
+		written, err := LoadContextsFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "staging", written.CurrentContext)
+		assert.Equal(t, []string{"production", "sandbox", "staging"}, []string{
+			written.Contexts[0].Name, written.Contexts[1].Name, written.Contexts[2].Name})
+		staging, ok := written.GetContext("staging")
+		assert.True(t, ok)
+		assert.Equal(t, "dns:///staging.myexample.com", staging.Endpoint())
+		config, err := written.EffectiveConfig("", false)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"level": float64(0)}, config["logger"])
+	})
+}
+
+func TestLoadContextsFile(t *testing.T) {
+	t.Run("Missing file", func(t *testing.T) {
+		file, err := LoadContextsFile(filepath.Join(t.TempDir(), "config.yaml"))
+		assert.Nil(t, err)
+		assert.Empty(t, file.Contexts)
+	})
+	t.Run("File without contexts", func(t *testing.T) {
+		file, err := LoadContextsFile(writeContextsConfig(t, "admin:\n  endpoint: dns:///localhost:30081\n"))
+		assert.Nil(t, err)
+		assert.Empty(t, file.Contexts)
+		assert.Empty(t, file.CurrentContext)
+	})
+	t.Run("Invalid contexts", func(t *testing.T) {
+		_, err := LoadContextsFile(writeContextsConfig(t, "contexts:\n  name: sandbox\n"))
+		assert.NotNil(t, err)
+	})
+}
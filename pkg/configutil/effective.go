@@ -0,0 +1,190 @@
+package configutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/spf13/pflag"
+)
+
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+
+	redacted = "<redacted>"
+)
+
+// Setting is a setting in effect, with the source of its value
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// EffectiveSettings returns the settings in effect of all the sections registered under the root one, sorted by key.
+// The source of a value is the first of the flag, the env var, the context, the config file or the default, which is
+// the precedence of the config accessor. The values of the secrets are redacted.
+func EffectiveSettings(root config.Section, flags *pflag.FlagSet, configFile, contextName string) ([]Setting, error) {
+	values, err := config.AllConfigsAsMap(root)
+	if err != nil {
+		return nil, err
+	}
+	file, err := LoadContextsFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	var contextValues map[string]interface{}
+	if c, ok := file.GetContext(contextName); ok {
+		contextValues = map[string]interface{}{adminKey: c.Admin, storageKey: c.Storage}
+		// The project and domain of the context are ignored when either is passed as a flag
+		if !flagChanged(flags, "project") && !flagChanged(flags, "domain") {
+			contextValues[rootKey] = map[string]interface{}{"project": c.Project, "domain": c.Domain}
+		}
+	}
+
+	leaves := map[string]interface{}{}
+	flatten(values, "", leaves)
+	settings := make([]Setting, 0, len(leaves))
+	for key, value := range leaves {
+		setting := Setting{Key: key, Value: formatValue(value), Source: SourceDefault}
+		switch {
+		case flagChanged(flags, key):
+			setting.Source = SourceFlag
+		case envSet(key):
+			setting.Source = SourceEnv
+		case hasKey(contextValues, key):
+			setting.Source = fmt.Sprintf("context %v", contextName)
+		case hasKey(file.sections, key):
+			setting.Source = SourceFile
+		}
+		if IsSecretKey(key) && len(setting.Value) > 0 {
+			setting.Value = redacted
+		}
+		settings = append(settings, setting)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// IsSecretKey tells whether the value of the key is a secret, e.g. a password or a secret key. The keys holding the
+// location of a secret, such as clientSecretLocation, aren't secrets.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		key = key[idx+1:]
+	}
+	for _, suffix := range []string{"location", "envvar", "env-var", "path", "file", "type"} {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+	for _, secret := range []string{"secret", "password", "apikey", "api-key", "api_key"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	// e.g. token or refresh_token, but not tokenRefreshWindow
+	return strings.HasSuffix(key, "token")
+}
+
+// RedactSecrets returns the config with the values of the secrets redacted
+func RedactSecrets(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			if _, nested := item.(map[string]interface{}); !nested && IsSecretKey(k) && item != nil && item != "" {
+				res[k] = redacted
+				continue
+			}
+			res[k] = RedactSecrets(item)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			res = append(res, RedactSecrets(item))
+		}
+		return res
+	}
+	return v
+}
+
+// flatten collects the leaves of the nested maps by their dotted key. Empty maps are leaves.
+func flatten(v interface{}, prefix string, leaves map[string]interface{}) {
+	if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+		for k, item := range m {
+			key := k
+			if len(prefix) > 0 {
+				key = prefix + "." + k
+			}
+			flatten(item, key, leaves)
+		}
+		return
+	}
+	leaves[prefix] = v
+}
+
+func formatValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
+
+// flagChanged tells whether the key is set by a flag. The settings of the root section are set by the flags without
+// the root prefix, e.g. --project.
+func flagChanged(flags *pflag.FlagSet, key string) bool {
+	if flags == nil {
+		return false
+	}
+	names := []string{key}
+	if strings.HasPrefix(key, rootKey+".") {
+		names = append(names, strings.TrimPrefix(key, rootKey+"."))
+	}
+	for _, name := range names {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+// envSet tells whether the env var the config accessor binds to the key is set, e.g. ADMIN.ENDPOINT
+func envSet(key string) bool {
+	_, ok := os.LookupEnv(strings.ToUpper(strings.Replace(key, "-", "_", -1)))
+	return ok
+}
+
+// hasKey tells whether the dotted key is set in the nested maps, the keys being case insensitive as viper lower cases
+// them.
+func hasKey(m map[string]interface{}, key string) bool {
+	var current interface{} = m
+	for _, part := range strings.Split(key, ".") {
+		asMap, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		found := false
+		for k, v := range asMap {
+			if strings.EqualFold(k, part) {
+				current, found = v, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return current != nil && current != ""
+}
@@ -0,0 +1,91 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAdminConfig struct {
+	Endpoint     string   `json:"endpoint"`
+	Insecure     bool     `json:"insecure"`
+	ClientSecret string   `json:"clientSecret"`
+	Scopes       []string `json:"scopes"`
+}
+
+type testRootConfig struct {
+	Project string `json:"project"`
+	Domain  string `json:"domain"`
+}
+
+func TestEffectiveSettings(t *testing.T) {
+	root := config.NewRootSection()
+	_, err := root.RegisterSection("admin", &testAdminConfig{Endpoint: "dns:///localhost:30081", Insecure: true,
+		ClientSecret: "s3cr3t", Scopes: []string{"all"}})
+	assert.Nil(t, err)
+	_, err = root.RegisterSection("root", &testRootConfig{Project: "flytesnacks", Domain: "development"})
+	assert.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(`
+admin:
+  endpoint: dns:///localhost:30081
+  insecure: true
+current-context: sandbox
+contexts:
+  - name: sandbox
+    project: flytesnacks
+`), 0600))
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("domain", "", "")
+	flags.String("admin.endpoint", "", "")
+	assert.Nil(t, flags.Parse([]string{"--domain", "development"}))
+	t.Setenv("ADMIN.INSECURE", "true")
+
+	settings, err := EffectiveSettings(root, flags, path, "sandbox")
+	assert.Nil(t, err)
+	assert.Equal(t, []Setting{
+		{Key: "admin.clientSecret", Value: redacted, Source: SourceDefault},
+		{Key: "admin.endpoint", Value: "dns:///localhost:30081", Source: SourceFile},
+		{Key: "admin.insecure", Value: "true", Source: SourceEnv},
+		{Key: "admin.scopes", Value: `["all"]`, Source: SourceDefault},
+		{Key: "root.domain", Value: "development", Source: SourceFlag},
+		// The project of the context is ignored as the domain is passed as a flag
+		{Key: "root.project", Value: "flytesnacks", Source: SourceDefault},
+	}, settings)
+
+	settings, err = EffectiveSettings(root, nil, path, "sandbox")
+	assert.Nil(t, err)
+	assert.Contains(t, settings, Setting{Key: "root.project", Value: "flytesnacks", Source: "context sandbox"})
+}
+
+func TestIsSecretKey(t *testing.T) {
+	for _, key := range []string{"storage.connection.secret-key", "admin.clientSecret", "storage.stow.config.secret_key",
+		"password", "auth.token"} {
+		assert.True(t, IsSecretKey(key), key)
+	}
+	for _, key := range []string{"admin.clientSecretLocation", "admin.clientSecretEnvVar", "storage.connection.access-key",
+		"admin.tokenRefreshWindow", "admin.endpoint"} {
+		assert.False(t, IsSecretKey(key), key)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"admin": map[string]interface{}{"clientSecretLocation": "/etc/secret", "clientSecret": redacted},
+		"contexts": []interface{}{
+			map[string]interface{}{"storage": map[string]interface{}{"secret-key": redacted, "access-key": "minio"}},
+		},
+		"token": "",
+	}, RedactSecrets(map[string]interface{}{
+		"admin": map[string]interface{}{"clientSecretLocation": "/etc/secret", "clientSecret": "s3cr3t"},
+		"contexts": []interface{}{
+			map[string]interface{}{"storage": map[string]interface{}{"secret-key": "miniostorage", "access-key": "minio"}},
+		},
+		"token": "",
+	}))
+}
@@ -0,0 +1,399 @@
+package configutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem is an issue found in a config file
+type Problem struct {
+	Line     int    `json:"line"`
+	Key      string `json:"key"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// ValidateConfigFile checks the keys and values of the config file against the config sections registered under the
+// root one, the way the strict mode of the config accessor decodes them, and returns the unknown keys, the deprecated
+// keys and the invalid values with their line. The admin and storage settings of the contexts are checked too.
+func ValidateConfigFile(path string, root config.Section) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %v: %w", path, err)
+	}
+	v := &validator{}
+	if len(doc.Content) > 0 {
+		v.validateFile(doc.Content[0], root)
+	}
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems, nil
+}
+
+// ErrorCount returns the number of problems which are errors
+func ErrorCount(problems []Problem) int {
+	count := 0
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) report(node *yaml.Node, key, severity, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		Line:     node.Line,
+		Key:      key,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validateFile(node *yaml.Node, root config.Section) {
+	node = resolve(node)
+	if isNull(node) {
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		v.report(node, "", SeverityError, "expected a map of config sections, got %v", kindName(node))
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		switch key {
+		case currentContextKey:
+			v.validateValue(reflect.TypeOf(""), valueNode, key)
+		case contextsKey:
+			v.validateContexts(valueNode, root)
+		default:
+			if section, ok := lookupSection(root, key); ok {
+				v.validateSection(section, valueNode, key)
+				continue
+			}
+			v.report(keyNode, key, SeverityError, "unknown key: no config section %v is registered", key)
+		}
+	}
+}
+
+func (v *validator) validateContexts(node *yaml.Node, root config.Section) {
+	node = resolve(node)
+	if isNull(node) {
+		return
+	}
+	if node.Kind != yaml.SequenceNode {
+		v.report(node, contextsKey, SeverityError, "expected a list of contexts, got %v", kindName(node))
+		return
+	}
+	for i, item := range node.Content {
+		prefix := fmt.Sprintf("%v[%v]", contextsKey, i)
+		item = resolve(item)
+		if item.Kind != yaml.MappingNode {
+			v.report(item, prefix, SeverityError, "expected a context, got %v", kindName(item))
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			keyNode, valueNode := item.Content[j], item.Content[j+1]
+			key := prefix + "." + keyNode.Value
+			switch keyNode.Value {
+			case "name", "project", "domain":
+				v.validateValue(reflect.TypeOf(""), valueNode, key)
+			case adminKey, storageKey:
+				if section, ok := lookupSection(root, keyNode.Value); ok {
+					v.validateSection(section, valueNode, key)
+				}
+			default:
+				v.report(keyNode, key, SeverityError, "unknown key: a context holds name, admin, storage, project and domain")
+			}
+		}
+	}
+}
+
+// validateSection validates the settings of the section, the keys of its subsections taking precedence over the fields
+// of its config.
+func (v *validator) validateSection(section config.Section, node *yaml.Node, path string) {
+	node = resolve(node)
+	if isNull(node) {
+		return
+	}
+	if len(section.GetSections()) == 0 && section.GetConfig() != nil {
+		v.validateValue(reflect.TypeOf(section.GetConfig()), node, path)
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		v.report(node, path, SeverityError, "expected a map, got %v", kindName(node))
+		return
+	}
+	var configType reflect.Type
+	if section.GetConfig() != nil {
+		configType = indirectType(reflect.TypeOf(section.GetConfig()))
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := path + "." + keyNode.Value
+		if subsection, ok := lookupSection(section, keyNode.Value); ok {
+			v.validateSection(subsection, valueNode, key)
+			continue
+		}
+		if configType == nil || configType.Kind() != reflect.Struct {
+			v.report(keyNode, key, SeverityError, "unknown key")
+			continue
+		}
+		v.validateField(configType, keyNode, valueNode, key)
+	}
+}
+
+func (v *validator) validateField(structType reflect.Type, keyNode, valueNode *yaml.Node, key string) {
+	field, ok := lookupField(structType, keyNode.Value)
+	if !ok {
+		v.report(keyNode, key, SeverityError, "unknown key")
+		return
+	}
+	if deprecated, description := isDeprecated(field); deprecated {
+		v.report(keyNode, key, SeverityWarning, "deprecated key: %v", description)
+	}
+	v.validateValue(field.Type, valueNode, key)
+}
+
+// validateValue validates the value against the type it is decoded to, the input being weakly typed
+func (v *validator) validateValue(t reflect.Type, node *yaml.Node, key string) {
+	node = resolve(node)
+	if isNull(node) {
+		return
+	}
+	t = indirectType(t)
+	if t == durationType {
+		if node.Kind != yaml.ScalarNode {
+			v.report(node, key, SeverityError, "expected a duration, got %v", kindName(node))
+		} else if _, err := strconv.ParseInt(node.Value, 10, 64); err != nil {
+			if _, err := time.ParseDuration(node.Value); err != nil {
+				v.report(node, key, SeverityError, "invalid duration %q", node.Value)
+			}
+		}
+		return
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			v.report(node, key, SeverityError, "invalid value: %v", err)
+			return
+		}
+		raw, err := json.Marshal(value)
+		if err == nil {
+			err = json.Unmarshal(raw, reflect.New(t).Interface())
+		}
+		if err != nil {
+			v.report(node, key, SeverityError, "invalid value %v: %v", strings.TrimSpace(string(raw)), err)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, key, SeverityError, "expected a map, got %v", kindName(node))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.validateField(t, node.Content[i], node.Content[i+1], key+"."+node.Content[i].Value)
+		}
+	case reflect.Map:
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				v.validateValue(t.Elem(), node.Content[i+1], key+"."+node.Content[i].Value)
+			}
+		case yaml.SequenceNode:
+			// A list of maps is merged into a map, for the keys to keep their case
+			for _, item := range node.Content {
+				v.validateValue(t, item, key)
+			}
+		default:
+			v.report(node, key, SeverityError, "expected a map, got %v", kindName(node))
+		}
+	case reflect.Slice, reflect.Array:
+		switch node.Kind {
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				v.validateValue(t.Elem(), item, fmt.Sprintf("%v[%v]", key, i))
+			}
+		case yaml.ScalarNode:
+			// A string is split on commas into a list of strings, or decoded from base64 into bytes
+			if t.Elem().Kind() != reflect.String && t.Elem().Kind() != reflect.Uint8 {
+				v.report(node, key, SeverityError, "expected a list, got %v", kindName(node))
+			}
+		default:
+			v.report(node, key, SeverityError, "expected a list, got %v", kindName(node))
+		}
+	case reflect.Bool:
+		v.validateScalar(node, key, "a bool", func(s string) error {
+			_, err := strconv.ParseBool(s)
+			return err
+		}, "!!bool", "!!int")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.validateScalar(node, key, "an integer", func(s string) error {
+			_, err := strconv.ParseInt(s, 0, t.Bits())
+			return err
+		}, "!!int", "!!bool")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.validateScalar(node, key, "a positive integer", func(s string) error {
+			_, err := strconv.ParseUint(s, 0, t.Bits())
+			return err
+		}, "!!bool")
+	case reflect.Float32, reflect.Float64:
+		v.validateScalar(node, key, "a number", func(s string) error {
+			_, err := strconv.ParseFloat(s, t.Bits())
+			return err
+		}, "!!float", "!!int", "!!bool")
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.report(node, key, SeverityError, "expected a string, got %v", kindName(node))
+		}
+	}
+}
+
+// validateScalar validates a bool or number, which is decoded from a scalar of one of the tags or from a string
+// parsed by the parse func. An empty string is decoded as zero.
+func (v *validator) validateScalar(node *yaml.Node, key, expected string, parse func(string) error, tags ...string) {
+	if node.Kind != yaml.ScalarNode {
+		v.report(node, key, SeverityError, "expected %v, got %v", expected, kindName(node))
+		return
+	}
+	if isScalar(node, tags...) || len(node.Value) == 0 {
+		return
+	}
+	if err := parse(node.Value); err != nil {
+		v.report(node, key, SeverityError, "expected %v, got %q", expected, node.Value)
+	}
+}
+
+// lookupSection returns the subsection of the key, the keys being case insensitive as viper lower cases them
+func lookupSection(section config.Section, key string) (config.Section, bool) {
+	for k, s := range section.GetSections() {
+		if strings.EqualFold(k, key) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// lookupField returns the field of the struct decoded from the key, the embedded structs being squashed
+func lookupField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && (len(name) == 0 || strings.Contains(opts, "squash") || strings.Contains(opts, "inline")) {
+			if embedded := indirectType(field.Type); embedded.Kind() == reflect.Struct {
+				if f, ok := lookupField(embedded, key); ok {
+					return f, true
+				}
+				continue
+			}
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func jsonName(field reflect.StructField) (string, string) {
+	tag := field.Tag.Get("json")
+	if idx := strings.Index(tag, ","); idx >= 0 {
+		return tag[:idx], tag[idx+1:]
+	}
+	return tag, ""
+}
+
+// isDeprecated tells whether the field is deprecated, following the convention of the Flyte configs to prefix the name
+// or the pflag description of the deprecated fields with Deprecated.
+func isDeprecated(field reflect.StructField) (bool, string) {
+	description := field.Tag.Get("pflag")
+	if idx := strings.Index(description, ","); idx >= 0 {
+		description = strings.TrimSpace(description[idx+1:])
+	}
+	if strings.HasPrefix(description, "Deprecated") {
+		return true, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(description, "Deprecated"), ":"))
+	}
+	if strings.HasPrefix(field.Name, "Deprecated") {
+		if len(description) == 0 {
+			description = "the setting is no longer used"
+		}
+		return true, description
+	}
+	return false, ""
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+func isScalar(node *yaml.Node, tags ...string) bool {
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	for _, tag := range tags {
+		if node.ShortTag() == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return "a scalar"
+	}
+}
@@ -0,0 +1,129 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFilesConfig struct {
+	Archive     bool              `json:"archive"`
+	Retries     int               `json:"retries"`
+	Timeout     time.Duration     `json:"timeout"`
+	Labels      map[string]string `json:"labels"`
+	Sources     []string          `json:"sources"`
+	Deprecated  string            `json:"oldSetting" pflag:",Deprecated: use sources instead"`
+	Destination config.URL        `json:"destination"`
+}
+
+func testRootSection(t *testing.T) config.Section {
+	root := config.NewRootSection()
+	_, err := root.RegisterSection("admin", &admin.Config{})
+	assert.Nil(t, err)
+	_, err = root.RegisterSection("storage", &storage.Config{})
+	assert.Nil(t, err)
+	_, err = root.RegisterSection("files", &testFilesConfig{})
+	assert.Nil(t, err)
+	return root
+}
+
+func validate(t *testing.T, content string) []Problem {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	problems, err := ValidateConfigFile(path, testRootSection(t))
+	assert.Nil(t, err)
+	return problems
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		problems := validate(t, `
+admin:
+  endpoint: dns:///localhost:30081
+  insecure: "true"
+  authType: Pkce
+  scopes: all,offline
+  maxBackoffDelay: 8s
+storage:
+  type: minio
+  connection:
+    auth-type: accesskey
+    disable-ssl: true
+  stow:
+    config:
+      region: us-east-1
+files:
+  archive: 1
+  retries: "3"
+  timeout: 10
+  labels:
+    - team: flyte
+  sources: [a, b]
+  destination: s3://bucket
+current-context: sandbox
+contexts:
+  - name: sandbox
+    admin:
+      endpoint: dns:///localhost:30081
+    project: flytesnacks
+`)
+		assert.Empty(t, problems)
+	})
+	t.Run("Problems", func(t *testing.T) {
+		problems := validate(t, `admin:
+  endpoint: dns:///localhost:30081
+  insecure: maybe
+  authType: Basic
+  useAuth: true
+  clientSecret: foo
+storage:
+  connection: s3
+files:
+  retries: many
+  timeout: 3x
+  labels: team
+  sources:
+    nested: true
+  oldSetting: x
+logger:
+  level: 0
+contexts:
+  - name: production
+    admin:
+      bogus: 1
+    extra: x
+`)
+		assert.Equal(t, []Problem{
+			{Line: 3, Key: "admin.insecure", Severity: SeverityError, Message: `expected a bool, got "maybe"`},
+			{Line: 4, Key: "admin.authType", Severity: SeverityError, Message: `invalid value "Basic": Basic does not belong to AuthType values`},
+			{Line: 5, Key: "admin.useAuth", Severity: SeverityWarning, Message: "deprecated key: Auth will be enabled/disabled based on admin's dynamically discovered information."},
+			{Line: 6, Key: "admin.clientSecret", Severity: SeverityError, Message: "unknown key"},
+			{Line: 8, Key: "storage.connection", Severity: SeverityError, Message: "expected a map, got a scalar"},
+			{Line: 10, Key: "files.retries", Severity: SeverityError, Message: `expected an integer, got "many"`},
+			{Line: 11, Key: "files.timeout", Severity: SeverityError, Message: `invalid duration "3x"`},
+			{Line: 12, Key: "files.labels", Severity: SeverityError, Message: "expected a map, got a scalar"},
+			{Line: 14, Key: "files.sources", Severity: SeverityError, Message: "expected a list, got a map"},
+			{Line: 15, Key: "files.oldSetting", Severity: SeverityWarning, Message: "deprecated key: use sources instead"},
+			{Line: 16, Key: "logger", Severity: SeverityError, Message: "unknown key: no config section logger is registered"},
+			{Line: 21, Key: "contexts[0].admin.bogus", Severity: SeverityError, Message: "unknown key"},
+			{Line: 22, Key: "contexts[0].extra", Severity: SeverityError, Message: "unknown key: a context holds name, admin, storage, project and domain"},
+		}, problems)
+		assert.Equal(t, 11, ErrorCount(problems))
+	})
+	t.Run("Invalid YAML", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("admin: [\n"), 0600))
+		_, err := ValidateConfigFile(path, testRootSection(t))
+		assert.NotNil(t, err)
+	})
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := ValidateConfigFile(filepath.Join(t.TempDir(), "config.yaml"), testRootSection(t))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
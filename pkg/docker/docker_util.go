@@ -7,10 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/docker/docker/client"
-	"github.com/enescakir/emoji"
 
 	"github.com/flyteorg/flytectl/clierrors"
 
@@ -20,9 +21,24 @@ import (
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 	cmdUtil "github.com/flyteorg/flytectl/pkg/commandutils"
+	"github.com/flyteorg/flytectl/pkg/configutil"
 	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
 )
 
+const (
+	// SandboxNameLabel is set on the sandbox containers to the name of the sandbox, empty for the default one.
+	SandboxNameLabel = "org.flyte.sandbox.name"
+	// SandboxPortOffsetLabel is set on the sandbox containers to the offset applied to their host ports.
+	SandboxPortOffsetLabel = "org.flyte.sandbox.port-offset"
+	// SandboxConsolePortLabel is set on the sandbox containers to the host port of Flyteconsole.
+	SandboxConsolePortLabel = "org.flyte.sandbox.console-port"
+	// SandboxHostPortsLabel is set on the sandbox containers to the default host ports remapped by the sandbox
+	// configuration file, formatted as comma separated default=remapped pairs.
+	SandboxHostPortsLabel = "org.flyte.sandbox.host-ports"
+	// sandboxPortOffsetStep is the distance between the host ports of two sandboxes running side by side.
+	sandboxPortOffsetStep = 100
+)
+
 var (
 	Kubeconfig              = f.FilePathJoin(f.UserHomeDir(), ".flyte", "k3s", "k3s.yaml")
 	SuccessMessage          = "Deploying Flyte..."
@@ -49,21 +65,35 @@ var (
 	StartingBufLen     = 32*1024 + StdWriterPrefixLen + 1
 )
 
-// GetDockerClient will returns the docker client
+// GetDockerClient will returns the client of the detected container runtime
 func GetDockerClient() (Docker, error) {
-	if Client == nil {
-		cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-		if err != nil {
-			fmt.Printf("%v Please Check your docker client %v \n", emoji.GrimacingFace, emoji.Whale)
-			return nil, err
-		}
-		return cli, nil
+	return GetContainerRuntimeClient(RuntimeAuto)
+}
+
+// SandboxContainerName returns the name of the container running the sandbox, the default sandbox having an empty name
+func SandboxContainerName(name string) string {
+	if len(name) == 0 {
+		return FlyteSandboxClusterName
+	}
+	return fmt.Sprintf("%v-%v", FlyteSandboxClusterName, name)
+}
+
+// SandboxVolumes returns the volumes mounting the directory of the sandbox, which receives its kubeconfig
+func SandboxVolumes(name string) []mount.Mount {
+	if len(name) == 0 {
+		return Volumes
+	}
+	return []mount.Mount{
+		{
+			Type:   mount.TypeBind,
+			Source: configutil.SandboxDir(name),
+			Target: K3sDir,
+		},
 	}
-	return Client, nil
 }
 
-// GetSandbox will return sandbox container if it exist
-func GetSandbox(ctx context.Context, cli Docker) (*types.Container, error) {
+// GetSandbox will return the container of the named sandbox if it exist
+func GetSandbox(ctx context.Context, cli Docker, name string) (*types.Container, error) {
 	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
 		All: true,
 	})
@@ -71,16 +101,148 @@ func GetSandbox(ctx context.Context, cli Docker) (*types.Container, error) {
 		return nil, err
 	}
 	for _, v := range containers {
-		if strings.Contains(v.Names[0], FlyteSandboxClusterName) {
+		if containerName(v) == SandboxContainerName(name) {
 			return &v, nil
 		}
 	}
 	return nil, nil
 }
 
-// RemoveSandbox will remove sandbox container if exist
-func RemoveSandbox(ctx context.Context, cli Docker, reader io.Reader) error {
-	c, err := GetSandbox(ctx, cli)
+// ListSandboxes will return the containers of all the sandboxes sorted by name
+func ListSandboxes(ctx context.Context, cli Docker) ([]types.Container, error) {
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		All: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var sandboxes []types.Container
+	for _, v := range containers {
+		if _, ok := v.Labels[SandboxNameLabel]; ok || containerName(v) == FlyteSandboxClusterName {
+			sandboxes = append(sandboxes, v)
+		}
+	}
+	sort.Slice(sandboxes, func(i, j int) bool {
+		return containerName(sandboxes[i]) < containerName(sandboxes[j])
+	})
+	return sandboxes, nil
+}
+
+// SandboxName returns the name of the sandbox run by the container
+func SandboxName(c types.Container) string {
+	return strings.TrimPrefix(strings.TrimPrefix(containerName(c), FlyteSandboxClusterName), "-")
+}
+
+// SandboxPortOffset returns the offset applied to the host ports of the sandbox container
+func SandboxPortOffset(c types.Container) int {
+	offset, err := strconv.Atoi(c.Labels[SandboxPortOffsetLabel])
+	if err != nil {
+		return 0
+	}
+	return offset
+}
+
+// NextPortOffset returns the lowest port offset which isn't used by the existing sandboxes. The default sandbox
+// always uses the offset 0, the named ones the following multiples of 100.
+func NextPortOffset(ctx context.Context, cli Docker) (int, error) {
+	sandboxes, err := ListSandboxes(ctx, cli)
+	if err != nil {
+		return 0, err
+	}
+	used := map[int]bool{}
+	for _, c := range sandboxes {
+		used[SandboxPortOffset(c)] = true
+	}
+	offset := sandboxPortOffsetStep
+	for used[offset] {
+		offset += sandboxPortOffsetStep
+	}
+	return offset, nil
+}
+
+// OffsetPortBindings returns a copy of the port bindings with the host ports shifted by the offset
+func OffsetPortBindings(portBindings map[nat.Port][]nat.PortBinding, offset int) (map[nat.Port][]nat.PortBinding, error) {
+	shifted := make(map[nat.Port][]nat.PortBinding, len(portBindings))
+	for port, bindings := range portBindings {
+		for _, binding := range bindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host port %v: %w", binding.HostPort, err)
+			}
+			shifted[port] = append(shifted[port], nat.PortBinding{
+				HostIP:   binding.HostIP,
+				HostPort: strconv.Itoa(hostPort + offset),
+			})
+		}
+	}
+	return shifted, nil
+}
+
+// RemapPortBindings returns a copy of the port bindings with the host ports found in the remap replaced by their
+// remapped value. Every remapped port must be one of the host ports of the bindings.
+func RemapPortBindings(portBindings map[nat.Port][]nat.PortBinding, remap map[int]int) (map[nat.Port][]nat.PortBinding, error) {
+	remapped := make(map[nat.Port][]nat.PortBinding, len(portBindings))
+	found := map[int]bool{}
+	for port, bindings := range portBindings {
+		for _, binding := range bindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err != nil {
+				return nil, fmt.Errorf("invalid host port %v: %w", binding.HostPort, err)
+			}
+			if newPort, ok := remap[hostPort]; ok {
+				found[hostPort] = true
+				hostPort = newPort
+			}
+			remapped[port] = append(remapped[port], nat.PortBinding{
+				HostIP:   binding.HostIP,
+				HostPort: strconv.Itoa(hostPort),
+			})
+		}
+	}
+	for hostPort := range remap {
+		if !found[hostPort] {
+			return nil, fmt.Errorf("host port %v is not a sandbox port and can't be remapped", hostPort)
+		}
+	}
+	return remapped, nil
+}
+
+// FormatHostPorts formats the remapped host ports as the value of the SandboxHostPortsLabel
+func FormatHostPorts(remap map[int]int) string {
+	pairs := make([]string, 0, len(remap))
+	for from, to := range remap {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", from, to))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// SandboxHostPort returns the host port which the default host port of the sandbox is published on, given the labels
+// of its container. The port is the remapped one if any, shifted by the port offset of the sandbox.
+func SandboxHostPort(labels map[string]string, port int) int {
+	hostPort := port
+	for _, pair := range strings.Split(labels[SandboxHostPortsLabel], ",") {
+		from, to, ok := strings.Cut(pair, "=")
+		if !ok || from != strconv.Itoa(port) {
+			continue
+		}
+		if remapped, err := strconv.Atoi(to); err == nil {
+			hostPort = remapped
+		}
+	}
+	return hostPort + SandboxPortOffset(types.Container{Labels: labels})
+}
+
+func containerName(c types.Container) string {
+	if len(c.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+// RemoveSandbox will remove the container of the named sandbox if exist
+func RemoveSandbox(ctx context.Context, cli Docker, reader io.Reader, name string) error {
+	c, err := GetSandbox(ctx, cli, name)
 	if err != nil {
 		return err
 	}
@@ -160,18 +322,19 @@ func PullDockerImage(ctx context.Context, cli Docker, image string, pullPolicy I
 
 //StartContainer will create and start docker container
 func StartContainer(ctx context.Context, cli Docker, volumes []mount.Mount, exposedPorts map[nat.Port]struct{},
-	portBindings map[nat.Port][]nat.PortBinding, name, image string, additionalEnvVars []string) (string, error) {
-	// Append the additional env variables to the default list of env
-	Environment = append(Environment, additionalEnvVars...)
+	portBindings map[nat.Port][]nat.PortBinding, name, image string, additionalEnvVars []string, labels map[string]string,
+	resources container.Resources) (string, error) {
 	resp, err := cli.ContainerCreate(ctx, &container.Config{
-		Env:          Environment,
+		Env:          mergeEnv(Environment, additionalEnvVars),
 		Image:        image,
 		Tty:          false,
 		ExposedPorts: exposedPorts,
+		Labels:       labels,
 	}, &container.HostConfig{
 		Mounts:       volumes,
 		PortBindings: portBindings,
 		Privileged:   true,
+		Resources:    resources,
 	}, nil,
 		nil, name)
 
@@ -185,6 +348,23 @@ func StartContainer(ctx context.Context, cli Docker, volumes []mount.Mount, expo
 	return resp.ID, nil
 }
 
+// mergeEnv appends the additional env variables to the default ones, an additional variable replacing the default one
+// with the same key.
+func mergeEnv(env, additionalEnvVars []string) []string {
+	merged := make([]string, 0, len(env)+len(additionalEnvVars))
+	index := map[string]int{}
+	for _, v := range append(append([]string{}, env...), additionalEnvVars...) {
+		key := strings.SplitN(v, "=", 2)[0]
+		if i, ok := index[key]; ok {
+			merged[i] = v
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, v)
+	}
+	return merged
+}
+
 // ReadLogs will return io scanner for reading the logs of a container
 func ReadLogs(ctx context.Context, cli Docker, id string) (*bufio.Scanner, error) {
 	reader, err := cli.ContainerLogs(ctx, id, types.ContainerLogsOptions{
@@ -199,6 +379,27 @@ func ReadLogs(ctx context.Context, cli Docker, id string) (*bufio.Scanner, error
 	return bufio.NewScanner(reader), nil
 }
 
+// CopyLogs will write the logs of a container to the writers. Logs are followed until the context is done if follow is
+// set, and restricted to the last since duration if it is not zero.
+func CopyLogs(ctx context.Context, cli Docker, id string, follow bool, since time.Duration, stdout, stderr io.Writer) error {
+	options := types.ContainerLogsOptions{
+		ShowStderr: true,
+		ShowStdout: true,
+		Timestamps: true,
+		Follow:     follow,
+	}
+	if since > 0 {
+		options.Since = since.String()
+	}
+	reader, err := cli.ContainerLogs(ctx, id, options)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = stdcopy.StdCopy(stdout, stderr, reader)
+	return err
+}
+
 // WaitForSandbox will wait until it doesn't get success message
 func WaitForSandbox(reader *bufio.Scanner, message string) bool {
 	for reader.Scan() {
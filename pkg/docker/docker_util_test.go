@@ -2,15 +2,19 @@ package docker
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
 	"github.com/flyteorg/flytectl/pkg/docker/mocks"
 	"github.com/stretchr/testify/mock"
 
@@ -44,7 +48,7 @@ func TestGetSandbox(t *testing.T) {
 		ctx := context.Background()
 
 		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(containers, nil)
-		c, err := GetSandbox(ctx, mockDocker)
+		c, err := GetSandbox(ctx, mockDocker, "")
 		assert.Equal(t, c.Names[0], FlyteSandboxClusterName)
 		assert.Nil(t, err)
 	})
@@ -54,7 +58,7 @@ func TestGetSandbox(t *testing.T) {
 		ctx := context.Background()
 
 		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
-		c, err := GetSandbox(ctx, mockDocker)
+		c, err := GetSandbox(ctx, mockDocker, "")
 		assert.Nil(t, c)
 		assert.Nil(t, err)
 	})
@@ -65,7 +69,7 @@ func TestGetSandbox(t *testing.T) {
 
 		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(containers, nil)
 		mockDocker.OnContainerRemove(ctx, mock.Anything, types.ContainerRemoveOptions{Force: true}).Return(nil)
-		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("y"))
+		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("y"), "")
 		assert.Nil(t, err)
 	})
 
@@ -80,7 +84,7 @@ func TestRemoveSandboxWithNoReply(t *testing.T) {
 		// Verify the attributes
 		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(containers, nil)
 		mockDocker.OnContainerRemove(ctx, mock.Anything, types.ContainerRemoveOptions{Force: true}).Return(nil)
-		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("n"))
+		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("n"), "")
 		assert.NotNil(t, err)
 	})
 
@@ -91,12 +95,102 @@ func TestRemoveSandboxWithNoReply(t *testing.T) {
 		// Verify the attributes
 		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
 		mockDocker.OnContainerRemove(ctx, mock.Anything, types.ContainerRemoveOptions{Force: true}).Return(nil)
-		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("n"))
+		err := RemoveSandbox(ctx, mockDocker, strings.NewReader("n"), "")
 		assert.Nil(t, err)
 	})
 
 }
 
+func TestNamedSandboxes(t *testing.T) {
+	ctx := context.Background()
+	sandboxes := []types.Container{
+		{ID: "2", Names: []string{"/flyte-sandbox-feature-x"}, Labels: map[string]string{
+			SandboxNameLabel: "feature-x", SandboxPortOffsetLabel: "100"}},
+		{ID: "1", Names: []string{"/flyte-sandbox"}},
+		{ID: "3", Names: []string{"/nginx"}},
+		{ID: "4", Names: []string{"/flyte-sandbox-bugfix"}, Labels: map[string]string{
+			SandboxNameLabel: "bugfix", SandboxPortOffsetLabel: "300"}},
+	}
+
+	t.Run("Container name", func(t *testing.T) {
+		assert.Equal(t, "flyte-sandbox", SandboxContainerName(""))
+		assert.Equal(t, "flyte-sandbox-feature-x", SandboxContainerName("feature-x"))
+	})
+
+	t.Run("Get named sandbox", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(sandboxes, nil)
+		c, err := GetSandbox(ctx, mockDocker, "feature-x")
+		assert.Nil(t, err)
+		assert.Equal(t, "2", c.ID)
+		c, err = GetSandbox(ctx, mockDocker, "")
+		assert.Nil(t, err)
+		assert.Equal(t, "1", c.ID)
+		c, err = GetSandbox(ctx, mockDocker, "feature")
+		assert.Nil(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("List sandboxes", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(sandboxes, nil)
+		list, err := ListSandboxes(ctx, mockDocker)
+		assert.Nil(t, err)
+		var names []string
+		for _, c := range list {
+			names = append(names, SandboxName(c))
+		}
+		assert.Equal(t, []string{"", "bugfix", "feature-x"}, names)
+	})
+
+	t.Run("Next port offset", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(sandboxes, nil)
+		offset, err := NextPortOffset(ctx, mockDocker)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, offset)
+	})
+
+	t.Run("Next port offset error", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(nil, fmt.Errorf("error"))
+		_, err := NextPortOffset(ctx, mockDocker)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Offset port bindings", func(t *testing.T) {
+		_, bindings, _ := GetSandboxPorts()
+		shifted, err := OffsetPortBindings(bindings, 100)
+		assert.Nil(t, err)
+		assert.Equal(t, "30186", shifted["30086/tcp"][0].HostPort)
+		assert.Equal(t, "0.0.0.0", shifted["30086/tcp"][0].HostIP)
+		assert.Equal(t, "30086", bindings["30086/tcp"][0].HostPort)
+	})
+}
+
+func TestRemapPortBindings(t *testing.T) {
+	_, portBindings, err := GetSandboxPorts()
+	assert.Nil(t, err)
+	remapped, err := RemapPortBindings(portBindings, map[int]int{30086: 31086})
+	assert.Nil(t, err)
+	assert.Equal(t, []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "31086"}}, remapped["30086/tcp"])
+	assert.Equal(t, portBindings["30084/tcp"], remapped["30084/tcp"])
+
+	_, err = RemapPortBindings(portBindings, map[int]int{30000: 31000})
+	assert.EqualError(t, err, "host port 30000 is not a sandbox port and can't be remapped")
+}
+
+func TestSandboxHostPort(t *testing.T) {
+	labels := map[string]string{
+		SandboxPortOffsetLabel: "100",
+		SandboxHostPortsLabel:  FormatHostPorts(map[int]int{30086: 31086, 30081: 31081}),
+	}
+	assert.Equal(t, "30081=31081,30086=31086", labels[SandboxHostPortsLabel])
+	assert.Equal(t, 31186, SandboxHostPort(labels, 30086))
+	assert.Equal(t, 30184, SandboxHostPort(labels, 30084))
+	assert.Equal(t, 30084, SandboxHostPort(nil, 30084))
+}
+
 func TestPullDockerImage(t *testing.T) {
 	t.Run("Successfully pull image Always", func(t *testing.T) {
 		setupSandbox()
@@ -160,7 +254,7 @@ func TestStartContainer(t *testing.T) {
 			ID: "Hello",
 		}, nil)
 		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
-		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil)
+		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil, nil, container.Resources{})
 		assert.Nil(t, err)
 		assert.Greater(t, len(id), 0)
 		assert.Equal(t, id, "Hello")
@@ -172,8 +266,8 @@ func TestStartContainer(t *testing.T) {
 		ctx := context.Background()
 		// Setup additional env
 		additionalEnv := []string{"a=1", "b=2"}
-		expectedEnv := append(Environment, "a=1")
-		expectedEnv = append(expectedEnv, "b=2")
+		defaultEnv := append([]string{}, Environment...)
+		expectedEnv := append(append([]string{}, Environment...), "a=1", "b=2")
 
 		// Verify the attributes
 		mockDocker.OnContainerCreate(ctx, &container.Config{
@@ -189,11 +283,40 @@ func TestStartContainer(t *testing.T) {
 			ID: "Hello",
 		}, nil)
 		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
-		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, additionalEnv)
+		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, additionalEnv, nil, container.Resources{})
 		assert.Nil(t, err)
 		assert.Greater(t, len(id), 0)
 		assert.Equal(t, id, "Hello")
-		assert.Equal(t, expectedEnv, Environment)
+		assert.Equal(t, defaultEnv, Environment)
+	})
+
+	t.Run("Successfully create a container with overridden Env and labels", func(t *testing.T) {
+		setupSandbox()
+		mockDocker := &mocks.Docker{}
+		ctx := context.Background()
+		additionalEnv := []string{"FLYTE_HOST=localhost:30181", "a=1"}
+		labels := map[string]string{SandboxNameLabel: "feature-x"}
+		expectedEnv := append([]string{}, Environment...)
+		expectedEnv[2] = "FLYTE_HOST=localhost:30181"
+		expectedEnv = append(expectedEnv, "a=1")
+
+		mockDocker.OnContainerCreate(ctx, &container.Config{
+			Env:          expectedEnv,
+			Image:        imageName,
+			Tty:          false,
+			ExposedPorts: p1,
+			Labels:       labels,
+		}, &container.HostConfig{
+			Mounts:       Volumes,
+			PortBindings: p2,
+			Privileged:   true,
+		}, nil, nil, mock.Anything).Return(container.ContainerCreateCreatedBody{
+			ID: "Hello",
+		}, nil)
+		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
+		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, additionalEnv, labels, container.Resources{})
+		assert.Nil(t, err)
+		assert.Equal(t, "Hello", id)
 	})
 
 	t.Run("Error in creating container", func(t *testing.T) {
@@ -215,7 +338,7 @@ func TestStartContainer(t *testing.T) {
 			ID: "",
 		}, fmt.Errorf("error"))
 		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
-		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil)
+		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil, nil, container.Resources{})
 		assert.NotNil(t, err)
 		assert.Equal(t, len(id), 0)
 		assert.Equal(t, id, "")
@@ -240,7 +363,7 @@ func TestStartContainer(t *testing.T) {
 			ID: "Hello",
 		}, nil)
 		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(fmt.Errorf("error"))
-		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil)
+		id, err := StartContainer(ctx, mockDocker, Volumes, p1, p2, "nginx", imageName, nil, nil, container.Resources{})
 		assert.NotNil(t, err)
 		assert.Equal(t, len(id), 0)
 		assert.Equal(t, id, "")
@@ -277,6 +400,30 @@ func TestReadLogs(t *testing.T) {
 	})
 }
 
+func TestCopyLogs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Since", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerLogsMatch(ctx, "test", types.ContainerLogsOptions{
+			ShowStderr: true,
+			ShowStdout: true,
+			Timestamps: true,
+			Since:      "10m0s",
+		}).Return(io.NopCloser(strings.NewReader("")), nil)
+		var out bytes.Buffer
+		assert.Nil(t, CopyLogs(ctx, mockDocker, "test", false, 10*time.Minute, &out, &out))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Error in reading logs", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerLogsMatch(ctx, "test", mock.Anything).Return(nil, fmt.Errorf("error"))
+		var out bytes.Buffer
+		assert.NotNil(t, CopyLogs(ctx, mockDocker, "test", true, 0, &out, &out))
+	})
+}
+
 func TestWaitForSandbox(t *testing.T) {
 	setupSandbox()
 	t.Run("Successfully read logs ", func(t *testing.T) {
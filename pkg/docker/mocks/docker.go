@@ -8,14 +8,12 @@ import (
 	container "github.com/docker/docker/api/types/container"
 
 	io "io"
-
-	mock "github.com/stretchr/testify/mock"
-
-	network "github.com/docker/docker/api/types/network"
+	time "time"
 
 	types "github.com/docker/docker/api/types"
-
+	network "github.com/docker/docker/api/types/network"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Docker is an autogenerated mock type for the Docker type
@@ -179,6 +177,45 @@ func (_m *Docker) ContainerExecInspect(ctx context.Context, execID string) (type
 	return r0, r1
 }
 
+type Docker_ContainerInspect struct {
+	*mock.Call
+}
+
+func (_m Docker_ContainerInspect) Return(_a0 types.ContainerJSON, _a1 error) *Docker_ContainerInspect {
+	return &Docker_ContainerInspect{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *Docker) OnContainerInspect(ctx context.Context, containerID string) *Docker_ContainerInspect {
+	c_call := _m.On("ContainerInspect", ctx, containerID)
+	return &Docker_ContainerInspect{Call: c_call}
+}
+
+func (_m *Docker) OnContainerInspectMatch(matchers ...interface{}) *Docker_ContainerInspect {
+	c_call := _m.On("ContainerInspect", matchers...)
+	return &Docker_ContainerInspect{Call: c_call}
+}
+
+// ContainerInspect provides a mock function with given fields: ctx, containerID
+func (_m *Docker) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	ret := _m.Called(ctx, containerID)
+
+	var r0 types.ContainerJSON
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.ContainerJSON); ok {
+		r0 = rf(ctx, containerID)
+	} else {
+		r0 = ret.Get(0).(types.ContainerJSON)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, containerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type Docker_ContainerList struct {
 	*mock.Call
 }
@@ -325,6 +362,38 @@ func (_m *Docker) ContainerStart(ctx context.Context, containerID string, option
 	return r0
 }
 
+type Docker_ContainerStop struct {
+	*mock.Call
+}
+
+func (_m Docker_ContainerStop) Return(_a0 error) *Docker_ContainerStop {
+	return &Docker_ContainerStop{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Docker) OnContainerStop(ctx context.Context, containerID string, timeout *time.Duration) *Docker_ContainerStop {
+	c_call := _m.On("ContainerStop", ctx, containerID, timeout)
+	return &Docker_ContainerStop{Call: c_call}
+}
+
+func (_m *Docker) OnContainerStopMatch(matchers ...interface{}) *Docker_ContainerStop {
+	c_call := _m.On("ContainerStop", matchers...)
+	return &Docker_ContainerStop{Call: c_call}
+}
+
+// ContainerStop provides a mock function with given fields: ctx, containerID, timeout
+func (_m *Docker) ContainerStop(ctx context.Context, containerID string, timeout *time.Duration) error {
+	ret := _m.Called(ctx, containerID, timeout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Duration) error); ok {
+		r0 = rf(ctx, containerID, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type Docker_ContainerWait struct {
 	*mock.Call
 }
@@ -368,6 +437,86 @@ func (_m *Docker) ContainerWait(ctx context.Context, containerID string, conditi
 	return r0, r1
 }
 
+type Docker_CopyFromContainer struct {
+	*mock.Call
+}
+
+func (_m Docker_CopyFromContainer) Return(_a0 io.ReadCloser, _a1 types.ContainerPathStat, _a2 error) *Docker_CopyFromContainer {
+	return &Docker_CopyFromContainer{Call: _m.Call.Return(_a0, _a1, _a2)}
+}
+
+func (_m *Docker) OnCopyFromContainer(ctx context.Context, containerID string, srcPath string) *Docker_CopyFromContainer {
+	c_call := _m.On("CopyFromContainer", ctx, containerID, srcPath)
+	return &Docker_CopyFromContainer{Call: c_call}
+}
+
+func (_m *Docker) OnCopyFromContainerMatch(matchers ...interface{}) *Docker_CopyFromContainer {
+	c_call := _m.On("CopyFromContainer", matchers...)
+	return &Docker_CopyFromContainer{Call: c_call}
+}
+
+// CopyFromContainer provides a mock function with given fields: ctx, containerID, srcPath
+func (_m *Docker) CopyFromContainer(ctx context.Context, containerID string, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	ret := _m.Called(ctx, containerID, srcPath)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) io.ReadCloser); ok {
+		r0 = rf(ctx, containerID, srcPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 types.ContainerPathStat
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) types.ContainerPathStat); ok {
+		r1 = rf(ctx, containerID, srcPath)
+	} else {
+		r1 = ret.Get(1).(types.ContainerPathStat)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, containerID, srcPath)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type Docker_CopyToContainer struct {
+	*mock.Call
+}
+
+func (_m Docker_CopyToContainer) Return(_a0 error) *Docker_CopyToContainer {
+	return &Docker_CopyToContainer{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Docker) OnCopyToContainer(ctx context.Context, containerID string, dstPath string, content io.Reader, options types.CopyToContainerOptions) *Docker_CopyToContainer {
+	c_call := _m.On("CopyToContainer", ctx, containerID, dstPath, content, options)
+	return &Docker_CopyToContainer{Call: c_call}
+}
+
+func (_m *Docker) OnCopyToContainerMatch(matchers ...interface{}) *Docker_CopyToContainer {
+	c_call := _m.On("CopyToContainer", matchers...)
+	return &Docker_CopyToContainer{Call: c_call}
+}
+
+// CopyToContainer provides a mock function with given fields: ctx, containerID, dstPath, content, options
+func (_m *Docker) CopyToContainer(ctx context.Context, containerID string, dstPath string, content io.Reader, options types.CopyToContainerOptions) error {
+	ret := _m.Called(ctx, containerID, dstPath, content, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, types.CopyToContainerOptions) error); ok {
+		r0 = rf(ctx, containerID, dstPath, content, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type Docker_ImageList struct {
 	*mock.Call
 }
@@ -449,3 +598,122 @@ func (_m *Docker) ImagePull(ctx context.Context, refStr string, options types.Im
 
 	return r0, r1
 }
+
+type Docker_ImageSave struct {
+	*mock.Call
+}
+
+func (_m Docker_ImageSave) Return(_a0 io.ReadCloser, _a1 error) *Docker_ImageSave {
+	return &Docker_ImageSave{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *Docker) OnImageSave(ctx context.Context, imageIDs []string) *Docker_ImageSave {
+	c_call := _m.On("ImageSave", ctx, imageIDs)
+	return &Docker_ImageSave{Call: c_call}
+}
+
+func (_m *Docker) OnImageSaveMatch(matchers ...interface{}) *Docker_ImageSave {
+	c_call := _m.On("ImageSave", matchers...)
+	return &Docker_ImageSave{Call: c_call}
+}
+
+// ImageSave provides a mock function with given fields: ctx, imageIDs
+func (_m *Docker) ImageSave(ctx context.Context, imageIDs []string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, imageIDs)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, []string) io.ReadCloser); ok {
+		r0 = rf(ctx, imageIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, imageIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type Docker_Info struct {
+	*mock.Call
+}
+
+func (_m Docker_Info) Return(_a0 types.Info, _a1 error) *Docker_Info {
+	return &Docker_Info{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *Docker) OnInfo(ctx context.Context) *Docker_Info {
+	c_call := _m.On("Info", ctx)
+	return &Docker_Info{Call: c_call}
+}
+
+func (_m *Docker) OnInfoMatch(matchers ...interface{}) *Docker_Info {
+	c_call := _m.On("Info", matchers...)
+	return &Docker_Info{Call: c_call}
+}
+
+// Info provides a mock function with given fields: ctx
+func (_m *Docker) Info(ctx context.Context) (types.Info, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Info
+	if rf, ok := ret.Get(0).(func(context.Context) types.Info); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Info)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type Docker_ServerVersion struct {
+	*mock.Call
+}
+
+func (_m Docker_ServerVersion) Return(_a0 types.Version, _a1 error) *Docker_ServerVersion {
+	return &Docker_ServerVersion{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *Docker) OnServerVersion(ctx context.Context) *Docker_ServerVersion {
+	c_call := _m.On("ServerVersion", ctx)
+	return &Docker_ServerVersion{Call: c_call}
+}
+
+func (_m *Docker) OnServerVersionMatch(matchers ...interface{}) *Docker_ServerVersion {
+	c_call := _m.On("ServerVersion", matchers...)
+	return &Docker_ServerVersion{Call: c_call}
+}
+
+// ServerVersion provides a mock function with given fields: ctx
+func (_m *Docker) ServerVersion(ctx context.Context) (types.Version, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Version
+	if rf, ok := ret.Get(0).(func(context.Context) types.Version); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Version)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
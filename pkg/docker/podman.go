@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// maxPrivilegedPort is the highest port which rootless podman can't publish on the host.
+const maxPrivilegedPort = 1023
+
+// podmanClient talks to the docker compatible API of podman, and adapts the sandbox containers to the constraints of
+// podman or fails with an explicit error for the features it doesn't support.
+type podmanClient struct {
+	Docker
+
+	infoOnce sync.Once
+	info     types.Info
+	infoErr  error
+}
+
+// NewPodmanClient returns the client of the podman API socket at the host URL, e.g. unix:///run/podman/podman.sock
+func NewPodmanClient(host string) (Docker, error) {
+	if !strings.HasPrefix(host, "unix://") {
+		return nil, fmt.Errorf("podman host %v is not supported. Only unix sockets are supported by the podman runtime", host)
+	}
+	cli, err := client.NewClientWithOpts(client.WithHost(host), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	return newPodmanClient(cli), nil
+}
+
+func newPodmanClient(cli Docker) *podmanClient {
+	return &podmanClient{Docker: cli}
+}
+
+// Info returns the info of the podman service, which doesn't change during the lifetime of the client
+func (p *podmanClient) Info(ctx context.Context) (types.Info, error) {
+	p.infoOnce.Do(func() {
+		p.info, p.infoErr = p.Docker.Info(ctx)
+	})
+	return p.info, p.infoErr
+}
+
+func (p *podmanClient) hasSecurityOption(ctx context.Context, name string) (bool, error) {
+	info, err := p.Info(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, option := range info.SecurityOptions {
+		if option == "name="+name || strings.HasPrefix(option, "name="+name+",") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ContainerCreate checks that rootless podman can publish the host ports and run the privileged container, and
+// relabels the bind mounts when SELinux is enabled so that the container can access them.
+func (p *podmanClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig,
+	networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.ContainerCreateCreatedBody, error) {
+	if hostConfig == nil {
+		return p.Docker.ContainerCreate(ctx, config, hostConfig, networkingConfig, platform, containerName)
+	}
+	rootless, err := p.hasSecurityOption(ctx, "rootless")
+	if err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+	if rootless {
+		if err := p.checkRootless(ctx, hostConfig); err != nil {
+			return container.ContainerCreateCreatedBody{}, err
+		}
+	}
+	selinux, err := p.hasSecurityOption(ctx, "selinux")
+	if err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+	adapted, err := podmanHostConfig(hostConfig, selinux)
+	if err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+	return p.Docker.ContainerCreate(ctx, config, adapted, networkingConfig, platform, containerName)
+}
+
+func (p *podmanClient) checkRootless(ctx context.Context, hostConfig *container.HostConfig) error {
+	for port, bindings := range hostConfig.PortBindings {
+		for _, binding := range bindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err == nil && hostPort > 0 && hostPort <= maxPrivilegedPort {
+				return fmt.Errorf("rootless podman can't publish the privileged host port %v of container port %v. "+
+					"Please remap it to a port above %v", hostPort, port, maxPrivilegedPort)
+			}
+		}
+	}
+	if hostConfig.Privileged {
+		info, err := p.Info(ctx)
+		if err != nil {
+			return err
+		}
+		if info.CgroupVersion == "1" {
+			return fmt.Errorf("the privileged sandbox container requires cgroup v2 with rootless podman. " +
+				"Please enable cgroup v2 or run podman as root")
+		}
+	}
+	return nil
+}
+
+// podmanHostConfig returns a copy of the host config whose bind mounts are checked to exist, podman not creating their
+// source, and converted to binds relabeled for the container when SELinux is enabled.
+func podmanHostConfig(hostConfig *container.HostConfig, selinux bool) (*container.HostConfig, error) {
+	adapted := *hostConfig
+	adapted.Mounts = nil
+	adapted.Binds = append([]string{}, hostConfig.Binds...)
+	for _, m := range hostConfig.Mounts {
+		if m.Type != mount.TypeBind {
+			adapted.Mounts = append(adapted.Mounts, m)
+			continue
+		}
+		if _, err := os.Stat(m.Source); err != nil {
+			return nil, fmt.Errorf("source %v of the bind mount to %v must exist with podman: %w", m.Source, m.Target, err)
+		}
+		if !selinux {
+			adapted.Mounts = append(adapted.Mounts, m)
+			continue
+		}
+		options := []string{"z"}
+		if m.ReadOnly {
+			options = append([]string{"ro"}, options...)
+		}
+		adapted.Binds = append(adapted.Binds, fmt.Sprintf("%v:%v:%v", m.Source, m.Target, strings.Join(options, ",")))
+	}
+	return &adapted, nil
+}
@@ -0,0 +1,173 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+)
+
+// podmanStandIn serves the subset of the docker compatible API of podman used by the sandbox on a unix socket
+type podmanStandIn struct {
+	host    string
+	info    types.Info
+	created *container.HostConfig
+}
+
+func startPodmanStandIn(t *testing.T, info types.Info) *podmanStandIn {
+	// Unix socket paths are limited to about 100 characters, which the test temp dirs can exceed.
+	dir, err := os.MkdirTemp("", "podman")
+	assert.Nil(t, err)
+	socket := filepath.Join(dir, "podman.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.Nil(t, err)
+	p := &podmanStandIn{host: "unix://" + socket, info: info}
+	server := &http.Server{Handler: http.HandlerFunc(p.serve)} // #nosec G112
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = os.RemoveAll(dir)
+	})
+	return p
+}
+
+func (p *podmanStandIn) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("API-Version", "1.40")
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/_ping"):
+		_, _ = w.Write([]byte("OK"))
+	case strings.HasSuffix(r.URL.Path, "/version"):
+		_ = json.NewEncoder(w).Encode(types.Version{Version: "4.2.0", APIVersion: "1.40",
+			Components: []types.ComponentVersion{{Name: podmanComponentName, Version: "4.2.0"}}})
+	case strings.HasSuffix(r.URL.Path, "/info"):
+		_ = json.NewEncoder(w).Encode(p.info)
+	case strings.HasSuffix(r.URL.Path, "/containers/create"):
+		var body struct {
+			HostConfig *container.HostConfig
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		p.created = body.HostConfig
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(container.ContainerCreateCreatedBody{ID: "podman-id"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPodmanClient(t *testing.T) {
+	ctx := context.Background()
+	source := t.TempDir()
+	hostConfig := func(hostPort string) *container.HostConfig {
+		return &container.HostConfig{
+			Privileged:   true,
+			PortBindings: nat.PortMap{"30081/tcp": {{HostIP: "0.0.0.0", HostPort: hostPort}}},
+			Mounts: []mount.Mount{
+				{Type: mount.TypeBind, Source: source, Target: "/root"},
+				{Type: mount.TypeBind, Source: source, Target: "/data", ReadOnly: true},
+			},
+		}
+	}
+
+	t.Run("Rootless with SELinux", func(t *testing.T) {
+		standIn := startPodmanStandIn(t, types.Info{CgroupVersion: "2", SecurityOptions: []string{"name=rootless", "name=selinux"}})
+		cli, err := NewPodmanClient(standIn.host)
+		assert.Nil(t, err)
+		resp, err := cli.ContainerCreate(ctx, &container.Config{}, hostConfig("30081"), nil, nil, "flyte-sandbox")
+		assert.Nil(t, err)
+		assert.Equal(t, "podman-id", resp.ID)
+		assert.Empty(t, standIn.created.Mounts)
+		assert.Equal(t, []string{source + ":/root:z", source + ":/data:ro,z"}, standIn.created.Binds)
+		assert.True(t, standIn.created.Privileged)
+	})
+	t.Run("Rootful without SELinux", func(t *testing.T) {
+		standIn := startPodmanStandIn(t, types.Info{CgroupVersion: "1"})
+		cli, err := NewPodmanClient(standIn.host)
+		assert.Nil(t, err)
+		_, err = cli.ContainerCreate(ctx, &container.Config{}, hostConfig("80"), nil, nil, "flyte-sandbox")
+		assert.Nil(t, err)
+		assert.Len(t, standIn.created.Mounts, 2)
+		assert.Empty(t, standIn.created.Binds)
+	})
+	t.Run("Rootless privileged port", func(t *testing.T) {
+		standIn := startPodmanStandIn(t, types.Info{CgroupVersion: "2", SecurityOptions: []string{"name=rootless"}})
+		cli, err := NewPodmanClient(standIn.host)
+		assert.Nil(t, err)
+		_, err = cli.ContainerCreate(ctx, &container.Config{}, hostConfig("80"), nil, nil, "flyte-sandbox")
+		assert.EqualError(t, err, "rootless podman can't publish the privileged host port 80 of container port 30081/tcp. "+
+			"Please remap it to a port above 1023")
+		assert.Nil(t, standIn.created)
+	})
+	t.Run("Rootless with cgroup v1", func(t *testing.T) {
+		standIn := startPodmanStandIn(t, types.Info{CgroupVersion: "1", SecurityOptions: []string{"name=rootless"}})
+		cli, err := NewPodmanClient(standIn.host)
+		assert.Nil(t, err)
+		_, err = cli.ContainerCreate(ctx, &container.Config{}, hostConfig("30081"), nil, nil, "flyte-sandbox")
+		assert.EqualError(t, err, "the privileged sandbox container requires cgroup v2 with rootless podman. "+
+			"Please enable cgroup v2 or run podman as root")
+	})
+	t.Run("Missing bind mount source", func(t *testing.T) {
+		standIn := startPodmanStandIn(t, types.Info{})
+		cli, err := NewPodmanClient(standIn.host)
+		assert.Nil(t, err)
+		config := hostConfig("30081")
+		config.Mounts[0].Source = filepath.Join(source, "missing")
+		_, err = cli.ContainerCreate(ctx, &container.Config{}, config, nil, nil, "flyte-sandbox")
+		assert.NotNil(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), fmt.Sprintf("source %v of the bind mount to /root must exist with podman", config.Mounts[0].Source)))
+	})
+	t.Run("Remote host", func(t *testing.T) {
+		_, err := NewPodmanClient("ssh://core@localhost:22/run/podman/podman.sock")
+		assert.EqualError(t, err, "podman host ssh://core@localhost:22/run/podman/podman.sock is not supported. "+
+			"Only unix sockets are supported by the podman runtime")
+	})
+}
+
+func TestGetContainerRuntimeClient(t *testing.T) {
+	standIn := startPodmanStandIn(t, types.Info{})
+	Client = nil
+
+	t.Run("Podman from CONTAINER_HOST", func(t *testing.T) {
+		t.Setenv(containerHostEnvName, standIn.host)
+		cli, err := GetContainerRuntimeClient(RuntimePodman)
+		assert.Nil(t, err)
+		assert.IsType(t, &podmanClient{}, cli)
+	})
+	t.Run("Podman detected behind DOCKER_HOST", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", standIn.host)
+		cli, err := GetContainerRuntimeClient(RuntimeAuto)
+		assert.Nil(t, err)
+		assert.IsType(t, &podmanClient{}, cli)
+	})
+	t.Run("Docker", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", standIn.host)
+		cli, err := GetContainerRuntimeClient(RuntimeDocker)
+		assert.Nil(t, err)
+		assert.NotEqual(t, fmt.Sprintf("%T", &podmanClient{}), fmt.Sprintf("%T", cli))
+	})
+	t.Run("Podman socket not found", func(t *testing.T) {
+		t.Setenv(containerHostEnvName, "")
+		t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+		if _, ok := podmanHost(); ok {
+			t.Skip("a podman socket is available on this host")
+		}
+		_, err := GetContainerRuntimeClient(RuntimePodman)
+		assert.EqualError(t, err, "podman socket not found. Please run systemctl --user start podman.socket or set CONTAINER_HOST")
+	})
+	t.Run("Unsupported runtime", func(t *testing.T) {
+		_, err := GetContainerRuntimeClient("containerd")
+		assert.EqualError(t, err, `unsupported container runtime "containerd". Supported runtimes are docker and podman`)
+	})
+}
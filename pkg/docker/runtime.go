@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/client"
+	"github.com/enescakir/emoji"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+)
+
+const (
+	// RuntimeAuto detects the container runtime from the available sockets.
+	RuntimeAuto = "auto"
+	// RuntimeDocker uses the docker daemon configured by the DOCKER_HOST environment variables.
+	RuntimeDocker = "docker"
+	// RuntimePodman uses the docker compatible API socket of podman.
+	RuntimePodman = "podman"
+
+	dockerSocket         = "/var/run/docker.sock"
+	rootfulPodmanSocket  = "/run/podman/podman.sock"
+	podmanComponentName  = "Podman Engine"
+	containerHostEnvName = "CONTAINER_HOST"
+)
+
+// GetContainerRuntimeClient returns the client of the container runtime, docker or podman. An empty or auto runtime is
+// detected from the environment: docker when DOCKER_HOST is set or the docker socket exists, podman otherwise when its
+// socket exists. A docker socket served by podman, e.g. by the podman-docker package, is detected as podman.
+func GetContainerRuntimeClient(runtime string) (Docker, error) {
+	if Client != nil {
+		return Client, nil
+	}
+	switch runtime {
+	case RuntimeDocker:
+		return newDockerClient()
+	case RuntimePodman:
+		host, ok := podmanHost()
+		if !ok {
+			return nil, fmt.Errorf("podman socket not found. Please run systemctl --user start podman.socket or set %v", containerHostEnvName)
+		}
+		return NewPodmanClient(host)
+	case "", RuntimeAuto:
+		return detectRuntimeClient()
+	default:
+		return nil, fmt.Errorf("unsupported container runtime %q. Supported runtimes are %v and %v", runtime, RuntimeDocker, RuntimePodman)
+	}
+}
+
+func newDockerClient() (Docker, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		fmt.Printf("%v Please Check your docker client %v \n", emoji.GrimacingFace, emoji.Whale)
+		return nil, err
+	}
+	return cli, nil
+}
+
+func detectRuntimeClient() (Docker, error) {
+	if len(os.Getenv("DOCKER_HOST")) == 0 && !socketExists(dockerSocket) {
+		if host, ok := podmanHost(); ok {
+			return NewPodmanClient(host)
+		}
+	}
+	cli, err := newDockerClient()
+	if err != nil {
+		return nil, err
+	}
+	version, err := cli.ServerVersion(context.Background())
+	if err != nil {
+		// The errors of an unreachable daemon are reported by the first call of the command.
+		return cli, nil
+	}
+	for _, component := range version.Components {
+		if component.Name == podmanComponentName {
+			return newPodmanClient(cli), nil
+		}
+	}
+	return cli, nil
+}
+
+// podmanHost returns the URL of the podman API socket, either set by CONTAINER_HOST or the one of the rootless podman
+// service of the user, falling back to the rootful one.
+func podmanHost() (string, bool) {
+	if host := os.Getenv(containerHostEnvName); len(host) > 0 {
+		return host, true
+	}
+	var candidates []string
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); len(runtimeDir) > 0 {
+		candidates = append(candidates, f.FilePathJoin(runtimeDir, "podman", "podman.sock"))
+	}
+	candidates = append(candidates, fmt.Sprintf("/run/user/%v/podman/podman.sock", os.Getuid()), rootfulPodmanSocket)
+	for _, socket := range candidates {
+		if socketExists(socket) {
+			return "unix://" + socket, true
+		}
+	}
+	return "", false
+}
+
+func socketExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	stdlibversion "github.com/flyteorg/flytestdlib/version"
 
 	"github.com/google/go-github/v42/github"
+	hversion "github.com/hashicorp/go-version"
 	"github.com/mouuff/go-rocket-update/pkg/provider"
 	"github.com/mouuff/go-rocket-update/pkg/updater"
 	"golang.org/x/oauth2"
@@ -34,15 +36,23 @@ const (
 	darwinMessage           = "To upgrade, run: flytectl upgrade \n"
 	releaseURL              = "https://github.com/flyteorg/flytectl/releases/tag/%s \n"
 	brewInstallDirectory    = "/Cellar/flytectl"
+
+	// ChannelStable selects the latest release of flytectl which isn't a prerelease.
+	ChannelStable = "stable"
+	// ChannelPrerelease selects the latest release of flytectl, prereleases included.
+	ChannelPrerelease = "prerelease"
 )
 
 var Client GHRepoService
 
+// FlytectlAssetName is the name of the release archive of flytectl for the platform
+var FlytectlAssetName = getFlytectlAssetName()
+
 // FlytectlReleaseConfig represent the updater config for flytectl binary
 var FlytectlReleaseConfig = &updater.Updater{
 	Provider: &provider.Github{
 		RepositoryURL: flytectlRepository,
-		ArchiveName:   getFlytectlAssetName(),
+		ArchiveName:   FlytectlAssetName,
 	},
 	ExecutableName: flytectl,
 	Version:        stdlibversion.Version,
@@ -59,6 +69,7 @@ type GHRepoService interface {
 	ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
 	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error)
 	GetCommitSHA1(ctx context.Context, owner, repo, ref, lastSHA string) (string, *github.Response, error)
+	DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error)
 }
 
 // GetLatestRelease returns the latest non-prerelease version of provided repoName, as
@@ -114,6 +125,119 @@ func GetAssetFromRelease(tag, assetName, repoName string, g GHRepoService) (*git
 	return nil, fmt.Errorf("assest is not found in %s[%s] release", repoName, tag)
 }
 
+// GetFlytectlRelease returns the flytectl release to upgrade to. A version pins the release, e.g. v0.5.0, or selects the
+// highest release matching its x or * segments, e.g. v0.5.x, otherwise the latest release of the channel is returned.
+func GetFlytectlRelease(version, channel string, g GHRepoService) (*github.RepositoryRelease, error) {
+	if channel != "" && channel != ChannelStable && channel != ChannelPrerelease {
+		return nil, fmt.Errorf("unsupported channel %q. Supported channels are %v and %v", channel, ChannelStable, ChannelPrerelease)
+	}
+	if len(version) > 0 {
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		if isVersionPattern(version) {
+			return getFlytectlReleaseMatching(version, channel == ChannelPrerelease, g)
+		}
+		release, err := GetReleaseByTag(flytectl, version, g)
+		if err != nil {
+			return nil, fmt.Errorf("flytectl release %v not found: %w", version, err)
+		}
+		return release, nil
+	}
+	if channel != ChannelPrerelease {
+		return GetLatestRelease(flytectl, g)
+	}
+	releases, err := ListReleases(flytectl, g)
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if !release.GetDraft() {
+			return release, nil
+		}
+	}
+	return nil, fmt.Errorf("no flytectl release found")
+}
+
+// getFlytectlReleaseMatching returns the highest flytectl release matching the version pattern, going through every
+// page of releases. Prereleases are only considered when asked for.
+func getFlytectlReleaseMatching(pattern string, prerelease bool, g GHRepoService) (*github.RepositoryRelease, error) {
+	var latest *github.RepositoryRelease
+	var latestVersion *hversion.Version
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := g.ListReleases(context.Background(), owner, flytectl, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			if release.GetDraft() || (release.GetPrerelease() && !prerelease) || !matchesVersionPattern(pattern, release.GetTagName()) {
+				continue
+			}
+			v, err := hversion.NewVersion(release.GetTagName())
+			if err != nil {
+				continue
+			}
+			if latestVersion == nil || v.GreaterThan(latestVersion) {
+				latest, latestVersion = release, v
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no flytectl release matching %v found", pattern)
+	}
+	return latest, nil
+}
+
+func isWildcard(segment string) bool {
+	return segment == "x" || segment == "X" || segment == "*"
+}
+
+// isVersionPattern returns whether any segment of the version is a wildcard, e.g. v0.5.x
+func isVersionPattern(version string) bool {
+	for _, segment := range strings.Split(strings.TrimPrefix(version, "v"), ".") {
+		if isWildcard(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesVersionPattern returns whether the tag matches the version pattern segment by segment, ignoring the
+// prerelease part of the tag. A trailing wildcard matches any remaining segments, e.g. v0.x matches v0.5.3.
+func matchesVersionPattern(pattern, tag string) bool {
+	patternSegments := strings.Split(strings.TrimPrefix(pattern, "v"), ".")
+	core := strings.SplitN(strings.SplitN(strings.TrimPrefix(tag, "v"), "-", 2)[0], "+", 2)[0]
+	tagSegments := strings.Split(core, ".")
+	if len(tagSegments) < len(patternSegments) {
+		return false
+	}
+	if len(tagSegments) > len(patternSegments) && !isWildcard(patternSegments[len(patternSegments)-1]) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if !isWildcard(segment) && segment != tagSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewFlytectlUpdater returns the updater replacing the flytectl binary with the one of the release, once the checksum
+// of the downloaded archive is verified.
+func NewFlytectlUpdater(release *github.RepositoryRelease, g GHRepoService) *updater.Updater {
+	return &updater.Updater{
+		Provider:           NewReleaseProvider(release, FlytectlAssetName, g),
+		ExecutableName:     flytectl,
+		Version:            stdlibversion.Version,
+		OverrideExecutable: FlytectlReleaseConfig.OverrideExecutable,
+	}
+}
+
 // GetSandboxImageSha returns the sha as per input
 func GetSandboxImageSha(tag string, pre bool, g GHRepoService) (string, string, error) {
 	var release *github.RepositoryRelease
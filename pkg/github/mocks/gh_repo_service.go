@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
-	github "github.com/google/go-github/v42/github"
+	io "io"
+	http "net/http"
+
+	v42github "github.com/google/go-github/v42/github"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,11 +17,59 @@ type GHRepoService struct {
 	mock.Mock
 }
 
+type GHRepoService_DownloadReleaseAsset struct {
+	*mock.Call
+}
+
+func (_m GHRepoService_DownloadReleaseAsset) Return(_a0 io.ReadCloser, _a1 string, _a2 error) *GHRepoService_DownloadReleaseAsset {
+	return &GHRepoService_DownloadReleaseAsset{Call: _m.Call.Return(_a0, _a1, _a2)}
+}
+
+func (_m *GHRepoService) OnDownloadReleaseAsset(ctx context.Context, owner string, repo string, id int64, followRedirectsClient *http.Client) *GHRepoService_DownloadReleaseAsset {
+	c_call := _m.On("DownloadReleaseAsset", ctx, owner, repo, id, followRedirectsClient)
+	return &GHRepoService_DownloadReleaseAsset{Call: c_call}
+}
+
+func (_m *GHRepoService) OnDownloadReleaseAssetMatch(matchers ...interface{}) *GHRepoService_DownloadReleaseAsset {
+	c_call := _m.On("DownloadReleaseAsset", matchers...)
+	return &GHRepoService_DownloadReleaseAsset{Call: c_call}
+}
+
+// DownloadReleaseAsset provides a mock function with given fields: ctx, owner, repo, id, followRedirectsClient
+func (_m *GHRepoService) DownloadReleaseAsset(ctx context.Context, owner string, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error) {
+	ret := _m.Called(ctx, owner, repo, id, followRedirectsClient)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *http.Client) io.ReadCloser); ok {
+		r0 = rf(ctx, owner, repo, id, followRedirectsClient)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, *http.Client) string); ok {
+		r1 = rf(ctx, owner, repo, id, followRedirectsClient)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, *http.Client) error); ok {
+		r2 = rf(ctx, owner, repo, id, followRedirectsClient)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type GHRepoService_GetCommitSHA1 struct {
 	*mock.Call
 }
 
-func (_m GHRepoService_GetCommitSHA1) Return(_a0 string, _a1 *github.Response, _a2 error) *GHRepoService_GetCommitSHA1 {
+func (_m GHRepoService_GetCommitSHA1) Return(_a0 string, _a1 *v42github.Response, _a2 error) *GHRepoService_GetCommitSHA1 {
 	return &GHRepoService_GetCommitSHA1{Call: _m.Call.Return(_a0, _a1, _a2)}
 }
 
@@ -33,7 +84,7 @@ func (_m *GHRepoService) OnGetCommitSHA1Match(matchers ...interface{}) *GHRepoSe
 }
 
 // GetCommitSHA1 provides a mock function with given fields: ctx, owner, repo, ref, lastSHA
-func (_m *GHRepoService) GetCommitSHA1(ctx context.Context, owner string, repo string, ref string, lastSHA string) (string, *github.Response, error) {
+func (_m *GHRepoService) GetCommitSHA1(ctx context.Context, owner string, repo string, ref string, lastSHA string) (string, *v42github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, ref, lastSHA)
 
 	var r0 string
@@ -43,12 +94,12 @@ func (_m *GHRepoService) GetCommitSHA1(ctx context.Context, owner string, repo s
 		r0 = ret.Get(0).(string)
 	}
 
-	var r1 *github.Response
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) *github.Response); ok {
+	var r1 *v42github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) *v42github.Response); ok {
 		r1 = rf(ctx, owner, repo, ref, lastSHA)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*github.Response)
+			r1 = ret.Get(1).(*v42github.Response)
 		}
 	}
 
@@ -66,7 +117,7 @@ type GHRepoService_GetLatestRelease struct {
 	*mock.Call
 }
 
-func (_m GHRepoService_GetLatestRelease) Return(_a0 *github.RepositoryRelease, _a1 *github.Response, _a2 error) *GHRepoService_GetLatestRelease {
+func (_m GHRepoService_GetLatestRelease) Return(_a0 *v42github.RepositoryRelease, _a1 *v42github.Response, _a2 error) *GHRepoService_GetLatestRelease {
 	return &GHRepoService_GetLatestRelease{Call: _m.Call.Return(_a0, _a1, _a2)}
 }
 
@@ -81,24 +132,24 @@ func (_m *GHRepoService) OnGetLatestReleaseMatch(matchers ...interface{}) *GHRep
 }
 
 // GetLatestRelease provides a mock function with given fields: ctx, owner, repo
-func (_m *GHRepoService) GetLatestRelease(ctx context.Context, owner string, repo string) (*github.RepositoryRelease, *github.Response, error) {
+func (_m *GHRepoService) GetLatestRelease(ctx context.Context, owner string, repo string) (*v42github.RepositoryRelease, *v42github.Response, error) {
 	ret := _m.Called(ctx, owner, repo)
 
-	var r0 *github.RepositoryRelease
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *github.RepositoryRelease); ok {
+	var r0 *v42github.RepositoryRelease
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v42github.RepositoryRelease); ok {
 		r0 = rf(ctx, owner, repo)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.RepositoryRelease)
+			r0 = ret.Get(0).(*v42github.RepositoryRelease)
 		}
 	}
 
-	var r1 *github.Response
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+	var r1 *v42github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *v42github.Response); ok {
 		r1 = rf(ctx, owner, repo)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*github.Response)
+			r1 = ret.Get(1).(*v42github.Response)
 		}
 	}
 
@@ -116,7 +167,7 @@ type GHRepoService_GetReleaseByTag struct {
 	*mock.Call
 }
 
-func (_m GHRepoService_GetReleaseByTag) Return(_a0 *github.RepositoryRelease, _a1 *github.Response, _a2 error) *GHRepoService_GetReleaseByTag {
+func (_m GHRepoService_GetReleaseByTag) Return(_a0 *v42github.RepositoryRelease, _a1 *v42github.Response, _a2 error) *GHRepoService_GetReleaseByTag {
 	return &GHRepoService_GetReleaseByTag{Call: _m.Call.Return(_a0, _a1, _a2)}
 }
 
@@ -131,24 +182,24 @@ func (_m *GHRepoService) OnGetReleaseByTagMatch(matchers ...interface{}) *GHRepo
 }
 
 // GetReleaseByTag provides a mock function with given fields: ctx, owner, repo, tag
-func (_m *GHRepoService) GetReleaseByTag(ctx context.Context, owner string, repo string, tag string) (*github.RepositoryRelease, *github.Response, error) {
+func (_m *GHRepoService) GetReleaseByTag(ctx context.Context, owner string, repo string, tag string) (*v42github.RepositoryRelease, *v42github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, tag)
 
-	var r0 *github.RepositoryRelease
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *github.RepositoryRelease); ok {
+	var r0 *v42github.RepositoryRelease
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *v42github.RepositoryRelease); ok {
 		r0 = rf(ctx, owner, repo, tag)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*github.RepositoryRelease)
+			r0 = ret.Get(0).(*v42github.RepositoryRelease)
 		}
 	}
 
-	var r1 *github.Response
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) *github.Response); ok {
+	var r1 *v42github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) *v42github.Response); ok {
 		r1 = rf(ctx, owner, repo, tag)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*github.Response)
+			r1 = ret.Get(1).(*v42github.Response)
 		}
 	}
 
@@ -166,11 +217,11 @@ type GHRepoService_ListReleases struct {
 	*mock.Call
 }
 
-func (_m GHRepoService_ListReleases) Return(_a0 []*github.RepositoryRelease, _a1 *github.Response, _a2 error) *GHRepoService_ListReleases {
+func (_m GHRepoService_ListReleases) Return(_a0 []*v42github.RepositoryRelease, _a1 *v42github.Response, _a2 error) *GHRepoService_ListReleases {
 	return &GHRepoService_ListReleases{Call: _m.Call.Return(_a0, _a1, _a2)}
 }
 
-func (_m *GHRepoService) OnListReleases(ctx context.Context, owner string, repo string, opts *github.ListOptions) *GHRepoService_ListReleases {
+func (_m *GHRepoService) OnListReleases(ctx context.Context, owner string, repo string, opts *v42github.ListOptions) *GHRepoService_ListReleases {
 	c_call := _m.On("ListReleases", ctx, owner, repo, opts)
 	return &GHRepoService_ListReleases{Call: c_call}
 }
@@ -181,29 +232,29 @@ func (_m *GHRepoService) OnListReleasesMatch(matchers ...interface{}) *GHRepoSer
 }
 
 // ListReleases provides a mock function with given fields: ctx, owner, repo, opts
-func (_m *GHRepoService) ListReleases(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+func (_m *GHRepoService) ListReleases(ctx context.Context, owner string, repo string, opts *v42github.ListOptions) ([]*v42github.RepositoryRelease, *v42github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, opts)
 
-	var r0 []*github.RepositoryRelease
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.ListOptions) []*github.RepositoryRelease); ok {
+	var r0 []*v42github.RepositoryRelease
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *v42github.ListOptions) []*v42github.RepositoryRelease); ok {
 		r0 = rf(ctx, owner, repo, opts)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*github.RepositoryRelease)
+			r0 = ret.Get(0).([]*v42github.RepositoryRelease)
 		}
 	}
 
-	var r1 *github.Response
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.ListOptions) *github.Response); ok {
+	var r1 *v42github.Response
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *v42github.ListOptions) *v42github.Response); ok {
 		r1 = rf(ctx, owner, repo, opts)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*github.Response)
+			r1 = ret.Get(1).(*v42github.Response)
 		}
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.ListOptions) error); ok {
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *v42github.ListOptions) error); ok {
 		r2 = rf(ctx, owner, repo, opts)
 	} else {
 		r2 = ret.Error(2)
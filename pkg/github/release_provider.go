@@ -0,0 +1,172 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v42/github"
+	"github.com/mouuff/go-rocket-update/pkg/provider"
+)
+
+const checksumsAssetName = "checksums.txt"
+
+// ReleaseProvider provides the files of an archive asset of a flytectl release. The archive is downloaded through the
+// GitHub API and its SHA256 checksum is verified against the checksums file of the release before it is opened.
+type ReleaseProvider struct {
+	Release     *github.RepositoryRelease
+	ArchiveName string
+
+	g                  GHRepoService
+	tmpDir             string
+	decompressProvider provider.Provider
+}
+
+// NewReleaseProvider returns the provider of the archive asset of the release
+func NewReleaseProvider(release *github.RepositoryRelease, archiveName string, g GHRepoService) *ReleaseProvider {
+	return &ReleaseProvider{Release: release, ArchiveName: archiveName, g: g}
+}
+
+// GetLatestVersion returns the tag of the release
+func (r *ReleaseProvider) GetLatestVersion() (string, error) {
+	return r.Release.GetTagName(), nil
+}
+
+// Open downloads the archive, verifies its checksum and opens it. The downloaded files are removed if it fails, the
+// updater not closing the provider in that case.
+func (r *ReleaseProvider) Open() (err error) {
+	defer func() {
+		if err != nil {
+			_ = r.Close()
+		}
+	}()
+	archive, err := r.asset(r.ArchiveName)
+	if err != nil {
+		return err
+	}
+	checksums, err := r.asset(checksumsAssetName)
+	if err != nil {
+		return fmt.Errorf("the integrity of %v can't be verified: %w", r.ArchiveName, err)
+	}
+	expected, err := r.expectedChecksum(checksums)
+	if err != nil {
+		return err
+	}
+
+	r.tmpDir, err = os.MkdirTemp("", "flytectl-upgrade")
+	if err != nil {
+		return err
+	}
+	archivePath := filepath.Join(r.tmpDir, r.ArchiveName)
+	actual, err := r.download(archive, archivePath)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for %v of release %v: expected %v, got %v", r.ArchiveName,
+			r.Release.GetTagName(), expected, actual)
+	}
+	r.decompressProvider, err = provider.Decompress(archivePath)
+	if err != nil {
+		return err
+	}
+	return r.decompressProvider.Open()
+}
+
+// Close closes the archive and removes the downloaded files
+func (r *ReleaseProvider) Close() error {
+	if r.decompressProvider != nil {
+		_ = r.decompressProvider.Close()
+		r.decompressProvider = nil
+	}
+	if len(r.tmpDir) > 0 {
+		err := os.RemoveAll(r.tmpDir)
+		r.tmpDir = ""
+		return err
+	}
+	return nil
+}
+
+// Walk walks the files of the archive
+func (r *ReleaseProvider) Walk(walkFn provider.WalkFunc) error {
+	if r.decompressProvider == nil {
+		return provider.ErrNotOpenned
+	}
+	return r.decompressProvider.Walk(walkFn)
+}
+
+// Retrieve extracts a file of the archive to the destination
+func (r *ReleaseProvider) Retrieve(src string, dest string) error {
+	if r.decompressProvider == nil {
+		return provider.ErrNotOpenned
+	}
+	return r.decompressProvider.Retrieve(src, dest)
+}
+
+func (r *ReleaseProvider) asset(name string) (*github.ReleaseAsset, error) {
+	for _, asset := range r.Release.Assets {
+		if asset.GetName() == name {
+			return asset, nil
+		}
+	}
+	return nil, fmt.Errorf("asset %v is not found in release %v", name, r.Release.GetTagName())
+}
+
+func (r *ReleaseProvider) open(asset *github.ReleaseAsset) (io.ReadCloser, error) {
+	rc, _, err := r.g.DownloadReleaseAsset(context.Background(), owner, flytectl, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		return nil, fmt.Errorf("unable to download asset %v of release %v", asset.GetName(), r.Release.GetTagName())
+	}
+	return rc, nil
+}
+
+// expectedChecksum returns the checksum of the archive listed in the checksums file, which holds a line
+// "<sha256>  <file name>" for every asset of the release.
+func (r *ReleaseProvider) expectedChecksum(checksums *github.ReleaseAsset) (string, error) {
+	rc, err := r.open(checksums)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == r.ArchiveName {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("checksum of %v is not found in %v of release %v", r.ArchiveName, checksumsAssetName,
+		r.Release.GetTagName())
+}
+
+// download writes the asset to the path and returns its SHA256 checksum
+func (r *ReleaseProvider) download(asset *github.ReleaseAsset, path string) (string, error) {
+	rc, err := r.open(asset)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	out, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), rc); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
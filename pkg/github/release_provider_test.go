@@ -0,0 +1,165 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flytectl/pkg/github/mocks"
+	"github.com/google/go-github/v42/github"
+	"github.com/mouuff/go-rocket-update/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func flytectlArchive(t *testing.T, binary string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "flytectl", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(binary))}))
+	_, err := tw.Write([]byte(binary))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gz.Close())
+	return buf.Bytes()
+}
+
+func mockReleaseAssets(archive []byte, checksums string) (*github.RepositoryRelease, *mocks.GHRepoService) {
+	tag := "v0.5.0"
+	archiveID, checksumsID := int64(1), int64(2)
+	archiveName, checksumsName := FlytectlAssetName, checksumsAssetName
+	release := &github.RepositoryRelease{TagName: &tag, Assets: []*github.ReleaseAsset{
+		{ID: &archiveID, Name: &archiveName},
+		{ID: &checksumsID, Name: &checksumsName},
+	}}
+	mockGh := &mocks.GHRepoService{}
+	mockGh.OnDownloadReleaseAssetMatch(mock.Anything, owner, flytectl, archiveID, mock.Anything).Return(
+		ioutil.NopCloser(bytes.NewReader(archive)), "", nil)
+	mockGh.OnDownloadReleaseAssetMatch(mock.Anything, owner, flytectl, checksumsID, mock.Anything).Return(
+		ioutil.NopCloser(bytes.NewBufferString(checksums)), "", nil)
+	return release, mockGh
+}
+
+func TestReleaseProvider(t *testing.T) {
+	archive := flytectlArchive(t, "flytectl v0.5.0")
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("Verified archive", func(t *testing.T) {
+		release, mockGh := mockReleaseAssets(archive, fmt.Sprintf("0123  flytectl_Other_arch.tar.gz\n%v  %v\n", checksum, FlytectlAssetName))
+		p := NewReleaseProvider(release, FlytectlAssetName, mockGh)
+		version, err := p.GetLatestVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "v0.5.0", version)
+		assert.Nil(t, p.Open())
+		defer p.Close()
+
+		var files []string
+		assert.Nil(t, p.Walk(func(info *provider.FileInfo) error {
+			if info.Mode.IsRegular() {
+				files = append(files, info.Path)
+			}
+			return nil
+		}))
+		assert.Equal(t, []string{"flytectl"}, files)
+		dest := filepath.Join(t.TempDir(), "flytectl")
+		assert.Nil(t, p.Retrieve("flytectl", dest))
+		content, err := os.ReadFile(dest)
+		assert.Nil(t, err)
+		assert.Equal(t, "flytectl v0.5.0", string(content))
+	})
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		release, mockGh := mockReleaseAssets([]byte("tampered"), fmt.Sprintf("%v  %v\n", checksum, FlytectlAssetName))
+		p := NewReleaseProvider(release, FlytectlAssetName, mockGh)
+		err := p.Open()
+		defer p.Close()
+		tampered := sha256.Sum256([]byte("tampered"))
+		assert.EqualError(t, err, fmt.Sprintf("checksum mismatch for %v of release v0.5.0: expected %v, got %v",
+			FlytectlAssetName, checksum, hex.EncodeToString(tampered[:])))
+		assert.Equal(t, provider.ErrNotOpenned, p.Walk(func(info *provider.FileInfo) error { return nil }))
+	})
+	t.Run("Archive missing from checksums", func(t *testing.T) {
+		release, mockGh := mockReleaseAssets(archive, "0123  flytectl_Other_arch.tar.gz\n")
+		err := NewReleaseProvider(release, FlytectlAssetName, mockGh).Open()
+		assert.EqualError(t, err, fmt.Sprintf("checksum of %v is not found in checksums.txt of release v0.5.0", FlytectlAssetName))
+	})
+	t.Run("Release without checksums", func(t *testing.T) {
+		release, mockGh := mockReleaseAssets(archive, "")
+		release.Assets = release.Assets[:1]
+		err := NewReleaseProvider(release, FlytectlAssetName, mockGh).Open()
+		assert.EqualError(t, err, fmt.Sprintf("the integrity of %v can't be verified: asset checksums.txt is not found in release v0.5.0",
+			FlytectlAssetName))
+	})
+}
+
+func TestGetFlytectlRelease(t *testing.T) {
+	stable, pre := "v0.5.0", "v0.6.0-b0"
+	isPrerelease := true
+	t.Run("Pinned version", func(t *testing.T) {
+		mockGh := &mocks.GHRepoService{}
+		mockGh.OnGetReleaseByTagMatch(mock.Anything, owner, flytectl, "v0.4.0").Return(&github.RepositoryRelease{TagName: &stable}, nil, nil)
+		release, err := GetFlytectlRelease("0.4.0", ChannelPrerelease, mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, stable, release.GetTagName())
+	})
+	t.Run("Pinned version not found", func(t *testing.T) {
+		mockGh := &mocks.GHRepoService{}
+		mockGh.OnGetReleaseByTagMatch(mock.Anything, owner, flytectl, "v9.9.9").Return(nil, nil, fmt.Errorf("404 Not Found"))
+		_, err := GetFlytectlRelease("v9.9.9", "", mockGh)
+		assert.EqualError(t, err, "flytectl release v9.9.9 not found: 404 Not Found")
+	})
+	t.Run("Wildcard version", func(t *testing.T) {
+		draft := true
+		tags := []string{"v0.5.2", "v0.5.10", "v0.5.11-b0", "v0.5.12", "v0.6.0", "v0.5.9"}
+		mockGh := &mocks.GHRepoService{}
+		mockGh.OnListReleasesMatch(mock.Anything, owner, flytectl, &github.ListOptions{PerPage: 100}).Return([]*github.RepositoryRelease{
+			{TagName: &tags[0]}, {TagName: &tags[1]}, {TagName: &tags[2], Prerelease: &isPrerelease},
+		}, &github.Response{NextPage: 2}, nil)
+		mockGh.OnListReleasesMatch(mock.Anything, owner, flytectl, &github.ListOptions{Page: 2, PerPage: 100}).Return([]*github.RepositoryRelease{
+			{TagName: &tags[3], Draft: &draft}, {TagName: &tags[4]}, {TagName: &tags[5]},
+		}, &github.Response{}, nil)
+		release, err := GetFlytectlRelease("v0.5.x", ChannelStable, mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, "v0.5.10", release.GetTagName())
+
+		release, err = GetFlytectlRelease("0.5.*", ChannelPrerelease, mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, "v0.5.11-b0", release.GetTagName())
+
+		release, err = GetFlytectlRelease("v0.x", "", mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, "v0.6.0", release.GetTagName())
+
+		_, err = GetFlytectlRelease("v1.x", "", mockGh)
+		assert.EqualError(t, err, "no flytectl release matching v1.x found")
+		mockGh.AssertNotCalled(t, "GetReleaseByTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("Stable channel", func(t *testing.T) {
+		mockGh := &mocks.GHRepoService{}
+		mockGh.OnGetLatestReleaseMatch(mock.Anything, owner, flytectl).Return(&github.RepositoryRelease{TagName: &stable}, nil, nil)
+		release, err := GetFlytectlRelease("", ChannelStable, mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, stable, release.GetTagName())
+	})
+	t.Run("Prerelease channel", func(t *testing.T) {
+		draft := true
+		mockGh := &mocks.GHRepoService{}
+		mockGh.OnListReleasesMatch(mock.Anything, owner, flytectl, mock.Anything).Return([]*github.RepositoryRelease{
+			{Draft: &draft}, {TagName: &pre, Prerelease: &isPrerelease}, {TagName: &stable},
+		}, nil, nil)
+		release, err := GetFlytectlRelease("", ChannelPrerelease, mockGh)
+		assert.Nil(t, err)
+		assert.Equal(t, pre, release.GetTagName())
+	})
+	t.Run("Unsupported channel", func(t *testing.T) {
+		_, err := GetFlytectlRelease("", "nightly", &mocks.GHRepoService{})
+		assert.EqualError(t, err, `unsupported channel "nightly". Supported channels are stable and prerelease`)
+	})
+}
@@ -0,0 +1,331 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	contentDigestHeader         = "Docker-Content-Digest"
+	maxManifestSize             = 4 << 20
+	tokenClientID               = "flytectl"
+)
+
+var manifestMediaTypes = []string{
+	ocispec.MediaTypeImageManifest,
+	ocispec.MediaTypeImageIndex,
+	mediaTypeDockerManifest,
+	mediaTypeDockerManifestList,
+}
+
+// Artifact is an artifact pulled from a registry, with the digest of its manifest
+type Artifact struct {
+	Reference Reference
+	Digest    string
+	Layers    []ocispec.Descriptor
+}
+
+// Client pulls artifacts from OCI registries with the registry HTTP API
+type Client struct {
+	HTTPClient *http.Client
+	// Credentials returns the credentials of a registry, nil pulling anonymously
+	Credentials func(registry string) (*Credentials, error)
+	// authorizations are the Authorization headers of the registries, by registry and scope
+	authorizations map[string]string
+}
+
+// NewClient returns a client authenticating with the credentials of the Docker config
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		Credentials: func(registry string) (*Credentials, error) {
+			return DockerCredentials(DockerConfigFile(), registry)
+		},
+	}
+}
+
+// Pull fetches the manifest of the artifact and streams its layers, in order, to the layer func. The content of the
+// layers is verified against their digest.
+func (c *Client) Pull(ctx context.Context, ref Reference, layerFunc func(layer ocispec.Descriptor, content io.Reader) error) (Artifact, error) {
+	manifest, manifestDigest, err := c.fetchManifest(ctx, ref, ref.manifestReference())
+	if err != nil {
+		return Artifact{}, err
+	}
+	artifact := Artifact{Reference: ref, Digest: manifestDigest.String(), Layers: manifest.Layers}
+	for _, layer := range manifest.Layers {
+		if err := c.pullLayer(ctx, ref, layer, layerFunc); err != nil {
+			return Artifact{}, err
+		}
+	}
+	return artifact, nil
+}
+
+func (c *Client) fetchManifest(ctx context.Context, ref Reference, reference string) (ocispec.Manifest, digest.Digest, error) {
+	resp, err := c.get(ctx, ref, "manifests/"+reference, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return ocispec.Manifest{}, "", err
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return ocispec.Manifest{}, "", err
+	}
+	if len(raw) > maxManifestSize {
+		return ocispec.Manifest{}, "", fmt.Errorf("manifest %v of %v exceeds the maximum size of %v bytes", reference, ref, maxManifestSize)
+	}
+	// The digest is computed from the content, the one announced by the registry only being checked against it.
+	manifestDigest := digest.FromBytes(raw)
+	if header := resp.Header.Get(contentDigestHeader); len(header) > 0 && !matchesDigest(raw, digest.Digest(header)) {
+		return ocispec.Manifest{}, "", fmt.Errorf("manifest %v of %v has digest %v, not %v as announced by the registry",
+			reference, ref, manifestDigest, header)
+	}
+	if expected, err := digest.Parse(reference); err == nil && !matchesDigest(raw, expected) {
+		return ocispec.Manifest{}, "", fmt.Errorf("manifest %v of %v has digest %v", reference, ref, manifestDigest)
+	}
+
+	var manifest struct {
+		ocispec.Manifest
+		MediaType string               `json:"mediaType"`
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return ocispec.Manifest{}, "", fmt.Errorf("failed to parse manifest of %v: %w", ref, err)
+	}
+	mediaType := manifest.MediaType
+	if contentType := resp.Header.Get("Content-Type"); len(contentType) > 0 {
+		mediaType = contentType
+	}
+	if mediaType == ocispec.MediaTypeImageIndex || mediaType == mediaTypeDockerManifestList || len(manifest.Manifests) > 0 {
+		// A package isn't platform specific, so the first manifest of an index is used.
+		if len(manifest.Manifests) == 0 {
+			return ocispec.Manifest{}, "", fmt.Errorf("index of %v has no manifests", ref)
+		}
+		m, _, err := c.fetchManifest(ctx, ref, manifest.Manifests[0].Digest.String())
+		return m, manifestDigest, err
+	}
+	return manifest.Manifest, manifestDigest, nil
+}
+
+// matchesDigest returns whether the content has the digest, computed with the algorithm of the digest
+func matchesDigest(content []byte, d digest.Digest) bool {
+	return d.Validate() == nil && d.Algorithm().FromBytes(content) == d
+}
+
+func (c *Client) pullLayer(ctx context.Context, ref Reference, layer ocispec.Descriptor,
+	layerFunc func(layer ocispec.Descriptor, content io.Reader) error) error {
+	if err := layer.Digest.Validate(); err != nil {
+		return fmt.Errorf("invalid digest of layer of %v: %w", ref, err)
+	}
+	resp, err := c.get(ctx, ref, "blobs/"+layer.Digest.String(), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	verifier := layer.Digest.Verifier()
+	if err := layerFunc(layer, io.TeeReader(resp.Body, verifier)); err != nil {
+		return err
+	}
+	// The layer func may not read the padding at the end of a tar stream.
+	if _, err := io.Copy(verifier, resp.Body); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("layer %v of %v doesn't match its digest", layer.Digest, ref)
+	}
+	return nil
+}
+
+// get requests the registry API, authenticating once the registry asks for it
+func (c *Client) get(ctx context.Context, ref Reference, path, accept string) (*http.Response, error) {
+	u := fmt.Sprintf("%v://%v/v2/%v/%v", c.scheme(ref), ref.apiRegistry(), ref.Repository, path)
+	do := func(authorization string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", accept)
+		}
+		if len(authorization) > 0 {
+			req.Header.Set("Authorization", authorization)
+		}
+		return c.HTTPClient.Do(req)
+	}
+
+	scope := fmt.Sprintf("repository:%v:pull", ref.Repository)
+	key := ref.Registry + " " + scope
+	resp, err := do(c.authorizations[key])
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		authorization, err := c.authorize(ctx, ref, challenge, scope)
+		if err != nil {
+			return nil, err
+		}
+		if c.authorizations == nil {
+			c.authorizations = map[string]string{}
+		}
+		c.authorizations[key] = authorization
+		if resp, err = do(authorization); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to fetch %v of %v: %v %v", path, ref, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// authorize answers the Basic or Bearer challenge of the registry with the credentials of the registry
+func (c *Client) authorize(ctx context.Context, ref Reference, challenge, scope string) (string, error) {
+	var credentials *Credentials
+	if c.Credentials != nil {
+		var err error
+		if credentials, err = c.Credentials(ref.Registry); err != nil {
+			return "", err
+		}
+	}
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil {
+			return "", fmt.Errorf("registry %v requires credentials. Please run docker login %v", ref.Registry, ref.Registry)
+		}
+		req := http.Request{Header: http.Header{}}
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+		token, err := c.fetchToken(ctx, ref, params, scope, credentials)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+	return "", fmt.Errorf("unsupported authentication challenge %q of registry %v", challenge, ref.Registry)
+}
+
+// fetchToken gets a bearer token from the token server of the registry, with the identity token of the credentials if
+// any, else their username and password, else anonymously.
+func (c *Client) fetchToken(ctx context.Context, ref Reference, params map[string]string, scope string,
+	credentials *Credentials) (string, error) {
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("bearer challenge of registry %v has no realm", ref.Registry)
+	}
+	if s, ok := params["scope"]; ok {
+		scope = s
+	}
+	var req *http.Request
+	var err error
+	if credentials != nil && len(credentials.IdentityToken) > 0 {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {credentials.IdentityToken},
+			"service":       {params["service"]},
+			"scope":         {scope},
+			"client_id":     {tokenClientID},
+		}
+		if req, err = http.NewRequestWithContext(ctx, http.MethodPost, realm, strings.NewReader(form.Encode())); err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		query := url.Values{"scope": {scope}}
+		if service, ok := params["service"]; ok {
+			query.Set("service", service)
+		}
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil); err != nil {
+			return "", err
+		}
+		if credentials != nil {
+			req.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to authenticate to registry %v: %v", ref.Registry, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse token of registry %v: %w", ref.Registry, err)
+	}
+	if len(token.Token) > 0 {
+		return token.Token, nil
+	}
+	if len(token.AccessToken) > 0 {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry %v returned no token", ref.Registry)
+}
+
+// scheme returns http for the registries of the local host, which are served without TLS like docker allows it
+func (c *Client) scheme(ref Reference) string {
+	host := ref.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return "https"
+}
+
+// parseChallenge parses a WWW-Authenticate header like Bearer realm="https://auth.docker.io/token",service="registry"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	scheme, rest := challenge, ""
+	if i := strings.Index(challenge, " "); i >= 0 {
+		scheme, rest = challenge[:i], challenge[i+1:]
+	}
+	for len(rest) > 0 {
+		rest = strings.TrimLeft(rest, " ,")
+		i := strings.Index(rest, "=")
+		if i < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:i]))
+		rest = rest[i+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if end := strings.Index(rest, ","); end >= 0 {
+			value, rest = rest[:end], rest[end+1:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+	}
+	return scheme, params
+}
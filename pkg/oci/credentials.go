@@ -0,0 +1,137 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+)
+
+const (
+	dockerConfigEnvVar  = "DOCKER_CONFIG"
+	dockerHubConfigKey  = "https://index.docker.io/v1/"
+	credentialHelperCmd = "docker-credential-"
+)
+
+// Credentials authenticate to a registry, with either a username and password or an identity token
+type Credentials struct {
+	Username      string
+	Password      string
+	IdentityToken string
+}
+
+// dockerConfig is the part of the Docker config holding the credentials of the registries
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		Username      string `json:"username"`
+		Password      string `json:"password"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// execCredentialHelper runs a Docker credential helper, replaced by the tests
+var execCredentialHelper = func(helper, registry string) ([]byte, error) {
+	cmd := exec.Command(credentialHelperCmd+helper, "get") // #nosec G204
+	cmd.Stdin = strings.NewReader(registry)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper %v%v failed: %v: %w", credentialHelperCmd, helper,
+			strings.TrimSpace(string(out)+" "+stderr.String()), err)
+	}
+	return out, nil
+}
+
+// DockerConfigFile returns the Docker config, in the dir of the DOCKER_CONFIG env var if set, else in ~/.docker
+func DockerConfigFile() string {
+	if dir := os.Getenv(dockerConfigEnvVar); len(dir) > 0 {
+		return f.FilePathJoin(dir, "config.json")
+	}
+	return f.FilePathJoin(f.UserHomeDir(), ".docker", "config.json")
+}
+
+// DockerCredentials returns the credentials of the registry from the Docker config, which keeps them either itself or in
+// a credential helper. No credentials are returned for a registry missing from the config, for anonymous pulls.
+func DockerCredentials(configFile, registry string) (*Credentials, error) {
+	raw, err := os.ReadFile(configFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse Docker config %v: %w", configFile, err)
+	}
+	keys := []string{registry, "https://" + registry, "http://" + registry}
+	if registry == dockerHubRegistry {
+		keys = append(keys, dockerHubConfigKey)
+	}
+
+	if helper, ok := cfg.CredHelpers[registry]; ok {
+		return helperCredentials(helper, registry)
+	}
+	for _, key := range keys {
+		auth, ok := cfg.Auths[key]
+		if !ok {
+			continue
+		}
+		credentials := &Credentials{Username: auth.Username, Password: auth.Password, IdentityToken: auth.IdentityToken}
+		if len(auth.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of %v in Docker config %v: %w", key, configFile, err)
+			}
+			userPassword := strings.SplitN(string(decoded), ":", 2)
+			if len(userPassword) != 2 {
+				return nil, fmt.Errorf("invalid auth of %v in Docker config %v", key, configFile)
+			}
+			credentials.Username, credentials.Password = userPassword[0], userPassword[1]
+		}
+		if len(credentials.Username) > 0 || len(credentials.IdentityToken) > 0 {
+			return credentials, nil
+		}
+	}
+	if len(cfg.CredsStore) > 0 {
+		key := registry
+		if registry == dockerHubRegistry {
+			key = dockerHubConfigKey
+		}
+		return helperCredentials(cfg.CredsStore, key)
+	}
+	return nil, nil
+}
+
+func helperCredentials(helper, registry string) (*Credentials, error) {
+	out, err := execCredentialHelper(helper, registry)
+	if err != nil {
+		// The helper fails for the registries it holds no credentials of.
+		if strings.Contains(err.Error(), "credentials not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var helperCredentials struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(out, &helperCredentials); err != nil {
+		return nil, fmt.Errorf("failed to parse the output of credential helper %v%v: %w", credentialHelperCmd, helper, err)
+	}
+	// A helper returns an identity token with the <token> username.
+	if helperCredentials.Username == "<token>" {
+		return &Credentials{IdentityToken: helperCredentials.Secret}, nil
+	}
+	return &Credentials{Username: helperCredentials.Username, Password: helperCredentials.Secret}, nil
+}
@@ -0,0 +1,261 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// registry is a stand-in of an OCI registry serving one artifact, behind a bearer token server when a password is set
+type registry struct {
+	server   *httptest.Server
+	manifest []byte
+	blobs    map[digest.Digest][]byte
+	password string
+	// digestHeader is the Docker-Content-Digest header of the manifest, if any
+	digestHeader string
+}
+
+func newRegistry(t *testing.T, password string, layers ...[]byte) *registry {
+	r := &registry{blobs: map[digest.Digest][]byte{}, password: password}
+	manifest := ocispec.Manifest{Config: ocispec.Descriptor{MediaType: ocispec.MediaTypeImageConfig}}
+	manifest.SchemaVersion = 2
+	for i, layer := range layers {
+		d := digest.FromBytes(layer)
+		r.blobs[d] = layer
+		manifest.Layers = append(manifest.Layers, ocispec.Descriptor{
+			MediaType:   ocispec.MediaTypeImageLayer,
+			Digest:      d,
+			Size:        int64(len(layer)),
+			Annotations: map[string]string{ocispec.AnnotationTitle: fmt.Sprintf("layer%v.tar", i)},
+		})
+	}
+	var err error
+	r.manifest, err = json.Marshal(manifest)
+	assert.Nil(t, err)
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *registry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func (r *registry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "flyte" || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token": "registry-token"}`))
+		return
+	}
+	if len(r.password) > 0 && req.Header.Get("Authorization") != "Bearer registry-token" {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer realm="%v/token",service="registry",scope="repository:flyte/package:pull"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case req.URL.Path == "/v2/flyte/package/manifests/v1" ||
+		req.URL.Path == "/v2/flyte/package/manifests/"+digest.FromBytes(r.manifest).String():
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+		if len(r.digestHeader) > 0 {
+			w.Header().Set(contentDigestHeader, r.digestHeader)
+		}
+		_, _ = w.Write(r.manifest)
+	case strings.HasPrefix(req.URL.Path, "/v2/flyte/package/blobs/"):
+		blob, ok := r.blobs[digest.Digest(strings.TrimPrefix(req.URL.Path, "/v2/flyte/package/blobs/"))]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(blob)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func pullAll(client *Client, ref Reference) (Artifact, []string, error) {
+	var contents []string
+	artifact, err := client.Pull(context.Background(), ref, func(layer ocispec.Descriptor, content io.Reader) error {
+		raw, err := ioutil.ReadAll(content)
+		contents = append(contents, string(raw))
+		return err
+	})
+	return artifact, contents, err
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("oci://ghcr.io/flyteorg/packages/flytesnacks:v1")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: "ghcr.io", Repository: "flyteorg/packages/flytesnacks", Tag: "v1"}, ref)
+	assert.Equal(t, "ghcr.io/flyteorg/packages/flytesnacks:v1", ref.String())
+
+	ref, err = ParseReference("oci://localhost:5000/flytesnacks")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: "localhost:5000", Repository: "flytesnacks", Tag: "latest"}, ref)
+
+	d := digest.FromString("manifest").String()
+	ref, err = ParseReference("oci://docker.io/flytesnacks@" + d)
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: "docker.io", Repository: "library/flytesnacks", Digest: d}, ref)
+	assert.Equal(t, "registry-1.docker.io", ref.apiRegistry())
+	assert.Equal(t, d, ref.manifestReference())
+
+	for _, invalid := range []string{"ghcr.io/flytesnacks:v1", "oci://flytesnacks:v1", "oci://ghcr.io/", "oci://ghcr.io/flytesnacks@sha256:x"} {
+		_, err = ParseReference(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestPull(t *testing.T) {
+	t.Run("Anonymous", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"), []byte("layer1"))
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v1", r.host()))
+		assert.Nil(t, err)
+		artifact, contents, err := pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"layer0", "layer1"}, contents)
+		assert.Equal(t, digest.FromBytes(r.manifest).String(), artifact.Digest)
+		assert.Equal(t, 2, len(artifact.Layers))
+	})
+	t.Run("By digest", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"))
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package@%v", r.host(), digest.FromBytes(r.manifest)))
+		assert.Nil(t, err)
+		_, contents, err := pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"layer0"}, contents)
+	})
+	t.Run("Bearer token", func(t *testing.T) {
+		r := newRegistry(t, "secret", []byte("layer0"))
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v1", r.host()))
+		assert.Nil(t, err)
+		client := &Client{HTTPClient: http.DefaultClient, Credentials: func(registry string) (*Credentials, error) {
+			assert.Equal(t, r.host(), registry)
+			return &Credentials{Username: "flyte", Password: "secret"}, nil
+		}}
+		_, contents, err := pullAll(client, ref)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"layer0"}, contents)
+
+		_, _, err = pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.NotNil(t, err)
+	})
+	t.Run("Corrupted layer", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"))
+		for d := range r.blobs {
+			r.blobs[d] = []byte("corrupted")
+		}
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v1", r.host()))
+		assert.Nil(t, err)
+		_, _, err = pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "doesn't match its digest")
+	})
+	t.Run("Digest header", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"))
+		r.digestHeader = digest.FromBytes(r.manifest).String()
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v1", r.host()))
+		assert.Nil(t, err)
+		artifact, _, err := pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.Nil(t, err)
+		assert.Equal(t, r.digestHeader, artifact.Digest)
+
+		r.digestHeader = digest.FromString("other").String()
+		_, _, err = pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "as announced by the registry")
+	})
+	t.Run("Oversized manifest", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"))
+		r.manifest = append(r.manifest, bytes.Repeat([]byte(" "), maxManifestSize)...)
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v1", r.host()))
+		assert.Nil(t, err)
+		_, _, err = pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "exceeds the maximum size")
+	})
+	t.Run("Missing tag", func(t *testing.T) {
+		r := newRegistry(t, "", []byte("layer0"))
+		ref, err := ParseReference(fmt.Sprintf("oci://%v/flyte/package:v2", r.host()))
+		assert.Nil(t, err)
+		_, _, err = pullAll(&Client{HTTPClient: http.DefaultClient}, ref)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDockerCredentials(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	auth := base64.StdEncoding.EncodeToString([]byte("flyte:secret"))
+	assert.Nil(t, os.WriteFile(configFile, []byte(`{
+  "auths": {
+    "ghcr.io": {"auth": "`+auth+`"},
+    "https://index.docker.io/v1/": {"auth": "`+auth+`"}
+  },
+  "credHelpers": {"123456789.dkr.ecr.us-east-1.amazonaws.com": "ecr-login"},
+  "credsStore": "desktop"
+}`), 0600))
+	defer func(helper func(helper, registry string) ([]byte, error)) { execCredentialHelper = helper }(execCredentialHelper)
+	execCredentialHelper = func(helper, registry string) ([]byte, error) {
+		switch helper {
+		case "ecr-login":
+			return []byte(`{"Username": "AWS", "Secret": "ecr-secret"}`), nil
+		case "desktop":
+			if registry == "quay.io" {
+				return []byte(`{"Username": "<token>", "Secret": "identity"}`), nil
+			}
+		}
+		return nil, errors.New("credentials not found in native keychain")
+	}
+
+	credentials, err := DockerCredentials(configFile, "ghcr.io")
+	assert.Nil(t, err)
+	assert.Equal(t, &Credentials{Username: "flyte", Password: "secret"}, credentials)
+	credentials, err = DockerCredentials(configFile, "docker.io")
+	assert.Nil(t, err)
+	assert.Equal(t, &Credentials{Username: "flyte", Password: "secret"}, credentials)
+	credentials, err = DockerCredentials(configFile, "123456789.dkr.ecr.us-east-1.amazonaws.com")
+	assert.Nil(t, err)
+	assert.Equal(t, &Credentials{Username: "AWS", Password: "ecr-secret"}, credentials)
+	credentials, err = DockerCredentials(configFile, "quay.io")
+	assert.Nil(t, err)
+	assert.Equal(t, &Credentials{IdentityToken: "identity"}, credentials)
+	credentials, err = DockerCredentials(configFile, "localhost:5000")
+	assert.Nil(t, err)
+	assert.Nil(t, credentials)
+	credentials, err = DockerCredentials(filepath.Join(t.TempDir(), "config.json"), "ghcr.io")
+	assert.Nil(t, err)
+	assert.Nil(t, credentials)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/ubuntu:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/ubuntu:pull,push",
+	}, params)
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
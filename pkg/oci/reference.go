@@ -0,0 +1,92 @@
+package oci
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Scheme prefixes the references of the artifacts of OCI registries
+const Scheme = "oci://"
+
+const (
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+	defaultTag           = "latest"
+)
+
+// Reference identifies an artifact of an OCI registry, by tag or digest
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// IsReference tells whether the ref is an oci:// reference
+func IsReference(ref string) bool {
+	return strings.HasPrefix(ref, Scheme)
+}
+
+// ParseReference parses an oci://registry/repository[:tag][@digest] reference. The tag defaults to latest when neither
+// a tag nor a digest is given.
+func ParseReference(ref string) (Reference, error) {
+	if !IsReference(ref) {
+		return Reference{}, fmt.Errorf("reference %v doesn't start with %v", ref, Scheme)
+	}
+	remainder := strings.TrimPrefix(ref, Scheme)
+	var r Reference
+	if i := strings.Index(remainder, "@"); i >= 0 {
+		r.Digest = remainder[i+1:]
+		remainder = remainder[:i]
+		if _, err := digest.Parse(r.Digest); err != nil {
+			return Reference{}, fmt.Errorf("invalid digest in reference %v: %w", ref, err)
+		}
+	}
+	// The tag follows the last colon after the last slash, a colon before it being the port of the registry.
+	if i := strings.LastIndex(remainder, ":"); i > strings.LastIndex(remainder, "/") {
+		r.Tag = remainder[i+1:]
+		remainder = remainder[:i]
+	}
+	i := strings.Index(remainder, "/")
+	if i <= 0 || i == len(remainder)-1 {
+		return Reference{}, fmt.Errorf("reference %v must be of the form %vregistry/repository:tag", ref, Scheme)
+	}
+	r.Registry, r.Repository = remainder[:i], remainder[i+1:]
+	if r.Registry == dockerHubRegistry && !strings.Contains(r.Repository, "/") {
+		r.Repository = "library/" + r.Repository
+	}
+	if len(r.Tag) == 0 && len(r.Digest) == 0 {
+		r.Tag = defaultTag
+	}
+	return r, nil
+}
+
+// String returns the reference without its scheme
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if len(r.Tag) > 0 {
+		s += ":" + r.Tag
+	}
+	if len(r.Digest) > 0 {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// manifestReference returns the digest of the reference if any, else its tag
+func (r Reference) manifestReference() string {
+	if len(r.Digest) > 0 {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// apiRegistry returns the host serving the registry API, which differs from the registry for Docker Hub
+func (r Reference) apiRegistry() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.Registry
+}
@@ -0,0 +1,286 @@
+package pkce
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// TokenCacheKeyEnvVar is the env var holding the key of the token cache file
+	TokenCacheKeyEnvVar = "FLYTECTL_TOKEN_CACHE_KEY"
+	tokenCacheFileMode  = 0600
+	tokenCacheDirMode   = 0700
+	tokenCacheKeySalt   = "flytectl-token-cache"
+	tokenCacheKeySize   = 32
+)
+
+var (
+	// ErrTokenNotFound is returned when no token of the service user is cached
+	ErrTokenNotFound = errors.New("no token found in the cache")
+
+	// DefaultTokenCacheFile is the token cache file used when none is configured
+	DefaultTokenCacheFile = f.FilePathJoin(f.UserHomeDir(), ".flyte", "tokens.enc")
+)
+
+// TokenCacheFileProvider saves and retrieves tokens from a file encrypted with AES-GCM, for the hosts without a keyring
+// like containers, CI runners and SSH sessions. The file holds the tokens of all the service users and is only
+// readable by its owner. The key is taken from the FLYTECTL_TOKEN_CACHE_KEY env var, else from a key file next to the
+// cache file, holding a random key generated on first use and only readable by its owner too. The key file only keeps
+// the tokens from the other users as long as the file permissions do, e.g. not from the readers of a backup of the
+// home dir, against which only the env var protects.
+type TokenCacheFileProvider struct {
+	Path        string
+	ServiceUser string
+	// Key overrides the key of the file when set
+	Key []byte
+}
+
+func (t TokenCacheFileProvider) SaveToken(token *oauth2.Token) error {
+	if token.AccessToken == "" {
+		return fmt.Errorf("cannot save empty token with expiration %v", token.Expiry)
+	}
+	unlock, err := t.lock()
+	if err != nil {
+		return fmt.Errorf("unable to save token. Error: %w", err)
+	}
+	defer unlock()
+	tokens, err := t.readTokens()
+	if err != nil {
+		return err
+	}
+	tokens[t.ServiceUser] = token
+	if err := t.writeTokens(tokens); err != nil {
+		return fmt.Errorf("unable to save token. Error: %w", err)
+	}
+	return nil
+}
+
+func (t TokenCacheFileProvider) GetToken() (*oauth2.Token, error) {
+	tokens, err := t.readTokens()
+	if err != nil {
+		return nil, err
+	}
+	token, ok := tokens[t.ServiceUser]
+	if !ok || token == nil {
+		return nil, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// DeleteToken removes the token from the file, and the file once it holds no tokens
+func (t TokenCacheFileProvider) DeleteToken() error {
+	unlock, err := t.lock()
+	if err != nil {
+		return fmt.Errorf("unable to delete token. Error: %w", err)
+	}
+	defer unlock()
+	tokens, err := t.readTokens()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[t.ServiceUser]; !ok {
+		return ErrTokenNotFound
+	}
+	delete(tokens, t.ServiceUser)
+	if len(tokens) == 0 {
+		err = os.Remove(t.Path)
+	} else {
+		err = t.writeTokens(tokens)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to delete token. Error: %w", err)
+	}
+	return nil
+}
+
+func (t TokenCacheFileProvider) readTokens() (map[string]*oauth2.Token, error) {
+	tokens := map[string]*oauth2.Token{}
+	info, err := os.Stat(t.Path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwnerOnly("token cache file", t.Path, info); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(t.Path)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := t.cipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("token cache file %v is corrupted", t.Path)
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt token cache file %v. The key may have changed, in which case delete the file and log in again. Error: %w",
+			t.Path, err)
+	}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("unmarshalling error for saved tokens. Error: %w", err)
+	}
+	return tokens, nil
+}
+
+// writeTokens replaces the file atomically, for a concurrent command not to read a partially written file. It's only
+// called with the lock held.
+func (t TokenCacheFileProvider) writeTokens(tokens map[string]*oauth2.Token) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tokens to save in cache due to %w", err)
+	}
+	aead, err := t.cipher(true)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	data := aead.Seal(nonce, nonce, plaintext, nil)
+
+	dir := filepath.Dir(t.Path)
+	if err := os.MkdirAll(dir, tokenCacheDirMode); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, filepath.Base(t.Path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), tokenCacheFileMode); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), t.Path)
+}
+
+// lock takes the lock of the file, for the concurrent commands reading, modifying and writing the file not to lose each
+// other's tokens. The returned func releases it.
+func (t TokenCacheFileProvider) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(t.Path), tokenCacheDirMode); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(t.Path+".lock", os.O_CREATE|os.O_RDWR, tokenCacheFileMode)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to lock %v: %w", file.Name(), err)
+	}
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// cipher returns the cipher of the file. The key file is only created when create is set, with the lock held.
+func (t TokenCacheFileProvider) cipher(create bool) (cipher.AEAD, error) {
+	key := t.Key
+	if len(key) == 0 {
+		var err error
+		if key, err = t.TokenCacheKey(create); err != nil {
+			return nil, err
+		}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyPath returns the path of the file holding the key of the token cache file
+func (t TokenCacheFileProvider) KeyPath() string {
+	return t.Path + ".key"
+}
+
+// TokenCacheKey returns the AES-256 key of the token cache file, derived from the FLYTECTL_TOKEN_CACHE_KEY env var if
+// set, else read from the key file. A random key is saved to the key file if it doesn't exist and create is set.
+func (t TokenCacheFileProvider) TokenCacheKey(create bool) ([]byte, error) {
+	if secret := os.Getenv(TokenCacheKeyEnvVar); len(secret) > 0 {
+		return deriveKey(secret), nil
+	}
+	info, err := os.Stat(t.KeyPath())
+	if os.IsNotExist(err) && create {
+		return t.createKey()
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("key file %v of the token cache file is missing. Delete %v and log in again, or set %v",
+			t.KeyPath(), t.Path, TokenCacheKeyEnvVar)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwnerOnly("token cache key file", t.KeyPath(), info); err != nil {
+		return nil, err
+	}
+	key, err := os.ReadFile(t.KeyPath())
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != tokenCacheKeySize {
+		return nil, fmt.Errorf("token cache key file %v is corrupted", t.KeyPath())
+	}
+	return key, nil
+}
+
+func (t TokenCacheFileProvider) createKey() ([]byte, error) {
+	key := make([]byte, tokenCacheKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(t.KeyPath(), os.O_CREATE|os.O_EXCL|os.O_WRONLY, tokenCacheFileMode)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(key); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return key, nil
+}
+
+// checkOwnerOnly fails if the file is accessible by other users than its owner. Windows files have no such permissions.
+func checkOwnerOnly(kind, path string, info os.FileInfo) error {
+	if runtime.GOOS != "windows" && info.Mode().Perm()&^tokenCacheFileMode != 0 {
+		return fmt.Errorf("%v %v is accessible by other users. Its permissions must be %o", kind, path, tokenCacheFileMode)
+	}
+	return nil
+}
+
+func deriveKey(secrets ...string) []byte {
+	key := sha256.Sum256([]byte(strings.Join(append([]string{tokenCacheKeySalt}, secrets...), "\x00")))
+	return key[:]
+}
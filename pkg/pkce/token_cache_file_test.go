@@ -0,0 +1,137 @@
+package pkce
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestFileSaveAndGetToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".flyte", "tokens.enc")
+	key := deriveKey("test")
+	tokenCacheProvider := TokenCacheFileProvider{Path: path, ServiceUser: "testServiceUser", Key: key}
+	plan, err := ioutil.ReadFile("testdata/token.json")
+	assert.NoError(t, err)
+	var tokenData oauth2.Token
+	assert.NoError(t, json.Unmarshal(plan, &tokenData))
+
+	t.Run("No token", func(t *testing.T) {
+		_, err := tokenCacheProvider.GetToken()
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+		assert.ErrorIs(t, tokenCacheProvider.DeleteToken(), ErrTokenNotFound)
+	})
+	t.Run("Valid Save/Get Token", func(t *testing.T) {
+		assert.NoError(t, tokenCacheProvider.SaveToken(&tokenData))
+		savedToken, err := tokenCacheProvider.GetToken()
+		assert.NoError(t, err)
+		assert.Equal(t, tokenData.AccessToken, savedToken.AccessToken)
+		assert.Equal(t, tokenData.RefreshToken, savedToken.RefreshToken)
+		assert.True(t, tokenData.Expiry.Equal(savedToken.Expiry))
+
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), tokenData.AccessToken[:20])
+	})
+	t.Run("Tokens of other service users", func(t *testing.T) {
+		other := TokenCacheFileProvider{Path: path, ServiceUser: "otherServiceUser", Key: key}
+		_, err := other.GetToken()
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+		assert.NoError(t, other.SaveToken(&oauth2.Token{AccessToken: "other"}))
+		assert.NoError(t, other.DeleteToken())
+		savedToken, err := tokenCacheProvider.GetToken()
+		assert.NoError(t, err)
+		assert.Equal(t, tokenData.AccessToken, savedToken.AccessToken)
+	})
+	t.Run("Wrong key", func(t *testing.T) {
+		wrongKey := TokenCacheFileProvider{Path: path, ServiceUser: "testServiceUser", Key: deriveKey("wrong")}
+		_, err := wrongKey.GetToken()
+		assert.NotNil(t, err)
+	})
+	t.Run("Permissive file", func(t *testing.T) {
+		assert.NoError(t, os.Chmod(path, 0644))
+		_, err := tokenCacheProvider.GetToken()
+		assert.EqualError(t, err, "token cache file "+path+" is accessible by other users. Its permissions must be 600")
+		assert.NoError(t, os.Chmod(path, 0600))
+	})
+	t.Run("Empty access token Save", func(t *testing.T) {
+		assert.NotNil(t, tokenCacheProvider.SaveToken(&oauth2.Token{}))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, tokenCacheProvider.DeleteToken())
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestTokenCacheKey(t *testing.T) {
+	t.Run("Env var", func(t *testing.T) {
+		t.Setenv(TokenCacheKeyEnvVar, "secret")
+		provider := TokenCacheFileProvider{Path: filepath.Join(t.TempDir(), "tokens.enc")}
+		key, err := provider.TokenCacheKey(true)
+		assert.NoError(t, err)
+		assert.Equal(t, deriveKey("secret"), key)
+		assert.Len(t, key, 32)
+		_, err = os.Stat(provider.KeyPath())
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Key file", func(t *testing.T) {
+		t.Setenv(TokenCacheKeyEnvVar, "")
+		path := filepath.Join(t.TempDir(), "tokens.enc")
+		provider := TokenCacheFileProvider{Path: path, ServiceUser: "testServiceUser"}
+		_, err := provider.GetToken()
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+		_, err = provider.TokenCacheKey(false)
+		assert.NotNil(t, err)
+
+		assert.NoError(t, provider.SaveToken(&oauth2.Token{AccessToken: "saved"}))
+		info, err := os.Stat(provider.KeyPath())
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		key, err := provider.TokenCacheKey(false)
+		assert.NoError(t, err)
+		assert.Len(t, key, 32)
+
+		// Another command reads the tokens with the key of the file.
+		other := TokenCacheFileProvider{Path: path, ServiceUser: "testServiceUser"}
+		token, err := other.GetToken()
+		assert.NoError(t, err)
+		assert.Equal(t, "saved", token.AccessToken)
+		again, err := other.TokenCacheKey(true)
+		assert.NoError(t, err)
+		assert.Equal(t, key, again)
+
+		assert.NoError(t, os.Chmod(provider.KeyPath(), 0644))
+		_, err = provider.GetToken()
+		assert.EqualError(t, err, "token cache key file "+provider.KeyPath()+" is accessible by other users. Its permissions must be 600")
+	})
+}
+
+func TestFileConcurrentSaveToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			provider := TokenCacheFileProvider{Path: path, ServiceUser: fmt.Sprintf("user%v", i), Key: deriveKey("test")}
+			assert.NoError(t, provider.SaveToken(&oauth2.Token{AccessToken: fmt.Sprintf("token%v", i)}))
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		provider := TokenCacheFileProvider{Path: path, ServiceUser: fmt.Sprintf("user%v", i), Key: deriveKey("test")}
+		token, err := provider.GetToken()
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("token%v", i), token.AccessToken)
+	}
+}
@@ -2,6 +2,7 @@ package pkce
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/zalando/go-keyring"
@@ -19,6 +20,15 @@ const (
 	KeyRingServiceName = "flytectl"
 )
 
+// KeyRingServiceUserFor returns the keyring user holding the tokens of the admin endpoint. The tokens of a config context
+// are kept apart from the ones of the other contexts, which may use the same endpoint with other credentials.
+func KeyRingServiceUserFor(endpoint, context string) string {
+	if len(context) == 0 {
+		return fmt.Sprintf("%s:%s", endpoint, KeyRingServiceUser)
+	}
+	return fmt.Sprintf("%s:%s:%s", context, endpoint, KeyRingServiceUser)
+}
+
 func (t TokenCacheKeyringProvider) SaveToken(token *oauth2.Token) error {
 	var tokenBytes []byte
 	if token.AccessToken == "" {
@@ -41,12 +51,12 @@ func (t TokenCacheKeyringProvider) SaveToken(token *oauth2.Token) error {
 func (t TokenCacheKeyringProvider) GetToken() (*oauth2.Token, error) {
 	// get saved token
 	tokenJSON, err := keyring.Get(t.ServiceName, t.ServiceUser)
-	if len(tokenJSON) == 0 {
-		return nil, fmt.Errorf("no token found in the cache")
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return nil, err
 	}
 
-	if err != nil {
-		return nil, err
+	if len(tokenJSON) == 0 {
+		return nil, ErrTokenNotFound
 	}
 
 	token := oauth2.Token{}
@@ -56,3 +66,11 @@ func (t TokenCacheKeyringProvider) GetToken() (*oauth2.Token, error) {
 
 	return &token, nil
 }
+
+// DeleteToken removes the token from the keyring
+func (t TokenCacheKeyringProvider) DeleteToken() error {
+	if err := keyring.Delete(t.ServiceName, t.ServiceUser); err != nil {
+		return fmt.Errorf("unable to delete token. Error: %w", err)
+	}
+	return nil
+}
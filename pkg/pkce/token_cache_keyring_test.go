@@ -62,3 +62,8 @@ func TestSaveAndGetToken(t *testing.T) {
 		assert.Nil(t, savedToken)
 	})
 }
+
+func TestKeyRingServiceUserFor(t *testing.T) {
+	assert.Equal(t, "dns:///flyte.myexample.com:flytectl-user", KeyRingServiceUserFor("dns:///flyte.myexample.com", ""))
+	assert.Equal(t, "production:dns:///flyte.myexample.com:flytectl-user", KeyRingServiceUserFor("dns:///flyte.myexample.com", "production"))
+}
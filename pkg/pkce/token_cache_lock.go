@@ -0,0 +1,17 @@
+//go:build !windows
+
+package pkce
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until the exclusive lock of the file is taken
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -89,7 +89,7 @@ func extractRow(data interface{}, columns []Column) []string {
 		if err != nil || out == nil {
 			out = ""
 		}
-		s := fmt.Sprintf("%s", out)
+		s := fmt.Sprintf("%v", out)
 		if c.TruncateTo != nil {
 			t := *c.TruncateTo
 			if len(s) > t {
@@ -307,3 +307,14 @@ func TestFormatParameterDescriptions(t *testing.T) {
 	FormatParameterDescriptions(paramMap)
 	assert.Equal(t, "bar\nfoo\nvar1: foo\nvar2: bar", paramMap[DefaultFormattedDescriptionsKey].Var.Description)
 }
+
+func TestExtractRow(t *testing.T) {
+	var row interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"line": 12, "valid": true, "key": "admin.endpoint"}`), &row))
+	assert.Equal(t, []string{"12", "true", "admin.endpoint", ""}, extractRow(row, []Column{
+		{Header: "Line", JSONPath: "$.line"},
+		{Header: "Valid", JSONPath: "$.valid"},
+		{Header: "Key", JSONPath: "$.key"},
+		{Header: "Missing", JSONPath: "$.missing"},
+	}))
+}
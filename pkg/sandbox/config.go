@@ -0,0 +1,208 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// registriesFile is read by k3s to configure the registry mirrors, the k3s directory of the sandbox directory being
+	// mounted to /etc/rancher/k3s.
+	registriesFile = "registries.yaml"
+	helmValuesFile = "flyte-values.yaml"
+	// helmValuesTarget is the auto deploying manifests directory of k3s which the Flyte chart is deployed from.
+	helmValuesTarget = "/var/lib/rancher/k3s/server/manifests/flyte-values.yaml"
+	flyteChartName   = "flyte"
+)
+
+// FileConfig is the declarative configuration of a sandbox, read from the file passed to --config. Flags take
+// precedence over the image, version and env of the file.
+type FileConfig struct {
+	Image   string   `json:"image"`
+	Version string   `json:"version"`
+	Env     []string `json:"env"`
+	// Resources limits the resources of the sandbox container.
+	Resources Resources `json:"resources"`
+	// HostPorts remaps the default host ports of the sandbox, e.g. 30081: 31081 publishes Flyteadmin on 31081.
+	HostPorts map[int]int `json:"hostPorts"`
+	// Ports publishes extra container ports, in the docker format [ip:]hostPort:containerPort.
+	Ports []string `json:"ports"`
+	// Volumes are mounted in the sandbox container in addition to the source directory.
+	Volumes []Volume `json:"volumes"`
+	// RegistryMirrors lists the mirror endpoints of every registry, e.g. docker.io.
+	RegistryMirrors map[string][]string `json:"registryMirrors"`
+	// HelmValues overrides the values of the Flyte chart deployed in the sandbox.
+	HelmValues map[string]interface{} `json:"helmValues"`
+}
+
+// Resources limits the CPU and memory of the sandbox container
+type Resources struct {
+	CPUs float64 `json:"cpus"`
+	// Memory accepts the docker and k8s units, e.g. 8g or 8Gi.
+	Memory string `json:"memory"`
+}
+
+// Volume is a bind mount of a host path in the sandbox container
+type Volume struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// LoadFileConfig reads the sandbox configuration file, an empty path returning an empty configuration
+func LoadFileConfig(path string) (*FileConfig, error) {
+	fileConfig := &FileConfig{}
+	if len(path) == 0 {
+		return fileConfig, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sandbox config %v: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, fileConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse sandbox config %v: %w", path, err)
+	}
+	// Relative volume sources are resolved from the directory of the file so that it can be shared along with them.
+	for i, v := range fileConfig.Volumes {
+		if len(v.Source) == 0 || len(v.Target) == 0 {
+			return nil, fmt.Errorf("volume %v of sandbox config %v requires a source and a target", i, path)
+		}
+		if !filepath.IsAbs(v.Source) {
+			fileConfig.Volumes[i].Source = filepath.Join(filepath.Dir(path), v.Source)
+		}
+	}
+	return fileConfig, nil
+}
+
+// ContainerResources returns the resource limits of the sandbox container
+func (c *FileConfig) ContainerResources() (container.Resources, error) {
+	var resources container.Resources
+	if c.Resources.CPUs < 0 {
+		return resources, fmt.Errorf("invalid cpus %v", c.Resources.CPUs)
+	}
+	resources.NanoCPUs = int64(c.Resources.CPUs * 1e9)
+	if len(c.Resources.Memory) > 0 {
+		memory, err := units.RAMInBytes(c.Resources.Memory)
+		if err != nil {
+			return resources, fmt.Errorf("invalid memory %v: %w", c.Resources.Memory, err)
+		}
+		resources.Memory = memory
+	}
+	return resources, nil
+}
+
+// PortBindings returns the sandbox ports with the host ports remapped and the extra ports added
+func (c *FileConfig) PortBindings(exposedPorts map[nat.Port]struct{}, portBindings map[nat.Port][]nat.PortBinding) (
+	map[nat.Port]struct{}, map[nat.Port][]nat.PortBinding, error) {
+	portBindings, err := docker.RemapPortBindings(portBindings, c.HostPorts)
+	if err != nil {
+		return nil, nil, err
+	}
+	extraExposedPorts, extraPortBindings, err := nat.ParsePortSpecs(c.Ports)
+	if err != nil {
+		return nil, nil, err
+	}
+	exposed := make(map[nat.Port]struct{}, len(exposedPorts)+len(extraExposedPorts))
+	for port := range exposedPorts {
+		exposed[port] = struct{}{}
+	}
+	for port := range extraExposedPorts {
+		exposed[port] = struct{}{}
+	}
+	for port, bindings := range extraPortBindings {
+		portBindings[port] = append(portBindings[port], bindings...)
+	}
+	return exposed, portBindings, nil
+}
+
+// Mounts writes the registry mirrors and the Helm values to the directory of the named sandbox and returns the extra
+// volumes of the sandbox container. Files left by a previous configuration are removed.
+func (c *FileConfig) Mounts(name string) ([]mount.Mount, error) {
+	var mounts []mount.Mount
+	for _, v := range c.Volumes {
+		source, err := filepath.Abs(v.Source)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+
+	registries := f.FilePathJoin(configutil.SandboxDir(name), "k3s", registriesFile)
+	if err := writeOrRemove(registries, len(c.RegistryMirrors) > 0, c.registriesConfig); err != nil {
+		return nil, err
+	}
+
+	helmValues := f.FilePathJoin(configutil.SandboxDir(name), helmValuesFile)
+	if err := writeOrRemove(helmValues, len(c.HelmValues) > 0, c.helmChartConfig); err != nil {
+		return nil, err
+	}
+	if len(c.HelmValues) > 0 {
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   helmValues,
+			Target:   helmValuesTarget,
+			ReadOnly: true,
+		})
+	}
+	return mounts, nil
+}
+
+// registriesConfig returns the k3s registries configuration of the mirrors
+func (c *FileConfig) registriesConfig() ([]byte, error) {
+	mirrors := map[string]interface{}{}
+	for registry, endpoints := range c.RegistryMirrors {
+		mirrors[registry] = map[string]interface{}{"endpoint": endpoints}
+	}
+	return yaml.Marshal(map[string]interface{}{"mirrors": mirrors})
+}
+
+// helmChartConfig returns the k3s HelmChartConfig overriding the values of the Flyte chart
+func (c *FileConfig) helmChartConfig() ([]byte, error) {
+	values, err := yaml.Marshal(c.HelmValues)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion": "helm.cattle.io/v1",
+		"kind":       "HelmChartConfig",
+		"metadata": map[string]interface{}{
+			"name":      flyteChartName,
+			"namespace": "kube-system",
+		},
+		"spec": map[string]interface{}{
+			"valuesContent": string(values),
+		},
+	})
+}
+
+func writeOrRemove(path string, write bool, content func() ([]byte, error)) error {
+	if !write {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := content()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,151 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSandboxConfig = `
+image: cr.flyte.org/flyteorg/flyte-sandbox:dind
+env:
+  - FOO=bar
+resources:
+  cpus: 2.5
+  memory: 8Gi
+hostPorts:
+  30081: 31081
+ports:
+  - "0.0.0.0:30100:30100"
+volumes:
+  - source: data
+    target: /data
+    readOnly: true
+registryMirrors:
+  docker.io:
+    - https://mirror.gcr.io
+helmValues:
+  flyteadmin:
+    replicaCount: 2
+`
+
+func writeSandboxConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "sandbox.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	t.Run("Empty path", func(t *testing.T) {
+		fileConfig, err := LoadFileConfig("")
+		assert.Nil(t, err)
+		assert.Equal(t, &FileConfig{}, fileConfig)
+	})
+	t.Run("Valid config", func(t *testing.T) {
+		path := writeSandboxConfig(t, testSandboxConfig)
+		fileConfig, err := LoadFileConfig(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "cr.flyte.org/flyteorg/flyte-sandbox:dind", fileConfig.Image)
+		assert.Equal(t, []string{"FOO=bar"}, fileConfig.Env)
+		assert.Equal(t, map[int]int{30081: 31081}, fileConfig.HostPorts)
+		assert.Equal(t, []Volume{{Source: filepath.Join(filepath.Dir(path), "data"), Target: "/data", ReadOnly: true}}, fileConfig.Volumes)
+		assert.Equal(t, map[string][]string{"docker.io": {"https://mirror.gcr.io"}}, fileConfig.RegistryMirrors)
+
+		resources, err := fileConfig.ContainerResources()
+		assert.Nil(t, err)
+		assert.Equal(t, container.Resources{NanoCPUs: 2500000000, Memory: 8 * 1024 * 1024 * 1024}, resources)
+	})
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadFileConfig("/non/existent/sandbox.yaml")
+		assert.NotNil(t, err)
+	})
+	t.Run("Invalid volume", func(t *testing.T) {
+		_, err := LoadFileConfig(writeSandboxConfig(t, "volumes:\n  - source: data\n"))
+		assert.NotNil(t, err)
+	})
+	t.Run("Invalid memory", func(t *testing.T) {
+		fileConfig, err := LoadFileConfig(writeSandboxConfig(t, "resources:\n  memory: lots\n"))
+		assert.Nil(t, err)
+		_, err = fileConfig.ContainerResources()
+		assert.EqualError(t, err, "invalid memory lots: invalid size: 'lots'")
+	})
+}
+
+func TestFileConfigPortBindings(t *testing.T) {
+	exposedPorts, portBindings, err := docker.GetSandboxPorts()
+	assert.Nil(t, err)
+
+	t.Run("Remapped and extra ports", func(t *testing.T) {
+		fileConfig := &FileConfig{HostPorts: map[int]int{30081: 31081}, Ports: []string{"0.0.0.0:30100:30100"}}
+		exposed, bindings, err := fileConfig.PortBindings(exposedPorts, portBindings)
+		assert.Nil(t, err)
+		assert.Contains(t, exposed, nat.Port("30100/tcp"))
+		assert.Equal(t, []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "30100"}}, bindings["30100/tcp"])
+		assert.ElementsMatch(t, []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "30080"}, {HostIP: "0.0.0.0", HostPort: "31081"}},
+			bindings["30081/tcp"])
+		assert.NotContains(t, exposedPorts, nat.Port("30100/tcp"))
+	})
+	t.Run("Unknown host port", func(t *testing.T) {
+		fileConfig := &FileConfig{HostPorts: map[int]int{30000: 31000}}
+		_, _, err := fileConfig.PortBindings(exposedPorts, portBindings)
+		assert.EqualError(t, err, "host port 30000 is not a sandbox port and can't be remapped")
+	})
+}
+
+func TestFileConfigMounts(t *testing.T) {
+	name := "config-test"
+	defer func() {
+		_ = os.RemoveAll(configutil.SandboxDir(name))
+	}()
+	registries := f.FilePathJoin(configutil.SandboxDir(name), "k3s", registriesFile)
+	helmValues := f.FilePathJoin(configutil.SandboxDir(name), helmValuesFile)
+
+	fileConfig := &FileConfig{
+		Volumes:         []Volume{{Source: "/tmp/data", Target: "/data"}},
+		RegistryMirrors: map[string][]string{"docker.io": {"https://mirror.gcr.io"}},
+		HelmValues:      map[string]interface{}{"flyteadmin": map[string]interface{}{"replicaCount": 2}},
+	}
+	mounts, err := fileConfig.Mounts(name)
+	assert.Nil(t, err)
+	assert.Equal(t, []mount.Mount{
+		{Type: mount.TypeBind, Source: "/tmp/data", Target: "/data"},
+		{Type: mount.TypeBind, Source: helmValues, Target: helmValuesTarget, ReadOnly: true},
+	}, mounts)
+
+	content, err := os.ReadFile(registries)
+	assert.Nil(t, err)
+	assert.Equal(t, `mirrors:
+  docker.io:
+    endpoint:
+    - https://mirror.gcr.io
+`, string(content))
+	content, err = os.ReadFile(helmValues)
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: helm.cattle.io/v1
+kind: HelmChartConfig
+metadata:
+  name: flyte
+  namespace: kube-system
+spec:
+  valuesContent: |
+    flyteadmin:
+      replicaCount: 2
+`, string(content))
+
+	// A configuration without mirrors nor Helm values removes the files written by the previous one.
+	mounts, err = (&FileConfig{}).Mounts(name)
+	assert.Nil(t, err)
+	assert.Empty(t, mounts)
+	_, err = os.Stat(registries)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(helmValues)
+	assert.True(t, os.IsNotExist(err))
+}
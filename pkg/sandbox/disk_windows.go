@@ -0,0 +1,18 @@
+//go:build windows
+
+package sandbox
+
+import "golang.org/x/sys/windows"
+
+// freeDiskSpace returns the number of bytes available to the user in the file system of the path
+func freeDiskSpace(path string) (uint64, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
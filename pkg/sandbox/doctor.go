@@ -0,0 +1,277 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/k8s"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// CheckStatus is the outcome of a doctor check
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "pass"
+	CheckFailed  CheckStatus = "fail"
+	CheckSkipped CheckStatus = "skip"
+
+	// minFreeDisk and minMemory are the resources below which the Flyte deployment gets evicted or doesn't start.
+	minFreeDisk = 10 * units.GiB
+	minMemory   = 4 * units.GiB
+
+	adminHealthPath = "/healthcheck"
+	minioHealthPath = "/minio/health/live"
+	doctorTimeout   = 5 * time.Second
+)
+
+// Check is the result of one of the checks run by the doctor, along with the hint to fix it if it failed
+type Check struct {
+	Name    string      `json:"name"`
+	Status  CheckStatus `json:"status"`
+	Message string      `json:"message"`
+	Hint    string      `json:"hint,omitempty"`
+}
+
+// Doctor runs the health checks of a sandbox and of the environment it runs in
+type Doctor struct {
+	cli        docker.Docker
+	httpClient *http.Client
+	// freeDiskSpace and portAvailable are swapped by the tests.
+	freeDiskSpace func(path string) (uint64, error)
+	portAvailable func(port int) bool
+}
+
+// NewDoctor returns a doctor using the docker client
+func NewDoctor(cli docker.Docker) *Doctor {
+	return &Doctor{
+		cli:           cli,
+		httpClient:    &http.Client{Timeout: doctorTimeout},
+		freeDiskSpace: freeDiskSpace,
+		portAvailable: portAvailable,
+	}
+}
+
+// Run returns the checks of the named sandbox. The checks of the cluster are skipped when the sandbox isn't running.
+func (d *Doctor) Run(ctx context.Context, name string) []Check {
+	checks := []Check{d.checkDocker(ctx)}
+	if checks[0].Status == CheckFailed {
+		return checks
+	}
+	checks = append(checks, d.checkMemory(ctx), d.checkDisk())
+
+	c, err := docker.GetSandbox(ctx, d.cli, name)
+	if err != nil {
+		return append(checks, Check{Name: "Sandbox container", Status: CheckFailed, Message: err.Error(),
+			Hint: "Check that the docker daemon is running"})
+	}
+	var labels map[string]string
+	running := c != nil && c.State == "running"
+	if c != nil {
+		labels = c.Labels
+	}
+	checks = append(checks, d.checkPorts(labels, running)...)
+
+	if !running {
+		skipped := fmt.Sprintf("sandbox container %v is not running", docker.SandboxContainerName(name))
+		for _, checkName := range []string{"Kube context", "Kubernetes API", "Flyteadmin", "Minio"} {
+			checks = append(checks, Check{Name: checkName, Status: CheckSkipped, Message: skipped,
+				Hint: "Start the sandbox with flytectl sandbox start"})
+		}
+		return checks
+	}
+	checks = append(checks, checkKubeContext(name))
+	checks = append(checks, checkKubernetesAPI(ctx, name, docker.SandboxHostPort(labels, k8sPort)))
+	checks = append(checks, d.checkEndpoint("Flyteadmin", fmt.Sprintf("http://localhost:%v%v", docker.SandboxHostPort(labels, flyteAdminPort), adminHealthPath),
+		"Check the flyteadmin logs with flytectl sandbox logs flyteadmin"))
+	checks = append(checks, d.checkEndpoint("Minio", fmt.Sprintf("http://localhost:%v%v", docker.SandboxHostPort(labels, minioPort), minioHealthPath),
+		"Check the minio logs with flytectl sandbox logs minio"))
+	return checks
+}
+
+func (d *Doctor) checkDocker(ctx context.Context) Check {
+	check := Check{Name: "Docker daemon"}
+	version, err := d.cli.ServerVersion(ctx)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = err.Error()
+		check.Hint = "Start docker or point DOCKER_HOST to a running docker daemon"
+		return check
+	}
+	check.Status = CheckPassed
+	check.Message = fmt.Sprintf("docker %v (API %v) on %v/%v", version.Version, version.APIVersion, version.Os, version.Arch)
+	return check
+}
+
+func (d *Doctor) checkMemory(ctx context.Context) Check {
+	check := Check{Name: "Memory"}
+	info, err := d.cli.Info(ctx)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = err.Error()
+		return check
+	}
+	check.Message = fmt.Sprintf("%v available to docker with %v CPUs", units.BytesSize(float64(info.MemTotal)), info.NCPU)
+	if info.MemTotal < minMemory {
+		check.Status = CheckFailed
+		check.Hint = fmt.Sprintf("Allow docker to use at least %v of memory", units.BytesSize(minMemory))
+		return check
+	}
+	check.Status = CheckPassed
+	return check
+}
+
+func (d *Doctor) checkDisk() Check {
+	check := Check{Name: "Disk"}
+	dir := configutil.SandboxDir("")
+	free, err := d.freeDiskSpace(dir)
+	if err != nil {
+		check.Status = CheckSkipped
+		check.Message = err.Error()
+		return check
+	}
+	check.Message = fmt.Sprintf("%v free in %v", units.BytesSize(float64(free)), dir)
+	if free < minFreeDisk {
+		check.Status = CheckFailed
+		check.Hint = "Free some disk space, e.g. with docker system prune -a --volumes"
+		return check
+	}
+	check.Status = CheckPassed
+	return check
+}
+
+// checkPorts checks that the host ports of the sandbox and of the demo are available, the ones published by the
+// running sandbox being expected to be in use.
+func (d *Doctor) checkPorts(labels map[string]string, running bool) []Check {
+	var ports []int
+	seen := map[int]bool{}
+	for _, getPorts := range []func() (map[nat.Port]struct{}, map[nat.Port][]nat.PortBinding, error){docker.GetSandboxPorts, docker.GetDemoPorts} {
+		_, portBindings, err := getPorts()
+		if err != nil {
+			return []Check{{Name: "Ports", Status: CheckFailed, Message: err.Error()}}
+		}
+		for _, bindings := range portBindings {
+			for _, binding := range bindings {
+				port, err := strconv.Atoi(binding.HostPort)
+				if err != nil || seen[port] {
+					continue
+				}
+				seen[port] = true
+				ports = append(ports, docker.SandboxHostPort(labels, port))
+			}
+		}
+	}
+	sort.Ints(ports)
+
+	checks := make([]Check, 0, len(ports))
+	for _, port := range ports {
+		check := Check{Name: fmt.Sprintf("Port %v", port), Status: CheckPassed, Message: "available"}
+		switch {
+		case running:
+			check.Message = "published by the sandbox"
+		case !d.portAvailable(port):
+			check.Status = CheckFailed
+			check.Message = "in use by another process"
+			check.Hint = "Stop the process listening on the port or remap it with hostPorts in the sandbox config file"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func checkKubeContext(name string) Check {
+	kubeconfig := configutil.SandboxKubeconfig(name)
+	check := Check{Name: "Kube context"}
+	cfg, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = err.Error()
+		check.Hint = "Restart the sandbox to write its kubeconfig again"
+		return check
+	}
+	if _, ok := cfg.Contexts[sandboxDockerContext]; !ok {
+		check.Status = CheckFailed
+		check.Message = fmt.Sprintf("context %v not found in %v", sandboxDockerContext, kubeconfig)
+		check.Hint = "Restart the sandbox to write its kubeconfig again"
+		return check
+	}
+	check.Status = CheckPassed
+	check.Message = fmt.Sprintf("context %v found in %v", sandboxDockerContext, kubeconfig)
+	return check
+}
+
+func checkKubernetesAPI(ctx context.Context, name string, apiPort int) Check {
+	check := Check{Name: "Kubernetes API"}
+	k8sClient, err := k8s.GetK8sClient(configutil.SandboxKubeconfig(name), fmt.Sprintf("https://127.0.0.1:%v", apiPort))
+	if err == nil {
+		var tainted bool
+		if _, err = k8sClient.CoreV1().Nodes().List(ctx, v1.ListOptions{}); err == nil {
+			tainted, err = isNodeTainted(ctx, k8sClient.CoreV1())
+		}
+		if err == nil && tainted {
+			check.Status = CheckFailed
+			check.Message = "the sandbox node is under disk pressure"
+			check.Hint = "Free some disk space, e.g. with docker system prune -a --volumes"
+			return check
+		}
+	}
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = err.Error()
+		check.Hint = "Check the sandbox container logs with flytectl sandbox logs"
+		return check
+	}
+	check.Status = CheckPassed
+	check.Message = fmt.Sprintf("reachable on port %v", apiPort)
+	return check
+}
+
+func (d *Doctor) checkEndpoint(name, url, hint string) Check {
+	check := Check{Name: name}
+	resp, err := d.httpClient.Get(url)
+	if err == nil {
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("%v returned %v", url, resp.Status)
+		}
+	}
+	if err != nil {
+		check.Status = CheckFailed
+		check.Message = err.Error()
+		check.Hint = hint
+		return check
+	}
+	check.Status = CheckPassed
+	check.Message = fmt.Sprintf("%v is healthy", url)
+	return check
+}
+
+func portAvailable(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// FailedChecks returns the number of failed checks
+func FailedChecks(checks []Check) int {
+	failed := 0
+	for _, c := range checks {
+		if c.Status == CheckFailed {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,116 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/flyteorg/flytectl/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func testDoctor(cli docker.Docker, freeDisk uint64, usedPort int) *Doctor {
+	return &Doctor{
+		cli:        cli,
+		httpClient: http.DefaultClient,
+		freeDiskSpace: func(string) (uint64, error) {
+			return freeDisk, nil
+		},
+		portAvailable: func(port int) bool {
+			return port != usedPort
+		},
+	}
+}
+
+func checkStatuses(checks []Check) map[string]CheckStatus {
+	statuses := map[string]CheckStatus{}
+	for _, c := range checks {
+		statuses[c.Name] = c.Status
+	}
+	return statuses
+}
+
+func TestDoctor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Docker unreachable", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnServerVersion(ctx).Return(types.Version{}, fmt.Errorf("cannot connect to the docker daemon"))
+		checks := testDoctor(mockDocker, 0, 0).Run(ctx, "")
+		assert.Equal(t, []Check{{Name: "Docker daemon", Status: CheckFailed, Message: "cannot connect to the docker daemon",
+			Hint: "Start docker or point DOCKER_HOST to a running docker daemon"}}, checks)
+		assert.Equal(t, 1, FailedChecks(checks))
+	})
+
+	t.Run("Sandbox not running", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnServerVersion(ctx).Return(types.Version{Version: "20.10.7", APIVersion: "1.41", Os: "linux", Arch: "amd64"}, nil)
+		mockDocker.OnInfo(ctx).Return(types.Info{MemTotal: 2 * 1024 * 1024 * 1024, NCPU: 4}, nil)
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		checks := testDoctor(mockDocker, 20*1024*1024*1024, 30081).Run(ctx, "")
+		statuses := checkStatuses(checks)
+		assert.Equal(t, CheckPassed, statuses["Docker daemon"])
+		assert.Equal(t, "docker 20.10.7 (API 1.41) on linux/amd64", checks[0].Message)
+		assert.Equal(t, CheckFailed, statuses["Memory"])
+		assert.Equal(t, CheckPassed, statuses["Disk"])
+		assert.Equal(t, CheckFailed, statuses["Port 30081"])
+		assert.Equal(t, CheckPassed, statuses["Port 30090"])
+		assert.Equal(t, CheckSkipped, statuses["Flyteadmin"])
+		assert.Equal(t, CheckSkipped, statuses["Minio"])
+		// 8 distinct host ports of the sandbox and the demo
+		assert.Equal(t, 3+8+4, len(checks))
+		assert.Equal(t, 2, FailedChecks(checks))
+	})
+
+	t.Run("Running sandbox", func(t *testing.T) {
+		name := "doctor-test"
+		assert.Nil(t, os.MkdirAll(f.FilePathJoin(configutil.SandboxDir(name), "k3s"), os.ModePerm))
+		assert.Nil(t, os.WriteFile(configutil.SandboxKubeconfig(name), []byte(content), os.ModePerm))
+		defer func() {
+			_ = os.RemoveAll(configutil.SandboxDir(name))
+			k8s.Client = nil
+		}()
+		k8s.Client = testclient.NewSimpleClientset(&corev1.Node{})
+
+		admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, adminHealthPath, r.URL.Path)
+		}))
+		defer admin.Close()
+		minio := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer minio.Close()
+		adminURL, _ := url.Parse(admin.URL)
+		minioURL, _ := url.Parse(minio.URL)
+
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnServerVersion(ctx).Return(types.Version{Version: "20.10.7"}, nil)
+		mockDocker.OnInfo(ctx).Return(types.Info{MemTotal: 8 * 1024 * 1024 * 1024}, nil)
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{
+			{ID: "1", Names: []string{"/flyte-sandbox-doctor-test"}, State: "running", Labels: map[string]string{
+				docker.SandboxNameLabel:      name,
+				docker.SandboxHostPortsLabel: fmt.Sprintf("30081=%v,30084=%v", adminURL.Port(), minioURL.Port()),
+			}},
+		}, nil)
+		checks := testDoctor(mockDocker, 20*1024*1024*1024, 30081).Run(ctx, name)
+		statuses := checkStatuses(checks)
+		assert.Equal(t, CheckPassed, statuses["Memory"])
+		assert.Equal(t, CheckPassed, statuses["Port "+adminURL.Port()])
+		assert.Equal(t, CheckPassed, statuses["Kube context"])
+		assert.Equal(t, CheckPassed, statuses["Kubernetes API"])
+		assert.Equal(t, CheckPassed, statuses["Flyteadmin"])
+		assert.Equal(t, CheckFailed, statuses["Minio"])
+		assert.Equal(t, 1, FailedChecks(checks))
+	})
+}
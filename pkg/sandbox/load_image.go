@@ -0,0 +1,91 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/enescakir/emoji"
+	"github.com/flyteorg/flytectl/pkg/docker"
+)
+
+// importImageCmd imports the image archive read from stdin into the containerd namespace of the k3s cluster of the
+// sandbox, keeping the digests so that the pods can refer to the images by digest too.
+var importImageCmd = []string{"ctr", "--address", "/run/k3s/containerd/containerd.sock", "--namespace", "k8s.io",
+	"images", "import", "--digests", "-"}
+
+// LoadImages exports the images from the container runtime of the host and imports them into the containerd of the
+// named sandbox, so that the pods of the sandbox can use the locally built images without pushing them to a registry.
+func LoadImages(ctx context.Context, cli docker.Docker, name string, images []string) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("sandbox container %v not found", docker.SandboxContainerName(name))
+	}
+	if c.State != "running" {
+		return fmt.Errorf("sandbox container %v is not running", docker.SandboxContainerName(name))
+	}
+	for _, image := range images {
+		fmt.Printf("%v loading image %v into sandbox container %v\n", emoji.Package, image, docker.SandboxContainerName(name))
+		if err := loadImage(ctx, cli, c.ID, image); err != nil {
+			return fmt.Errorf("failed to load image %v: %w", image, err)
+		}
+	}
+	return nil
+}
+
+// loadImage streams the archive of the image saved by the host into the stdin of the import command run in the sandbox
+// container, the way kind load docker-image does.
+func loadImage(ctx context.Context, cli docker.Docker, id, image string) error {
+	archive, err := cli.ImageSave(ctx, []string{image})
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	exec, err := cli.ContainerExecCreate(ctx, id, types.ExecConfig{
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          importImageCmd,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := cli.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	copyErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(resp.Conn, archive)
+		if closeErr := resp.CloseWrite(); err == nil {
+			err = closeErr
+		}
+		copyErr <- err
+	}()
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, resp.Reader); err != nil {
+		return err
+	}
+	if err := <-copyErr; err != nil {
+		return err
+	}
+
+	inspect, err := cli.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("import exited with code %v: %v", inspect.ExitCode, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
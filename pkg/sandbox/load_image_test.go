@@ -0,0 +1,83 @@
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// stdinConn records the stdin written to an attached exec
+type stdinConn struct {
+	net.Conn
+	stdin       bytes.Buffer
+	writeClosed bool
+}
+
+func (c *stdinConn) Write(b []byte) (int, error) { return c.stdin.Write(b) }
+func (c *stdinConn) CloseWrite() error {
+	c.writeClosed = true
+	return nil
+}
+func (c *stdinConn) Close() error { return nil }
+
+func execOutput(t *testing.T, output string) *bufio.Reader {
+	var buf bytes.Buffer
+	_, err := stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte(output))
+	assert.Nil(t, err)
+	return bufio.NewReader(&buf)
+}
+
+func TestLoadImages(t *testing.T) {
+	ctx := context.Background()
+	sandboxContainer := types.Container{ID: "sandbox", Names: []string{"/flyte-sandbox"}, State: "running"}
+	setup := func(t *testing.T, exitCode int, output string) (*mocks.Docker, *stdinConn) {
+		conn := &stdinConn{}
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{sandboxContainer}, nil)
+		mockDocker.OnImageSave(ctx, []string{"my-image:dev"}).Return(ioutil.NopCloser(strings.NewReader("archive")), nil)
+		mockDocker.OnContainerExecCreateMatch(ctx, "sandbox", mock.MatchedBy(func(config types.ExecConfig) bool {
+			return config.AttachStdin && assert.ObjectsAreEqual(importImageCmd, config.Cmd)
+		})).Return(types.IDResponse{ID: "exec"}, nil)
+		mockDocker.OnContainerExecAttach(ctx, "exec", types.ExecStartCheck{}).Return(types.HijackedResponse{
+			Conn:   conn,
+			Reader: execOutput(t, output),
+		}, nil)
+		mockDocker.OnContainerExecInspect(ctx, "exec").Return(types.ContainerExecInspect{ExitCode: exitCode}, nil)
+		return mockDocker, conn
+	}
+
+	t.Run("Load image", func(t *testing.T) {
+		mockDocker, conn := setup(t, 0, "unpacking docker.io/library/my-image:dev...done")
+		assert.Nil(t, LoadImages(ctx, mockDocker, "", []string{"my-image:dev"}))
+		assert.Equal(t, "archive", conn.stdin.String())
+		assert.True(t, conn.writeClosed)
+	})
+	t.Run("Import failure", func(t *testing.T) {
+		mockDocker, _ := setup(t, 1, "ctr: unrecognized image format\n")
+		err := LoadImages(ctx, mockDocker, "", []string{"my-image:dev"})
+		assert.EqualError(t, err, "failed to load image my-image:dev: import exited with code 1: ctr: unrecognized image format")
+	})
+	t.Run("Sandbox not running", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{
+			{ID: "sandbox", Names: []string{"/flyte-sandbox"}, State: "exited"}}, nil)
+		err := LoadImages(ctx, mockDocker, "", []string{"my-image:dev"})
+		assert.EqualError(t, err, "sandbox container flyte-sandbox is not running")
+	})
+	t.Run("Sandbox not found", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{sandboxContainer}, nil)
+		err := LoadImages(ctx, mockDocker, "feature-x", []string{"my-image:dev"})
+		assert.EqualError(t, err, "sandbox container flyte-sandbox-feature-x not found")
+	})
+}
@@ -0,0 +1,167 @@
+package sandbox
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/k8s"
+	propellerK8s "github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	corev1api "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const componentLabel = "app.kubernetes.io/name"
+
+// LogOptions holds the options shared by the logs of the sandbox container and the ones of the pods
+type LogOptions struct {
+	// Follow streams the logs until the context is done
+	Follow bool
+	// Since restricts the logs to the ones more recent than the duration, zero meaning all the logs
+	Since time.Duration
+}
+
+// ContainerLogs writes the logs of the container of the named sandbox
+func ContainerLogs(ctx context.Context, cli docker.Docker, name string, options LogOptions, out io.Writer) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("sandbox container %v not found", docker.SandboxContainerName(name))
+	}
+	return docker.CopyLogs(ctx, cli, c.ID, options.Follow, options.Since, out, out)
+}
+
+// GetK8sClient returns the k8s client of the named sandbox
+func GetK8sClient(ctx context.Context, cli docker.Docker, name string) (k8s.K8s, error) {
+	c, err := docker.GetSandbox(ctx, cli, name)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, fmt.Errorf("sandbox container %v not found", docker.SandboxContainerName(name))
+	}
+	endpoint := fmt.Sprintf("https://127.0.0.1:%v", docker.SandboxHostPort(c.Labels, k8sPort))
+	return k8s.GetK8sClient(configutil.SandboxKubeconfig(name), endpoint)
+}
+
+// ComponentLogs writes the logs of the pods of a Flyte component, e.g. flyteadmin, running in the flyte namespace
+func ComponentLogs(ctx context.Context, client corev1.CoreV1Interface, component string, options LogOptions, out io.Writer) error {
+	pods, err := client.Pods(flyteNamespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var matches []corev1api.Pod
+	components := map[string]bool{}
+	for _, pod := range pods.Items {
+		name := podComponent(pod)
+		components[name] = true
+		if name == component || pod.Name == component {
+			matches = append(matches, pod)
+		}
+	}
+	if len(matches) == 0 {
+		available := make([]string, 0, len(components))
+		for name := range components {
+			available = append(available, name)
+		}
+		sort.Strings(available)
+		return fmt.Errorf("no pod found for component %v. Available components: %v", component, strings.Join(available, ", "))
+	}
+	return streamPodLogs(ctx, client, matches, options, out)
+}
+
+// ExecutionLogs writes the logs of all the task pods of an execution
+func ExecutionLogs(ctx context.Context, client corev1.CoreV1Interface, namespace, execution string, options LogOptions, out io.Writer) error {
+	pods, err := client.Pods(namespace).List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%v=%v", propellerK8s.ExecutionIDLabel, execution),
+	})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no task pod found for execution %v in namespace %v", execution, namespace)
+	}
+	return streamPodLogs(ctx, client, pods.Items, options, out)
+}
+
+// podComponent returns the component of a pod from its labels, or else from its name without the suffixes added by
+// the deployment and the replica set.
+func podComponent(pod corev1api.Pod) string {
+	if name, ok := pod.Labels[componentLabel]; ok {
+		return name
+	}
+	if name, ok := pod.Labels["app"]; ok {
+		return name
+	}
+	parts := strings.Split(pod.Name, "-")
+	if len(parts) > 2 {
+		return strings.Join(parts[:len(parts)-2], "-")
+	}
+	return pod.Name
+}
+
+// streamPodLogs writes the logs of every container of the pods, each line being prefixed with the pod and container
+// names. The logs are streamed concurrently so that following them interleaves the lines of all the containers.
+func streamPodLogs(ctx context.Context, client corev1.CoreV1Interface, pods []corev1api.Pod, options LogOptions, out io.Writer) error {
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	type podContainer struct {
+		pod       corev1api.Pod
+		container string
+	}
+	var containers []podContainer
+	for _, pod := range pods {
+		for _, c := range append(append([]corev1api.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			containers = append(containers, podContainer{pod: pod, container: c.Name})
+		}
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(chan error, len(containers))
+	for _, c := range containers {
+		logOptions := &corev1api.PodLogOptions{Container: c.container, Follow: options.Follow}
+		if options.Since > 0 {
+			seconds := int64(options.Since.Seconds())
+			logOptions.SinceSeconds = &seconds
+		}
+		stream, err := client.Pods(c.pod.Namespace).GetLogs(c.pod.Name, logOptions).Stream(ctx)
+		if err != nil {
+			errs <- fmt.Errorf("failed to get logs of %v/%v: %w", c.pod.Name, c.container, err)
+			continue
+		}
+		prefix := fmt.Sprintf("[%v/%v] ", c.pod.Name, c.container)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer stream.Close()
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				mutex.Lock()
+				_, err := fmt.Fprintf(out, "%v%v\n", prefix, scanner.Text())
+				mutex.Unlock()
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
@@ -0,0 +1,75 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	propellerK8s "github.com/flyteorg/flytepropeller/pkg/compiler/transformers/k8s"
+	"github.com/stretchr/testify/assert"
+	corev1api "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func logsPod(name, namespace string, labels map[string]string, containers ...string) *corev1api.Pod {
+	pod := &corev1api.Pod{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1api.Container{Name: c})
+	}
+	return pod
+}
+
+func TestComponentLogs(t *testing.T) {
+	ctx := context.Background()
+	client := testclient.NewSimpleClientset(
+		logsPod("flyteadmin-6d9c8f7b5-x2x4z", flyteNamespace, map[string]string{componentLabel: "flyteadmin"}, "flyteadmin", "sync"),
+		logsPod("flytepropeller-7f8d9c6b4-abcde", flyteNamespace, nil, "flytepropeller"),
+		logsPod("minio-5c8b7d9f6-q1w2e", flyteNamespace, map[string]string{"app": "minio"}, "minio"),
+	)
+
+	t.Run("Component label", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Nil(t, ComponentLogs(ctx, client.CoreV1(), "flyteadmin", LogOptions{}, &out))
+		assert.Contains(t, out.String(), "[flyteadmin-6d9c8f7b5-x2x4z/flyteadmin] fake logs\n")
+		assert.Contains(t, out.String(), "[flyteadmin-6d9c8f7b5-x2x4z/sync] fake logs\n")
+	})
+
+	t.Run("Pod name", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Nil(t, ComponentLogs(ctx, client.CoreV1(), "flytepropeller", LogOptions{}, &out))
+		assert.Equal(t, "[flytepropeller-7f8d9c6b4-abcde/flytepropeller] fake logs\n", out.String())
+	})
+
+	t.Run("Unknown component", func(t *testing.T) {
+		var out bytes.Buffer
+		err := ComponentLogs(ctx, client.CoreV1(), "datacatalog", LogOptions{}, &out)
+		assert.EqualError(t, err, "no pod found for component datacatalog. Available components: flyteadmin, flytepropeller, minio")
+	})
+}
+
+func TestExecutionLogs(t *testing.T) {
+	ctx := context.Background()
+	client := testclient.NewSimpleClientset(
+		logsPod("f1234-n0-0", "flytesnacks-development", map[string]string{propellerK8s.ExecutionIDLabel: "f1234"}, "primary"),
+		logsPod("f5678-n0-0", "flytesnacks-development", map[string]string{propellerK8s.ExecutionIDLabel: "f5678"}, "primary"),
+	)
+
+	t.Run("Task pods", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Nil(t, ExecutionLogs(ctx, client.CoreV1(), "flytesnacks-development", "f1234", LogOptions{}, &out))
+		assert.Equal(t, "[f1234-n0-0/primary] fake logs\n", out.String())
+	})
+
+	t.Run("No task pod", func(t *testing.T) {
+		var out bytes.Buffer
+		err := ExecutionLogs(ctx, client.CoreV1(), "flytesnacks-production", "f1234", LogOptions{}, &out)
+		assert.EqualError(t, err, "no task pod found for execution f1234 in namespace flytesnacks-production")
+	})
+}
+
+func TestPodComponent(t *testing.T) {
+	assert.Equal(t, "flyteadmin", podComponent(*logsPod("flyteadmin-6d9c8f7b5-x2x4z", flyteNamespace, nil)))
+	assert.Equal(t, "minio", podComponent(*logsPod("storage-0", flyteNamespace, map[string]string{"app": "minio"})))
+	assert.Equal(t, "postgres", podComponent(*logsPod("postgres", flyteNamespace, nil)))
+}
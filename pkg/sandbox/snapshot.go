@@ -0,0 +1,273 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/enescakir/emoji"
+	sandboxCmdConfig "github.com/flyteorg/flytectl/cmd/config/subcommand/sandbox"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
+	"github.com/flyteorg/flytectl/pkg/util"
+)
+
+const snapshotMetadataFile = "snapshot.json"
+
+// snapshotPaths are the directories of the sandbox container holding the state of the cluster. The k3s data dir
+// holds the datastore of the cluster along with the volumes of the database and the object store.
+var snapshotPaths = []string{"/var/lib/rancher/k3s", "/var/lib/flyte"}
+
+// SnapshotMetadata describes a snapshot of a sandbox. The config of the sandbox container is kept to recreate an
+// identical container on restore.
+type SnapshotMetadata struct {
+	Sandbox    string                `json:"sandbox"`
+	Image      string                `json:"image"`
+	CreatedAt  time.Time             `json:"createdAt"`
+	Paths      []string              `json:"paths"`
+	Config     *container.Config     `json:"config"`
+	HostConfig *container.HostConfig `json:"hostConfig"`
+}
+
+// SaveSnapshot stops the container of the named sandbox and archives its state to the snapshot dir. The container is
+// started again afterwards if it was running, whether or not the snapshot could be saved.
+func SaveSnapshot(ctx context.Context, cli docker.Docker, sandboxName, snapshot string, force bool) (err error) {
+	if !sandboxNameRegex.MatchString(snapshot) {
+		return fmt.Errorf("invalid snapshot name %q. Only lowercase alphanumeric characters and '-' are allowed", snapshot)
+	}
+	dir := configutil.SnapshotDir(snapshot)
+	if _, err := os.Stat(dir); err == nil && !force {
+		return fmt.Errorf("snapshot %v already exists. Use --force to overwrite it", snapshot)
+	}
+	c, err := docker.GetSandbox(ctx, cli, sandboxName)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("sandbox container %v not found", docker.SandboxContainerName(sandboxName))
+	}
+	inspect, err := cli.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ContainerJSONBase == nil || inspect.Config == nil {
+		return fmt.Errorf("unable to inspect sandbox container %v", docker.SandboxContainerName(sandboxName))
+	}
+	running := inspect.State != nil && inspect.State.Running
+	if running {
+		fmt.Printf("%v stopping sandbox container %v\n", emoji.StopSign, docker.SandboxContainerName(sandboxName))
+		if err := cli.ContainerStop(ctx, c.ID, nil); err != nil {
+			return err
+		}
+		defer func() {
+			if err == nil {
+				err = bootSandbox(ctx, cli, c.ID, inspect.Config.Labels)
+				return
+			}
+			if startErr := cli.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); startErr != nil {
+				fmt.Printf("%v unable to start sandbox container %v again: %v\n", emoji.CrossMark, docker.SandboxContainerName(sandboxName), startErr)
+			}
+		}()
+	}
+
+	tmpDir := dir + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return err
+	}
+	metadata := SnapshotMetadata{
+		Sandbox:    sandboxName,
+		Image:      inspect.Config.Image,
+		CreatedAt:  time.Now(),
+		Config:     inspect.Config,
+		HostConfig: inspect.HostConfig,
+	}
+	for _, p := range snapshotPaths {
+		saved, err := archivePath(ctx, cli, c.ID, p, f.FilePathJoin(tmpDir, archiveName(p)))
+		if err != nil {
+			_ = os.RemoveAll(tmpDir)
+			return err
+		}
+		if saved {
+			metadata.Paths = append(metadata.Paths, p)
+		}
+	}
+	if err := writeSnapshotMetadata(f.FilePathJoin(tmpDir, snapshotMetadataFile), metadata); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return err
+	}
+	fmt.Printf("%v snapshot %v saved to %v\n", emoji.Package, snapshot, dir)
+	return nil
+}
+
+// RestoreSnapshot recreates the container of the snapshotted sandbox from the archived state, replacing the existing
+// container of the sandbox once confirmed.
+func RestoreSnapshot(ctx context.Context, cli docker.Docker, snapshot string, reader io.Reader) error {
+	dir := configutil.SnapshotDir(snapshot)
+	metadata, err := readSnapshotMetadata(f.FilePathJoin(dir, snapshotMetadataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("snapshot %v not found", snapshot)
+		}
+		return err
+	}
+	if err := docker.RemoveSandbox(ctx, cli, reader, metadata.Sandbox); err != nil {
+		return err
+	}
+	if err := setupSandboxConfig(metadata.Sandbox, docker.SandboxHostPort(metadata.Config.Labels, flyteAdminPort)); err != nil {
+		return err
+	}
+	if err := docker.PullDockerImage(ctx, cli, metadata.Image, docker.ImagePullPolicyIfNotPresent, docker.ImagePullOptions{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("%v restoring snapshot %v taken at %v\n", emoji.FactoryWorker, snapshot, metadata.CreatedAt.Format(time.RFC3339))
+	resp, err := cli.ContainerCreate(ctx, metadata.Config, metadata.HostConfig, nil, nil, docker.SandboxContainerName(metadata.Sandbox))
+	if err != nil {
+		return err
+	}
+	for _, p := range metadata.Paths {
+		if err := restorePath(ctx, cli, resp.ID, f.FilePathJoin(dir, archiveName(p))); err != nil {
+			return err
+		}
+	}
+	return bootSandbox(ctx, cli, resp.ID, metadata.Config.Labels)
+}
+
+// bootSandbox starts the sandbox container and waits for Flyte to be ready
+func bootSandbox(ctx context.Context, cli docker.Docker, id string, labels map[string]string) error {
+	fmt.Printf("%v booting Flyte-sandbox container\n", emoji.FactoryWorker)
+	if err := cli.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+		return err
+	}
+	logReader, err := docker.ReadLogs(ctx, cli, id)
+	if err != nil {
+		return err
+	}
+	docker.WaitForSandbox(logReader, docker.SuccessMessage)
+	name := labels[docker.SandboxNameLabel]
+	if _, err := connectCluster(ctx, name, docker.SandboxHostPort(labels, k8sPort), sandboxCmdConfig.DefaultConfig.Timeout.Duration); err != nil {
+		return err
+	}
+	util.PrintNamedSandboxMessage(consolePort(labels), name)
+	return nil
+}
+
+// consolePort returns the console port of a sandbox container from its labels, falling back to the default sandbox
+// console port for the containers started without it.
+func consolePort(labels map[string]string) int {
+	port, err := strconv.Atoi(labels[docker.SandboxConsolePortLabel])
+	if err != nil {
+		return docker.SandboxHostPort(labels, util.SandBoxConsolePort)
+	}
+	return port
+}
+
+// archivePath writes the gzipped tar archive of the container path to the file, the entries being named after their
+// absolute path in the container so that the archive can be extracted at the root of a new container. It returns false
+// if the path doesn't exist in the container.
+func archivePath(ctx context.Context, cli docker.Docker, id, containerPath, file string) (bool, error) {
+	content, _, err := cli.CopyFromContainer(ctx, id, containerPath)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer content.Close()
+	out, err := os.Create(file)
+	if err != nil {
+		return false, err
+	}
+	defer out.Close()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	tr := tar.NewReader(content)
+	parent := strings.TrimPrefix(path.Dir(containerPath), "/")
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+		header.Name = path.Join(parent, header.Name)
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = path.Join(parent, header.Linkname)
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return false, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return false, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return false, err
+	}
+	return true, gz.Close()
+}
+
+// restorePath extracts the gzipped tar archive of a container path into the container
+func restorePath(ctx context.Context, cli docker.Docker, id, file string) error {
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return cli.CopyToContainer(ctx, id, "/", gz, types.CopyToContainerOptions{})
+}
+
+func archiveName(containerPath string) string {
+	return strings.ReplaceAll(strings.Trim(containerPath, "/"), "/", "_") + ".tar.gz"
+}
+
+func writeSnapshotMetadata(file string, metadata SnapshotMetadata) error {
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0600)
+}
+
+func readSnapshotMetadata(file string) (SnapshotMetadata, error) {
+	var metadata SnapshotMetadata
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return metadata, err
+	}
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return metadata, fmt.Errorf("invalid snapshot metadata %v: %w", file, err)
+	}
+	if metadata.Config == nil {
+		return metadata, fmt.Errorf("invalid snapshot metadata %v: missing container config", file)
+	}
+	return metadata, nil
+}
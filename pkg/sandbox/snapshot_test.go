@@ -0,0 +1,144 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/flyteorg/flytectl/pkg/configutil"
+	"github.com/flyteorg/flytectl/pkg/docker"
+	"github.com/flyteorg/flytectl/pkg/docker/mocks"
+	"github.com/flyteorg/flytectl/pkg/k8s"
+	k8sMocks "github.com/flyteorg/flytectl/pkg/k8s/mocks"
+	"github.com/flyteorg/flytectl/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+const testSnapshot = "test-snapshot"
+
+func k3sArchive(t *testing.T) io.ReadCloser {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "k3s/", Typeflag: tar.TypeDir, Mode: 0755}))
+	assert.Nil(t, tw.WriteHeader(&tar.Header{Name: "k3s/state.db", Typeflag: tar.TypeReg, Mode: 0600, Size: 5}))
+	_, err := tw.Write([]byte("state"))
+	assert.Nil(t, err)
+	assert.Nil(t, tw.Close())
+	return ioutil.NopCloser(&buf)
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	defer func() { _ = os.RemoveAll(configutil.SnapshotDir(testSnapshot)) }()
+	sandboxContainer := types.Container{ID: "sandbox", Names: []string{"/" + docker.FlyteSandboxClusterName}}
+	containerConfig := &container.Config{Image: "sandbox:v1", Labels: map[string]string{docker.SandboxConsolePortLabel: "30081"}}
+
+	t.Run("Save snapshot", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{sandboxContainer}, nil)
+		mockDocker.OnContainerInspect(ctx, "sandbox").Return(types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{}, HostConfig: &container.HostConfig{Privileged: true}},
+			Config:            containerConfig,
+		}, nil)
+		mockDocker.OnCopyFromContainer(ctx, "sandbox", "/var/lib/rancher/k3s").Return(k3sArchive(t), types.ContainerPathStat{}, nil)
+		mockDocker.OnCopyFromContainer(ctx, "sandbox", "/var/lib/flyte").Return(nil, types.ContainerPathStat{},
+			errdefs.NotFound(fmt.Errorf("not found")))
+		assert.Nil(t, SaveSnapshot(ctx, mockDocker, "", testSnapshot, false))
+
+		metadata, err := readSnapshotMetadata(configutil.SnapshotDir(testSnapshot) + "/" + snapshotMetadataFile)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/var/lib/rancher/k3s"}, metadata.Paths)
+		assert.Equal(t, "sandbox:v1", metadata.Image)
+		assert.True(t, metadata.HostConfig.Privileged)
+
+		err = SaveSnapshot(ctx, mockDocker, "", testSnapshot, false)
+		assert.EqualError(t, err, "snapshot test-snapshot already exists. Use --force to overwrite it")
+	})
+	t.Run("Save snapshot of running sandbox fails", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{sandboxContainer}, nil)
+		mockDocker.OnContainerInspect(ctx, "sandbox").Return(types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Running: true}},
+			Config:            containerConfig,
+		}, nil)
+		mockDocker.OnContainerStop(ctx, "sandbox", (*time.Duration)(nil)).Return(nil)
+		mockDocker.OnCopyFromContainer(ctx, "sandbox", "/var/lib/rancher/k3s").Return(nil, types.ContainerPathStat{},
+			fmt.Errorf("copy failed"))
+		mockDocker.OnContainerStart(ctx, "sandbox", types.ContainerStartOptions{}).Return(nil)
+		err := SaveSnapshot(ctx, mockDocker, "", "failed-snapshot", false)
+		assert.EqualError(t, err, "copy failed")
+		mockDocker.AssertNumberOfCalls(t, "ContainerStart", 1)
+		_, err = os.Stat(configutil.SnapshotDir("failed-snapshot"))
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Save snapshot of missing sandbox", func(t *testing.T) {
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{sandboxContainer}, nil)
+		err := SaveSnapshot(ctx, mockDocker, "feature-x", testSnapshot, true)
+		assert.EqualError(t, err, "sandbox container flyte-sandbox-feature-x not found")
+	})
+	t.Run("Save snapshot with invalid name", func(t *testing.T) {
+		err := SaveSnapshot(ctx, &mocks.Docker{}, "", "../demo", false)
+		assert.NotNil(t, err)
+	})
+	t.Run("Restore snapshot", func(t *testing.T) {
+		assert.Nil(t, util.SetupFlyteDir())
+		assert.Nil(t, ioutil.WriteFile(docker.Kubeconfig, []byte(content), os.ModePerm))
+		client := testclient.NewSimpleClientset()
+		k8s.Client = client
+		_, err := client.CoreV1().Pods("flyte").Create(ctx, &fakePod, v1.CreateOptions{})
+		assert.Nil(t, err)
+		mockK8sContextMgr := &k8sMocks.ContextOps{}
+		mockK8sContextMgr.OnCopyContextMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		k8s.ContextMgr = mockK8sContextMgr
+
+		var restored bytes.Buffer
+		mockDocker := &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		mockDocker.OnImageListMatch(ctx, mock.Anything).Return([]types.ImageSummary{{RepoTags: []string{"sandbox:v1"}}}, nil)
+		mockDocker.OnContainerCreateMatch(ctx, mock.MatchedBy(func(c *container.Config) bool {
+			return c.Image == "sandbox:v1"
+		}), mock.MatchedBy(func(h *container.HostConfig) bool {
+			return h.Privileged
+		}), mock.Anything, mock.Anything, docker.FlyteSandboxClusterName).Return(container.ContainerCreateCreatedBody{ID: "restored"}, nil)
+		mockDocker.OnCopyToContainerMatch(ctx, "restored", "/", mock.Anything, types.CopyToContainerOptions{}).Run(func(args mock.Arguments) {
+			_, _ = io.Copy(&restored, args.Get(3).(io.Reader))
+		}).Return(nil)
+		mockDocker.OnContainerStart(ctx, "restored", types.ContainerStartOptions{}).Return(nil)
+		mockDocker.OnContainerLogsMatch(ctx, "restored", mock.Anything).Return(ioutil.NopCloser(strings.NewReader(docker.SuccessMessage)), nil)
+		assert.Nil(t, RestoreSnapshot(ctx, mockDocker, testSnapshot, strings.NewReader("y")))
+
+		tr := tar.NewReader(&restored)
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+			names = append(names, header.Name)
+		}
+		assert.Equal(t, []string{"var/lib/rancher/k3s", "var/lib/rancher/k3s/state.db"}, names)
+	})
+	t.Run("Restore missing snapshot", func(t *testing.T) {
+		err := RestoreSnapshot(ctx, &mocks.Docker{}, "missing-snapshot", strings.NewReader("y"))
+		assert.EqualError(t, err, "snapshot missing-snapshot not found")
+	})
+}
+
+func TestArchiveName(t *testing.T) {
+	assert.Equal(t, "var_lib_rancher_k3s.tar.gz", archiveName("/var/lib/rancher/k3s"))
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/avast/retry-go"
@@ -20,7 +22,6 @@ import (
 	"github.com/flyteorg/flytectl/pkg/github"
 	"github.com/flyteorg/flytectl/pkg/k8s"
 	"github.com/flyteorg/flytectl/pkg/util"
-	"github.com/kataras/tablewriter"
 	corev1api "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -31,9 +32,10 @@ const (
 	flyteNamespace       = "flyte"
 	diskPressureTaint    = "node.kubernetes.io/disk-pressure"
 	taintEffect          = "NoSchedule"
-	sandboxContextName   = "flyte-sandbox"
 	sandboxDockerContext = "default"
-	k8sEndpoint          = "https://127.0.0.1:30086"
+	k8sPort              = 30086
+	flyteAdminPort       = 30081
+	minioPort            = 30084
 	sandboxImageName     = "cr.flyte.org/flyteorg/flyte-sandbox"
 	demoImageName        = "cr.flyte.org/flyteorg/flyte-sandbox-lite"
 )
@@ -57,11 +59,20 @@ func isNodeTainted(ctx context.Context, client corev1.CoreV1Interface) (bool, er
 	return false, nil
 }
 
+// isPodReady returns true for the completed pods and the running pods whose containers are all ready
 func isPodReady(v corev1api.Pod) bool {
-	if (v.Status.Phase == corev1api.PodRunning) || (v.Status.Phase == corev1api.PodSucceeded) {
+	if v.Status.Phase == corev1api.PodSucceeded {
 		return true
 	}
-	return false
+	if v.Status.Phase != corev1api.PodRunning {
+		return false
+	}
+	for _, c := range v.Status.ContainerStatuses {
+		if !c.Ready {
+			return false
+		}
+	}
+	return true
 }
 
 func getFlyteDeployment(ctx context.Context, client corev1.CoreV1Interface) (*corev1api.PodList, error) {
@@ -72,59 +83,6 @@ func getFlyteDeployment(ctx context.Context, client corev1.CoreV1Interface) (*co
 	return pods, nil
 }
 
-func WatchFlyteDeployment(ctx context.Context, appsClient corev1.CoreV1Interface) error {
-	var data = os.Stdout
-	table := tablewriter.NewWriter(data)
-	table.SetHeader([]string{"Service", "Status", "Namespace"})
-	table.SetRowLine(true)
-
-	for {
-		isTaint, err := isNodeTainted(ctx, appsClient)
-		if err != nil {
-			return err
-		}
-		if isTaint {
-			return fmt.Errorf("docker sandbox doesn't have sufficient memory available. Please run docker system prune -a --volumes")
-		}
-
-		pods, err := getFlyteDeployment(ctx, appsClient)
-		if err != nil {
-			return err
-		}
-		table.ClearRows()
-		table.SetAutoWrapText(false)
-		table.SetAutoFormatHeaders(true)
-
-		// Clear os.Stdout
-		_, _ = data.WriteString("\x1b[3;J\x1b[H\x1b[2J")
-
-		var total, ready int
-		total = len(pods.Items)
-		ready = 0
-		if total != 0 {
-			for _, v := range pods.Items {
-				if isPodReady(v) {
-					ready++
-				}
-				if len(v.Status.Conditions) > 0 {
-					table.Append([]string{v.GetName(), string(v.Status.Phase), v.GetNamespace()})
-				}
-			}
-			table.Render()
-			if total == ready {
-				break
-			}
-		} else {
-			table.Append([]string{"k8s: This might take a little bit", "Bootstrapping", ""})
-			table.Render()
-		}
-
-		time.Sleep(40 * time.Second)
-	}
-
-	return nil
-}
-
 func MountVolume(file, destination string) (*mount.Mount, error) {
 	if len(file) > 0 {
 		source, err := filepath.Abs(file)
@@ -140,48 +98,129 @@ func MountVolume(file, destination string) (*mount.Mount, error) {
 	return nil, nil
 }
 
-func UpdateLocalKubeContext(dockerCtx string, contextName string) error {
+// sandboxNameRegex restricts the sandbox names to the ones usable in container, directory and kube context names.
+var sandboxNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func UpdateLocalKubeContext(kubeconfig, dockerCtx string, contextName string) error {
 	srcConfigAccess := &clientcmd.PathOptions{
-		GlobalFile:   docker.Kubeconfig,
+		GlobalFile:   kubeconfig,
 		LoadingRules: clientcmd.NewDefaultClientConfigLoadingRules(),
 	}
 	k8sCtxMgr := k8s.NewK8sContextManager()
 	return k8sCtxMgr.CopyContext(srcConfigAccess, dockerCtx, contextName)
 }
 
+// setKubeconfigServer points the clusters of the kubeconfig written by the sandbox to the given server, the sandbox
+// always writing its in-container port which differs from the host port of named sandboxes.
+func setKubeconfigServer(kubeconfig, server string) error {
+	cfg, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Clusters) == 0 {
+		return fmt.Errorf("no cluster found in kubeconfig %v", kubeconfig)
+	}
+	for _, cluster := range cfg.Clusters {
+		cluster.Server = server
+	}
+	return clientcmd.WriteToFile(*cfg, kubeconfig)
+}
+
+// sandboxLabels returns the labels of the container of the named sandbox, nil if it doesn't exist
+func sandboxLabels(ctx context.Context, cli docker.Docker, name string) (map[string]string, error) {
+	c, err := docker.GetSandbox(ctx, cli, name)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	return c.Labels, nil
+}
+
 func startSandbox(ctx context.Context, cli docker.Docker, g github.GHRepoService, reader io.Reader, sandboxConfig *sandboxCmdConfig.Config, defaultImageName string, defaultImagePrefix string, exposedPorts map[nat.Port]struct{}, portBindings map[nat.Port][]nat.PortBinding, consolePort int) (*bufio.Scanner, error) {
+	name := sandboxConfig.Name
+	if len(name) > 0 && !sandboxNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid sandbox name %q. Only lowercase alphanumeric characters and '-' are allowed", name)
+	}
+	fileConfig, err := LoadFileConfig(sandboxConfig.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := fileConfig.ContainerResources()
+	if err != nil {
+		return nil, err
+	}
+	if exposedPorts, portBindings, err = fileConfig.PortBindings(exposedPorts, portBindings); err != nil {
+		return nil, err
+	}
 	fmt.Printf("%v Bootstrapping a brand new flyte cluster... %v %v\n", emoji.FactoryWorker, emoji.Hammer, emoji.Wrench)
 
-	if err := docker.RemoveSandbox(ctx, cli, reader); err != nil {
+	if err := docker.RemoveSandbox(ctx, cli, reader, name); err != nil {
 		if err.Error() != clierrors.ErrSandboxExists {
 			return nil, err
 		}
+		labels, err := sandboxLabels(ctx, cli, name)
+		if err != nil {
+			return nil, err
+		}
 		fmt.Printf("Existing details of your sandbox")
-		util.PrintSandboxMessage(consolePort)
+		util.PrintNamedSandboxMessage(docker.SandboxHostPort(labels, consolePort), name)
 		return nil, nil
 	}
 
-	if err := util.SetupFlyteDir(); err != nil {
-		return nil, err
+	// Named sandboxes get their host ports shifted so that they can run side by side with the other sandboxes.
+	offset := 0
+	if len(name) > 0 {
+		if offset, err = docker.NextPortOffset(ctx, cli); err != nil {
+			return nil, err
+		}
+		if portBindings, err = docker.OffsetPortBindings(portBindings, offset); err != nil {
+			return nil, err
+		}
 	}
-
-	templateValues := configutil.ConfigTemplateSpec{
-		Host:     "localhost:30081",
-		Insecure: true,
+	labels := map[string]string{
+		docker.SandboxNameLabel:       name,
+		docker.SandboxPortOffsetLabel: strconv.Itoa(offset),
+	}
+	if len(fileConfig.HostPorts) > 0 {
+		labels[docker.SandboxHostPortsLabel] = docker.FormatHostPorts(fileConfig.HostPorts)
+	}
+	labels[docker.SandboxConsolePortLabel] = strconv.Itoa(docker.SandboxHostPort(labels, consolePort))
+
+	var env []string
+	adminPort, minioHostPort := docker.SandboxHostPort(labels, flyteAdminPort), docker.SandboxHostPort(labels, minioPort)
+	if adminPort != flyteAdminPort || minioHostPort != minioPort {
+		env = append(env,
+			fmt.Sprintf("FLYTE_HOST=localhost:%v", adminPort),
+			fmt.Sprintf("FLYTE_AWS_ENDPOINT=http://localhost:%v", minioHostPort))
 	}
-	if err := configutil.SetupConfig(configutil.FlytectlConfig, configutil.GetTemplate(), templateValues); err != nil {
+	env = append(env, fileConfig.Env...)
+	env = append(env, sandboxConfig.Env...)
+
+	if err := setupSandboxConfig(name, adminPort); err != nil {
 		return nil, err
 	}
 
-	volumes := docker.Volumes
+	volumes := append([]mount.Mount{}, docker.SandboxVolumes(name)...)
 	if vol, err := MountVolume(sandboxConfig.Source, docker.Source); err != nil {
 		return nil, err
 	} else if vol != nil {
 		volumes = append(volumes, *vol)
 	}
+	fileMounts, err := fileConfig.Mounts(name)
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, fileMounts...)
+
 	sandboxImage := sandboxConfig.Image
 	if len(sandboxImage) == 0 {
-		image, version, err := github.GetFullyQualifiedImageName(defaultImagePrefix, sandboxConfig.Version, defaultImageName, sandboxConfig.Prerelease, g)
+		sandboxImage = fileConfig.Image
+	}
+	if len(sandboxImage) == 0 {
+		version := sandboxConfig.Version
+		if len(version) == 0 {
+			version = fileConfig.Version
+		}
+		image, version, err := github.GetFullyQualifiedImageName(defaultImagePrefix, version, defaultImageName, sandboxConfig.Prerelease, g)
 		if err != nil {
 			return nil, err
 		}
@@ -195,8 +234,8 @@ func startSandbox(ctx context.Context, cli docker.Docker, g github.GHRepoService
 	}
 
 	fmt.Printf("%v booting Flyte-sandbox container\n", emoji.FactoryWorker)
-	ID, err := docker.StartContainer(ctx, cli, volumes, exposedPorts, portBindings, docker.FlyteSandboxClusterName,
-		sandboxImage, sandboxConfig.Env)
+	ID, err := docker.StartContainer(ctx, cli, volumes, exposedPorts, portBindings, docker.SandboxContainerName(name),
+		sandboxImage, env, labels, resources)
 
 	if err != nil {
 		fmt.Printf("%v Something went wrong: Failed to start Sandbox container %v, Please check your docker client and try again. \n", emoji.GrimacingFace, emoji.Whale)
@@ -211,6 +250,49 @@ func startSandbox(ctx context.Context, cli docker.Docker, g github.GHRepoService
 	return logReader, nil
 }
 
+// setupSandboxConfig writes the flytectl config pointing to the admin of the named sandbox, published on the host port
+func setupSandboxConfig(name string, adminPort int) error {
+	if err := util.SetupSandboxDir(name); err != nil {
+		return err
+	}
+	templateValues := configutil.ConfigTemplateSpec{
+		Host:     fmt.Sprintf("localhost:%v", adminPort),
+		Insecure: true,
+	}
+	return configutil.SetupConfig(configutil.SandboxConfigFile(name), configutil.GetTemplate(), templateValues)
+}
+
+// connectCluster switches the local kube context to the named sandbox once its kubeconfig is written, and waits for
+// the Flyte deployment to be ready. The k8s API of the sandbox is published on the host port.
+func connectCluster(ctx context.Context, name string, apiPort int, timeout time.Duration) (k8s.K8s, error) {
+	kubeconfig := configutil.SandboxKubeconfig(name)
+	endpoint := fmt.Sprintf("https://127.0.0.1:%v", apiPort)
+	var k8sClient k8s.K8s
+	err := retry.Do(
+		func() error {
+			if apiPort != k8sPort {
+				if err := setKubeconfigServer(kubeconfig, endpoint); err != nil {
+					return err
+				}
+			}
+			var err error
+			k8sClient, err = k8s.GetK8sClient(kubeconfig, endpoint)
+			return err
+		},
+		retry.Attempts(10),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = UpdateLocalKubeContext(kubeconfig, sandboxDockerContext, docker.SandboxContainerName(name)); err != nil {
+		return nil, err
+	}
+	if err := WatchFlyteDeployment(ctx, k8sClient.CoreV1(), timeout); err != nil {
+		return nil, err
+	}
+	return k8sClient, nil
+}
+
 func primeFlytekitPod(ctx context.Context, podService corev1.PodInterface) {
 	_, err := podService.Create(ctx, &corev1api.Pod{
 		ObjectMeta: v1.ObjectMeta{
@@ -235,7 +317,7 @@ func primeFlytekitPod(ctx context.Context, podService corev1.PodInterface) {
 }
 
 func StartCluster(ctx context.Context, args []string, sandboxConfig *sandboxCmdConfig.Config, primePod bool, defaultImageName string, defaultImagePrefix string, exposedPorts map[nat.Port]struct{}, portBindings map[nat.Port][]nat.PortBinding, consolePort int) error {
-	cli, err := docker.GetDockerClient()
+	cli, err := docker.GetContainerRuntimeClient(sandboxConfig.Runtime)
 	if err != nil {
 		return err
 	}
@@ -251,28 +333,18 @@ func StartCluster(ctx context.Context, args []string, sandboxConfig *sandboxCmdC
 	}
 
 	if reader != nil {
-		var k8sClient k8s.K8s
-		err = retry.Do(
-			func() error {
-				k8sClient, err = k8s.GetK8sClient(docker.Kubeconfig, k8sEndpoint)
-				return err
-			},
-			retry.Attempts(10),
-		)
+		labels, err := sandboxLabels(ctx, cli, sandboxConfig.Name)
 		if err != nil {
 			return err
 		}
-		if err = UpdateLocalKubeContext(sandboxDockerContext, sandboxContextName); err != nil {
-			return err
-		}
-
-		if err := WatchFlyteDeployment(ctx, k8sClient.CoreV1()); err != nil {
+		k8sClient, err := connectCluster(ctx, sandboxConfig.Name, docker.SandboxHostPort(labels, k8sPort), sandboxConfig.Timeout.Duration)
+		if err != nil {
 			return err
 		}
 		if primePod {
 			primeFlytekitPod(ctx, k8sClient.CoreV1().Pods("default"))
 		}
-
+		util.PrintNamedSandboxMessage(docker.SandboxHostPort(labels, consolePort), sandboxConfig.Name)
 	}
 	return nil
 }
@@ -284,12 +356,7 @@ func StartDemoCluster(ctx context.Context, args []string, sandboxConfig *sandbox
 	if err != nil {
 		return err
 	}
-	err = StartCluster(ctx, args, sandboxConfig, primePod, demoImageName, sandboxImagePrefix, exposedPorts, portBindings, util.DemoConsolePort)
-	if err != nil {
-		return err
-	}
-	util.PrintSandboxMessage(util.DemoConsolePort)
-	return nil
+	return StartCluster(ctx, args, sandboxConfig, primePod, demoImageName, sandboxImagePrefix, exposedPorts, portBindings, util.DemoConsolePort)
 }
 
 func StartSandboxCluster(ctx context.Context, args []string, sandboxConfig *sandboxCmdConfig.Config) error {
@@ -299,10 +366,5 @@ func StartSandboxCluster(ctx context.Context, args []string, sandboxConfig *sand
 	if err != nil {
 		return err
 	}
-	err = StartCluster(ctx, args, sandboxConfig, primePod, sandboxImageName, demoImagePrefix, exposedPorts, portBindings, util.SandBoxConsolePort)
-	if err != nil {
-		return err
-	}
-	util.PrintSandboxMessage(util.SandBoxConsolePort)
-	return nil
+	return StartCluster(ctx, args, sandboxConfig, primePod, sandboxImageName, demoImagePrefix, exposedPorts, portBindings, util.SandBoxConsolePort)
 }
@@ -7,7 +7,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/flyteorg/flytectl/pkg/configutil"
 	"github.com/flyteorg/flytectl/pkg/docker"
 	"github.com/flyteorg/flytectl/pkg/docker/mocks"
 	f "github.com/flyteorg/flytectl/pkg/filesystemutils"
@@ -26,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 var content = `
@@ -145,6 +148,66 @@ func TestStartFunc(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Nil(t, reader)
 	})
+	t.Run("Successfully run named sandbox", func(t *testing.T) {
+		sandboxSetup()
+		config.Name = "feature-x"
+		defer func() {
+			config.Name = ""
+			_ = os.RemoveAll(configutil.SandboxDir("feature-x"))
+		}()
+		mockDocker = &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{
+			{ID: "bugfix", Names: []string{"/flyte-sandbox-bugfix"}, Labels: map[string]string{
+				docker.SandboxNameLabel: "bugfix", docker.SandboxPortOffsetLabel: "100"}},
+		}, nil)
+		mockDocker.OnImagePullMatch(ctx, mock.Anything, types.ImagePullOptions{}).Return(os.Stdin, nil)
+		mockDocker.OnContainerCreateMatch(ctx, mock.MatchedBy(func(c *container.Config) bool {
+			return c.Labels[docker.SandboxNameLabel] == "feature-x" && c.Labels[docker.SandboxPortOffsetLabel] == "200" &&
+				c.Labels[docker.SandboxConsolePortLabel] == "30281"
+		}), mock.MatchedBy(func(h *container.HostConfig) bool {
+			return h.PortBindings["30086/tcp"][0].HostPort == "30286" && h.Mounts[0].Source == configutil.SandboxDir("feature-x")
+		}), mock.Anything, mock.Anything, "flyte-sandbox-feature-x").Return(container.ContainerCreateCreatedBody{
+			ID: "Hello",
+		}, nil)
+		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
+		mockDocker.OnContainerLogsMatch(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		_, err := startSandbox(ctx, mockDocker, githubMock, os.Stdin, config, sandboxImageName, defaultImagePrefix, exposedPorts, portBindings, util.SandBoxConsolePort)
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(configutil.SandboxConfigFile("feature-x"))
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "endpoint: localhost:30281")
+		assert.Equal(t, "30086", portBindings["30086/tcp"][0].HostPort)
+	})
+	t.Run("Successfully run sandbox with config file", func(t *testing.T) {
+		sandboxSetup()
+		config.ConfigFile = writeSandboxConfig(t, "resources:\n  cpus: 4\nhostPorts:\n  30081: 31081\n  30080: 31080\n")
+		defer func() { config.ConfigFile = "" }()
+		mockDocker = &mocks.Docker{}
+		mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return([]types.Container{}, nil)
+		mockDocker.OnImagePullMatch(ctx, mock.Anything, types.ImagePullOptions{}).Return(os.Stdin, nil)
+		mockDocker.OnContainerCreateMatch(ctx, mock.MatchedBy(func(c *container.Config) bool {
+			return c.Labels[docker.SandboxHostPortsLabel] == "30080=31080,30081=31081" &&
+				c.Labels[docker.SandboxConsolePortLabel] == "31081"
+		}), mock.MatchedBy(func(h *container.HostConfig) bool {
+			return h.Resources.NanoCPUs == 4000000000 && len(h.PortBindings["30081/tcp"]) == 2
+		}), mock.Anything, mock.Anything, "flyte-sandbox").Return(container.ContainerCreateCreatedBody{
+			ID: "Hello",
+		}, nil)
+		mockDocker.OnContainerStart(ctx, "Hello", types.ContainerStartOptions{}).Return(nil)
+		mockDocker.OnContainerLogsMatch(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		_, err := startSandbox(ctx, mockDocker, githubMock, os.Stdin, config, sandboxImageName, defaultImagePrefix, exposedPorts, portBindings, util.SandBoxConsolePort)
+		assert.Nil(t, err)
+		content, err := ioutil.ReadFile(configutil.SandboxConfigFile(""))
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), "endpoint: localhost:31081")
+	})
+	t.Run("Invalid sandbox name", func(t *testing.T) {
+		sandboxSetup()
+		config.Name = "Feature_X"
+		defer func() { config.Name = "" }()
+		_, err := startSandbox(ctx, mockDocker, githubMock, os.Stdin, config, sandboxImageName, defaultImagePrefix, exposedPorts, portBindings, util.SandBoxConsolePort)
+		assert.EqualError(t, err, `invalid sandbox name "Feature_X". Only lowercase alphanumeric characters and '-' are allowed`)
+	})
 	t.Run("Successfully run demo cluster with source code", func(t *testing.T) {
 		sandboxCmdConfig.DefaultConfig.Source = f.UserHomeDir()
 		sandboxCmdConfig.DefaultConfig.Version = ""
@@ -337,6 +400,19 @@ func TestStartFunc(t *testing.T) {
 	})
 }
 
+func TestSetKubeconfigServer(t *testing.T) {
+	kubeconfig := f.FilePathJoin(t.TempDir(), "k3s.yaml")
+	assert.Nil(t, ioutil.WriteFile(kubeconfig, []byte(content), os.ModePerm))
+	assert.Nil(t, setKubeconfigServer(kubeconfig, "https://127.0.0.1:30186"))
+	cfg, err := clientcmd.LoadFromFile(kubeconfig)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://127.0.0.1:30186", cfg.Clusters["default"].Server)
+
+	empty := f.FilePathJoin(t.TempDir(), "k3s.yaml")
+	assert.Nil(t, ioutil.WriteFile(empty, []byte(""), os.ModePerm))
+	assert.NotNil(t, setKubeconfigServer(empty, "https://127.0.0.1:30186"))
+}
+
 func TestMonitorFlyteDeployment(t *testing.T) {
 	t.Run("Monitor k8s deployment fail because of storage", func(t *testing.T) {
 		ctx := context.Background()
@@ -359,7 +435,7 @@ func TestMonitorFlyteDeployment(t *testing.T) {
 			t.Error(err)
 		}
 
-		err = WatchFlyteDeployment(ctx, client.CoreV1())
+		err = WatchFlyteDeployment(ctx, client.CoreV1(), time.Minute)
 		assert.NotNil(t, err)
 
 	})
@@ -382,7 +458,7 @@ func TestMonitorFlyteDeployment(t *testing.T) {
 			t.Error(err)
 		}
 
-		err = WatchFlyteDeployment(ctx, client.CoreV1())
+		err = WatchFlyteDeployment(ctx, client.CoreV1(), time.Minute)
 		assert.Nil(t, err)
 
 	})
@@ -11,8 +11,9 @@ import (
 	"github.com/flyteorg/flytectl/pkg/k8s"
 )
 
-func Teardown(ctx context.Context, cli docker.Docker) error {
-	c, err := docker.GetSandbox(ctx, cli)
+// Teardown removes the container, the config and the kube context of the named sandbox
+func Teardown(ctx context.Context, cli docker.Docker, name string) error {
+	c, err := docker.GetSandbox(ctx, cli, name)
 	if err != nil {
 		return err
 	}
@@ -23,17 +24,17 @@ func Teardown(ctx context.Context, cli docker.Docker) error {
 			return err
 		}
 	}
-	if err := configutil.ConfigCleanup(); err != nil {
+	if err := configutil.SandboxConfigCleanup(name); err != nil {
 		fmt.Printf("Config cleanup failed. Which Failed due to %v \n ", err)
 	}
-	if err := removeSandboxKubeContext(); err != nil {
+	if err := removeSandboxKubeContext(name); err != nil {
 		fmt.Printf("Kubecontext cleanup failed. Which Failed due to %v \n ", err)
 	}
 	fmt.Printf("%v %v Sandbox cluster is removed successfully. \n", emoji.Broom, emoji.Broom)
 	return nil
 }
 
-func removeSandboxKubeContext() error {
+func removeSandboxKubeContext(name string) error {
 	k8sCtxMgr := k8s.NewK8sContextManager()
-	return k8sCtxMgr.RemoveContext(sandboxContextName)
+	return k8sCtxMgr.RemoveContext(docker.SandboxContainerName(name))
 }
@@ -28,12 +28,12 @@ func TestTearDownFunc(t *testing.T) {
 	mockDocker := &mocks.Docker{}
 	mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(containers, nil)
 	mockDocker.OnContainerRemove(ctx, mock.Anything, types.ContainerRemoveOptions{Force: true}).Return(fmt.Errorf("err"))
-	err := Teardown(ctx, mockDocker)
+	err := Teardown(ctx, mockDocker, "")
 	assert.NotNil(t, err)
 
 	mockDocker = &mocks.Docker{}
 	mockDocker.OnContainerList(ctx, types.ContainerListOptions{All: true}).Return(nil, fmt.Errorf("err"))
-	err = Teardown(ctx, mockDocker)
+	err = Teardown(ctx, mockDocker, "")
 	assert.NotNil(t, err)
 
 	mockDocker = &mocks.Docker{}
@@ -42,7 +42,7 @@ func TestTearDownFunc(t *testing.T) {
 	mockK8sContextMgr := &k8sMocks.ContextOps{}
 	mockK8sContextMgr.OnRemoveContext(mock.Anything).Return(nil)
 	k8s.ContextMgr = mockK8sContextMgr
-	err = Teardown(ctx, mockDocker)
+	err = Teardown(ctx, mockDocker, "")
 	assert.Nil(t, err)
 
 }
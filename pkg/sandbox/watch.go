@@ -0,0 +1,299 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kataras/tablewriter"
+	"golang.org/x/term"
+	corev1api "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// crashLoopRestarts is the number of restarts of a crash looping container after which the deployment is failed.
+	crashLoopRestarts = 3
+	crashLoopBackOff  = "CrashLoopBackOff"
+)
+
+// podReport is the readiness of a pod of the Flyte deployment
+type podReport struct {
+	name     string
+	phase    string
+	ready    int
+	total    int
+	restarts int32
+	reason   string
+}
+
+func (r podReport) String() string {
+	line := fmt.Sprintf("%v: %v, %v/%v containers ready, %v restarts", r.name, r.phase, r.ready, r.total, r.restarts)
+	if len(r.reason) > 0 {
+		line = fmt.Sprintf("%v (%v)", line, r.reason)
+	}
+	return line
+}
+
+// deploymentWatcher follows the pods of the Flyte deployment and reports their readiness. On a terminal the report is
+// a table redrawn in place, otherwise a line is printed for every change of a pod so that it reads well in CI logs.
+type deploymentWatcher struct {
+	client corev1.CoreV1Interface
+	out    io.Writer
+	tty    bool
+	pods   map[string]corev1api.Pod
+	// printed holds the last line printed for every pod in non-TTY mode.
+	printed map[string]string
+}
+
+// WatchFlyteDeployment waits for all the pods of the Flyte deployment to be ready. It fails when a pod is crash
+// looping, when the node runs out of disk or when the timeout expires, a zero timeout meaning no timeout.
+func WatchFlyteDeployment(ctx context.Context, appsClient corev1.CoreV1Interface, timeout time.Duration) error {
+	w := &deploymentWatcher{
+		client:  appsClient,
+		out:     os.Stdout,
+		tty:     term.IsTerminal(int(os.Stdout.Fd())),
+		pods:    map[string]corev1api.Pod{},
+		printed: map[string]string{},
+	}
+	return w.watch(ctx, timeout)
+}
+
+func (w *deploymentWatcher) watch(ctx context.Context, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for {
+		done, err := w.watchPods(ctx)
+		if ctx.Err() != nil {
+			return w.timeoutError(timeout)
+		}
+		if done || err != nil {
+			return err
+		}
+		// The watch was closed by the API server, hence it is started again from a fresh list of the pods.
+	}
+}
+
+// watchPods lists the pods and follows their changes until they are all ready or the watch is closed.
+func (w *deploymentWatcher) watchPods(ctx context.Context) (bool, error) {
+	pods, err := getFlyteDeployment(ctx, w.client)
+	if err != nil {
+		return false, err
+	}
+	w.pods = map[string]corev1api.Pod{}
+	for _, pod := range pods.Items {
+		w.pods[pod.Name] = pod
+	}
+	if done, err := w.check(ctx); done || err != nil {
+		return done, err
+	}
+
+	watcher, err := w.client.Pods(flyteNamespace).Watch(ctx, v1.ListOptions{ResourceVersion: pods.ResourceVersion})
+	if err != nil {
+		return false, err
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			pod, isPod := event.Object.(*corev1api.Pod)
+			if !isPod {
+				// Error events, e.g. for an expired resource version, are recovered from with a new list.
+				return false, nil
+			}
+			if event.Type == watch.Deleted {
+				delete(w.pods, pod.Name)
+			} else {
+				w.pods[pod.Name] = *pod
+			}
+			if done, err := w.check(ctx); done || err != nil {
+				return done, err
+			}
+		}
+	}
+}
+
+// check reports the readiness of the pods and returns true once they are all ready.
+func (w *deploymentWatcher) check(ctx context.Context) (bool, error) {
+	isTaint, err := isNodeTainted(ctx, w.client)
+	if err != nil {
+		return false, err
+	}
+	if isTaint {
+		return false, fmt.Errorf("docker sandbox doesn't have sufficient memory available. Please run docker system prune -a --volumes or flytectl sandbox doctor for a diagnosis")
+	}
+
+	reports := w.reports(ctx)
+	w.render(reports)
+	for _, pod := range w.pods {
+		if err := crashLoopError(pod); err != nil {
+			return false, err
+		}
+	}
+	if len(w.pods) == 0 {
+		return false, nil
+	}
+	for _, pod := range w.pods {
+		if !isPodReady(pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// reports returns the readiness of the pods sorted by name. The reason of a pod is the one of its waiting or last
+// terminated container if any, e.g. ImagePullBackOff or OOMKilled, or else the reason of its last event.
+func (w *deploymentWatcher) reports(ctx context.Context) []podReport {
+	eventReasons := w.lastEventReasons(ctx)
+	reports := make([]podReport, 0, len(w.pods))
+	for _, pod := range w.pods {
+		report := podReport{
+			name:  pod.Name,
+			phase: string(pod.Status.Phase),
+			total: len(pod.Spec.Containers),
+		}
+		if report.total < len(pod.Status.ContainerStatuses) {
+			report.total = len(pod.Status.ContainerStatuses)
+		}
+		for _, c := range pod.Status.ContainerStatuses {
+			if c.Ready {
+				report.ready++
+			}
+			report.restarts += c.RestartCount
+			if reason := containerReason(c); len(reason) > 0 && len(report.reason) == 0 {
+				report.reason = reason
+			}
+		}
+		if len(report.reason) == 0 {
+			report.reason = eventReasons[pod.Name]
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].name < reports[j].name
+	})
+	return reports
+}
+
+// lastEventReasons returns the reason of the last event of every pod. Events are informative only, hence failing to
+// list them doesn't fail the watch.
+func (w *deploymentWatcher) lastEventReasons(ctx context.Context) map[string]string {
+	reasons := map[string]string{}
+	events, err := w.client.Events(flyteNamespace).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return reasons
+	}
+	last := map[string]time.Time{}
+	for _, event := range events.Items {
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		timestamp := event.LastTimestamp.Time
+		if timestamp.IsZero() {
+			timestamp = event.EventTime.Time
+		}
+		if previous, ok := last[event.InvolvedObject.Name]; ok && previous.After(timestamp) {
+			continue
+		}
+		last[event.InvolvedObject.Name] = timestamp
+		reasons[event.InvolvedObject.Name] = event.Reason
+	}
+	return reasons
+}
+
+func (w *deploymentWatcher) render(reports []podReport) {
+	if !w.tty {
+		if len(reports) == 0 && len(w.printed) == 0 {
+			w.printLine("", "k8s: This might take a little bit. Bootstrapping")
+		}
+		for _, report := range reports {
+			w.printLine(report.name, report.String())
+		}
+		return
+	}
+
+	table := tablewriter.NewWriter(w.out)
+	table.SetHeader([]string{"Service", "Status", "Ready", "Restarts", "Reason"})
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	if len(reports) == 0 {
+		table.Append([]string{"k8s: This might take a little bit", "Bootstrapping", "", "", ""})
+	}
+	for _, report := range reports {
+		table.Append([]string{report.name, report.phase, fmt.Sprintf("%v/%v", report.ready, report.total),
+			fmt.Sprintf("%v", report.restarts), report.reason})
+	}
+	// Clear the terminal before redrawing the table
+	_, _ = io.WriteString(w.out, "\x1b[3;J\x1b[H\x1b[2J")
+	table.Render()
+}
+
+// printLine prints the line of a pod unless it is the same as the last one printed for that pod.
+func (w *deploymentWatcher) printLine(pod, line string) {
+	if w.printed[pod] == line {
+		return
+	}
+	w.printed[pod] = line
+	_, _ = fmt.Fprintln(w.out, line)
+}
+
+// timeoutError lists the pods which aren't ready when the timeout expires.
+func (w *deploymentWatcher) timeoutError(timeout time.Duration) error {
+	if len(w.pods) == 0 {
+		return fmt.Errorf("timed out after %v waiting for the Flyte deployment. No pod was created in the %v namespace", timeout, flyteNamespace)
+	}
+	var notReady []string
+	for _, report := range w.reports(context.Background()) {
+		if !isPodReady(w.pods[report.name]) {
+			notReady = append(notReady, report.String())
+		}
+	}
+	return fmt.Errorf("timed out after %v waiting for the Flyte deployment. Pods not ready:\n%v", timeout, strings.Join(notReady, "\n"))
+}
+
+func containerReason(c corev1api.ContainerStatus) string {
+	if c.State.Waiting != nil && len(c.State.Waiting.Reason) > 0 {
+		return c.State.Waiting.Reason
+	}
+	if c.State.Terminated != nil && len(c.State.Terminated.Reason) > 0 {
+		return c.State.Terminated.Reason
+	}
+	if c.RestartCount > 0 && c.LastTerminationState.Terminated != nil {
+		return c.LastTerminationState.Terminated.Reason
+	}
+	return ""
+}
+
+// crashLoopError returns the diagnostics of the first container of the pod which keeps crashing, if any.
+func crashLoopError(pod corev1api.Pod) error {
+	for _, c := range pod.Status.ContainerStatuses {
+		if c.State.Waiting == nil || c.State.Waiting.Reason != crashLoopBackOff || c.RestartCount < crashLoopRestarts {
+			continue
+		}
+		details := ""
+		if last := c.LastTerminationState.Terminated; last != nil {
+			details = fmt.Sprintf(", last terminated with %v (exit code %v)", last.Reason, last.ExitCode)
+			if len(last.Message) > 0 {
+				details = fmt.Sprintf("%v: %v", details, strings.TrimSpace(last.Message))
+			}
+		}
+		return fmt.Errorf("pod %v is crash looping: container %v restarted %v times%v. Check its logs with kubectl logs -n %v %v -c %v --previous",
+			pod.Name, c.Name, c.RestartCount, details, flyteNamespace, pod.Name, c.Name)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1api "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func flytePod(name string, phase corev1api.PodPhase, statuses ...corev1api.ContainerStatus) *corev1api.Pod {
+	return &corev1api.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: flyteNamespace},
+		Spec:       corev1api.PodSpec{Containers: []corev1api.Container{{Name: name}}},
+		Status:     corev1api.PodStatus{Phase: phase, ContainerStatuses: statuses},
+	}
+}
+
+func newTestWatcher(client *testclient.Clientset, out *bytes.Buffer) *deploymentWatcher {
+	return &deploymentWatcher{
+		client:  client.CoreV1(),
+		out:     out,
+		pods:    map[string]corev1api.Pod{},
+		printed: map[string]string{},
+	}
+}
+
+func TestDeploymentWatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Ready after pod update", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(flytePod("flyteadmin", corev1api.PodPending,
+			corev1api.ContainerStatus{Name: "flyteadmin", State: corev1api.ContainerState{
+				Waiting: &corev1api.ContainerStateWaiting{Reason: "ContainerCreating"}}}))
+		var out bytes.Buffer
+		w := newTestWatcher(client, &out)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = client.CoreV1().Pods(flyteNamespace).Update(ctx, flytePod("flyteadmin", corev1api.PodRunning,
+				corev1api.ContainerStatus{Name: "flyteadmin", Ready: true}), v1.UpdateOptions{})
+		}()
+		assert.Nil(t, w.watch(ctx, time.Minute))
+		assert.Equal(t, `flyteadmin: Pending, 0/1 containers ready, 0 restarts (ContainerCreating)
+flyteadmin: Running, 1/1 containers ready, 0 restarts
+`, out.String())
+	})
+
+	t.Run("Crash looping pod", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(flytePod("flyteadmin", corev1api.PodRunning,
+			corev1api.ContainerStatus{Name: "flyteadmin", RestartCount: 4,
+				State: corev1api.ContainerState{Waiting: &corev1api.ContainerStateWaiting{Reason: crashLoopBackOff}},
+				LastTerminationState: corev1api.ContainerState{Terminated: &corev1api.ContainerStateTerminated{
+					Reason: "OOMKilled", ExitCode: 137}}}))
+		var out bytes.Buffer
+		err := newTestWatcher(client, &out).watch(ctx, time.Minute)
+		assert.EqualError(t, err, "pod flyteadmin is crash looping: container flyteadmin restarted 4 times, last terminated "+
+			"with OOMKilled (exit code 137). Check its logs with kubectl logs -n flyte flyteadmin -c flyteadmin --previous")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(
+			flytePod("flyteadmin", corev1api.PodRunning, corev1api.ContainerStatus{Name: "flyteadmin", Ready: true}),
+			flytePod("minio", corev1api.PodPending, corev1api.ContainerStatus{Name: "minio", State: corev1api.ContainerState{
+				Waiting: &corev1api.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}))
+		var out bytes.Buffer
+		err := newTestWatcher(client, &out).watch(ctx, 100*time.Millisecond)
+		assert.EqualError(t, err, "timed out after 100ms waiting for the Flyte deployment. Pods not ready:\n"+
+			"minio: Pending, 0/1 containers ready, 0 restarts (ImagePullBackOff)")
+	})
+
+	t.Run("Timeout without pods", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestWatcher(testclient.NewSimpleClientset(), &out).watch(ctx, 100*time.Millisecond)
+		assert.EqualError(t, err, "timed out after 100ms waiting for the Flyte deployment. No pod was created in the flyte namespace")
+		assert.Equal(t, "k8s: This might take a little bit. Bootstrapping\n", out.String())
+	})
+
+	t.Run("Event reason", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(flytePod("postgres", corev1api.PodPending),
+			&corev1api.Event{ObjectMeta: v1.ObjectMeta{Name: "e1", Namespace: flyteNamespace}, Reason: "Scheduled",
+				InvolvedObject: corev1api.ObjectReference{Kind: "Pod", Name: "postgres"}, LastTimestamp: v1.NewTime(time.Unix(10, 0))},
+			&corev1api.Event{ObjectMeta: v1.ObjectMeta{Name: "e2", Namespace: flyteNamespace}, Reason: "Pulling",
+				InvolvedObject: corev1api.ObjectReference{Kind: "Pod", Name: "postgres"}, LastTimestamp: v1.NewTime(time.Unix(20, 0))})
+		var out bytes.Buffer
+		w := newTestWatcher(client, &out)
+		pod := flytePod("postgres", corev1api.PodPending)
+		w.pods[pod.Name] = *pod
+		assert.Equal(t, []podReport{{name: "postgres", phase: "Pending", total: 1, reason: "Pulling"}}, w.reports(ctx))
+	})
+
+	t.Run("Terminal table", func(t *testing.T) {
+		client := testclient.NewSimpleClientset(flytePod("flyteadmin", corev1api.PodRunning,
+			corev1api.ContainerStatus{Name: "flyteadmin", Ready: true}))
+		var out bytes.Buffer
+		w := newTestWatcher(client, &out)
+		w.tty = true
+		assert.Nil(t, w.watch(ctx, time.Minute))
+		assert.Contains(t, out.String(), "\x1b[2J")
+		assert.Contains(t, out.String(), "flyteadmin")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/flyteorg/flytectl/pkg/configutil"
@@ -51,18 +52,46 @@ func SetupFlyteDir() error {
 	return nil
 }
 
+// SetupSandboxDir will create the dir of the named sandbox if not exist
+func SetupSandboxDir(name string) error {
+	if len(name) == 0 {
+		return SetupFlyteDir()
+	}
+	kubeconfig := configutil.SandboxKubeconfig(name)
+	if err := os.MkdirAll(filepath.Dir(kubeconfig), os.ModePerm); err != nil {
+		return err
+	}
+	if _, err := os.Stat(kubeconfig); err != nil {
+		if os.IsNotExist(err) {
+			if err := ioutil.WriteFile(kubeconfig, []byte(""), os.ModePerm); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // PrintSandboxMessage will print sandbox success message
 func PrintSandboxMessage(flyteConsolePort int) {
+	PrintNamedSandboxMessage(flyteConsolePort, "")
+}
+
+// PrintNamedSandboxMessage will print the success message of the named sandbox
+func PrintNamedSandboxMessage(flyteConsolePort int, name string) {
+	sandboxKubeconfig := docker.Kubeconfig
+	if len(name) > 0 {
+		sandboxKubeconfig = configutil.SandboxKubeconfig(name)
+	}
 	kubeconfig := strings.Join([]string{
 		"$KUBECONFIG",
 		f.FilePathJoin(f.UserHomeDir(), ".kube", "config"),
-		docker.Kubeconfig,
+		sandboxKubeconfig,
 	}, ":")
 	successMsg := fmt.Sprintf("%v http://localhost:%v/console", ProgressSuccessMessage, flyteConsolePort)
 	fmt.Printf("%v %v %v %v %v \n", emoji.ManTechnologist, successMsg, emoji.Rocket, emoji.Rocket, emoji.PartyPopper)
 	fmt.Printf("Add KUBECONFIG and FLYTECTL_CONFIG to your environment variable \n")
 	fmt.Printf("export KUBECONFIG=%v \n", kubeconfig)
-	fmt.Printf("export FLYTECTL_CONFIG=%v \n", configutil.FlytectlConfig)
+	fmt.Printf("export FLYTECTL_CONFIG=%v \n", configutil.SandboxConfigFile(name))
 }
 
 // SendRequest will create request and return the response
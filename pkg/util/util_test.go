@@ -1,8 +1,11 @@
 package util
 
 import (
+	"os"
 	"testing"
 
+	"github.com/flyteorg/flytectl/pkg/configutil"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,10 +26,20 @@ func TestSetupFlyteDir(t *testing.T) {
 	assert.Nil(t, SetupFlyteDir())
 }
 
+func TestSetupSandboxDir(t *testing.T) {
+	defer func() { _ = os.RemoveAll(configutil.SandboxDir("feature-x")) }()
+	assert.Nil(t, SetupSandboxDir("feature-x"))
+	_, err := os.Stat(configutil.SandboxKubeconfig("feature-x"))
+	assert.Nil(t, err)
+}
+
 func TestPrintSandboxMessage(t *testing.T) {
 	t.Run("Print Sandbox Message", func(t *testing.T) {
 		PrintSandboxMessage(SandBoxConsolePort)
 	})
+	t.Run("Print named Sandbox Message", func(t *testing.T) {
+		PrintNamedSandboxMessage(SandBoxConsolePort+100, "feature-x")
+	})
 }
 
 func TestSendRequest(t *testing.T) {
@@ -0,0 +1,45 @@
+// Package workflowutil provides helpers for traversing the nodes of workflow templates
+package workflowutil
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// VisitReferences calls visit for every task, subworkflow and launch plan identifier referenced by the nodes of the
+// template, including the nodes nested in branches and the failure node. The identifiers are passed by reference and
+// can be modified in place.
+func VisitReferences(template *core.WorkflowTemplate, visit func(id *core.Identifier)) {
+	if template == nil {
+		return
+	}
+	for _, node := range template.Nodes {
+		visitNode(node, visit)
+	}
+	visitNode(template.FailureNode, visit)
+}
+
+func visitNode(node *core.Node, visit func(id *core.Identifier)) {
+	if node == nil {
+		return
+	}
+	switch target := node.Target.(type) {
+	case *core.Node_TaskNode:
+		if id := target.TaskNode.GetReferenceId(); id != nil {
+			visit(id)
+		}
+	case *core.Node_WorkflowNode:
+		if id := target.WorkflowNode.GetSubWorkflowRef(); id != nil {
+			visit(id)
+		}
+		if id := target.WorkflowNode.GetLaunchplanRef(); id != nil {
+			visit(id)
+		}
+	case *core.Node_BranchNode:
+		ifElse := target.BranchNode.GetIfElse()
+		visitNode(ifElse.GetCase().GetThenNode(), visit)
+		for _, block := range ifElse.GetOther() {
+			visitNode(block.GetThenNode(), visit)
+		}
+		visitNode(ifElse.GetElseNode(), visit)
+	}
+}
@@ -0,0 +1,56 @@
+package workflowutil
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func taskNode(name string) *core.Node {
+	return &core.Node{
+		Target: &core.Node_TaskNode{TaskNode: &core.TaskNode{
+			Reference: &core.TaskNode_ReferenceId{ReferenceId: &core.Identifier{ResourceType: core.ResourceType_TASK, Name: name}},
+		}},
+	}
+}
+
+func TestVisitReferences(t *testing.T) {
+	template := &core.WorkflowTemplate{
+		Nodes: []*core.Node{
+			{Id: "start-node"},
+			taskNode("t1"),
+			{
+				Target: &core.Node_WorkflowNode{WorkflowNode: &core.WorkflowNode{
+					Reference: &core.WorkflowNode_SubWorkflowRef{SubWorkflowRef: &core.Identifier{ResourceType: core.ResourceType_WORKFLOW, Name: "sub"}},
+				}},
+			},
+			{
+				Target: &core.Node_WorkflowNode{WorkflowNode: &core.WorkflowNode{
+					Reference: &core.WorkflowNode_LaunchplanRef{LaunchplanRef: &core.Identifier{ResourceType: core.ResourceType_LAUNCH_PLAN, Name: "lp"}},
+				}},
+			},
+			{
+				Target: &core.Node_BranchNode{BranchNode: &core.BranchNode{IfElse: &core.IfElseBlock{
+					Case:    &core.IfBlock{ThenNode: taskNode("then")},
+					Other:   []*core.IfBlock{{ThenNode: taskNode("other")}},
+					Default: &core.IfElseBlock_ElseNode{ElseNode: taskNode("else")},
+				}}},
+			},
+		},
+		FailureNode: taskNode("failure"),
+	}
+
+	var names []string
+	VisitReferences(template, func(id *core.Identifier) {
+		names = append(names, id.Name)
+		id.Version = "v2"
+	})
+	assert.Equal(t, []string{"t1", "sub", "lp", "then", "other", "else", "failure"}, names)
+	assert.Equal(t, "v2", template.Nodes[1].GetTaskNode().GetReferenceId().Version)
+	assert.Equal(t, "v2", template.FailureNode.GetTaskNode().GetReferenceId().Version)
+
+	VisitReferences(nil, func(id *core.Identifier) {
+		assert.Fail(t, "unexpected visit")
+	})
+}